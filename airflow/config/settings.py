@@ -58,6 +58,8 @@ class Settings(BaseSettings):
     # ETL / Scheduler
     # ------------------------
     TRAIN_INTERVAL_MINUTES: int = 240
+    # NDJSON engagement files appended by the app server
+    ENGAGEMENT_DIR: str = os.getenv("ENGAGEMENT_DIR", "/opt/airflow/data/engagement")
 
     # ------------------------
     # Logging
@@ -0,0 +1,67 @@
+from etl_base import save_preprocessed
+from config.settings import settings
+from datetime import date, timedelta
+import glob
+import os
+
+import pandas as pd
+
+OUTPUT_PATH = "/tmp/engagement_{day}.parquet"
+
+
+def load_engagement(day: str) -> pd.DataFrame:
+    """
+    Read one day of engagement events. The app server writes one NDJSON
+    file per day and host, so every host's file for the day is read.
+    """
+    pattern = os.path.join(settings.ENGAGEMENT_DIR, f"engagement-{day}.*.ndjson")
+    frames = [pd.read_json(path, lines=True) for path in sorted(glob.glob(pattern))]
+    frames = [df for df in frames if not df.empty]
+    if not frames:
+        return pd.DataFrame(
+            columns=[
+                "kind",
+                "user",
+                "post_id",
+                "surface",
+                "dwell_ms",
+                "reaction",
+                "recommendation_id",
+                "occurred_at",
+            ]
+        )
+
+    df = pd.concat(frames, ignore_index=True)
+    df["occurred_at"] = pd.to_datetime(df["occurred_at"], utc=True)
+    return df
+
+
+def run_engagement_etl(day: str = None):
+    """
+    ETL pipeline for engagement events. Defaults to yesterday, the last
+    day whose files are complete.
+    """
+    day = day or (date.today() - timedelta(days=1)).isoformat()
+
+    # --------------------------
+    # 1. Fetch raw events
+    # --------------------------
+    df = load_engagement(day)
+
+    # --------------------------
+    # 2. Order events across hosts
+    # --------------------------
+    df = df.sort_values("occurred_at", kind="stable", ignore_index=True)
+
+    # --------------------------
+    # 3. Save preprocessed batch
+    # --------------------------
+    output_path = OUTPUT_PATH.format(day=day)
+    os.makedirs(os.path.dirname(output_path), exist_ok=True)
+    save_preprocessed(df, output_path)
+
+    return output_path
+
+
+if __name__ == "__main__":
+    run_engagement_etl()
@@ -2,26 +2,33 @@ package server
 
 import (
 	"context"
-	"log"
 	"os"
 	"os/signal"
+	"strings"
 	"syscall"
 	"time"
 
+	"github.com/gofiber/contrib/otelfiber/v2"
 	"github.com/gofiber/fiber/v2"
 	"github.com/gofiber/fiber/v2/middleware/cors"
-	"github.com/gofiber/fiber/v2/middleware/monitor"
-	"github.com/gofiber/fiber/v2/middleware/pprof"
 	"github.com/gofiber/swagger"
 
 	"github.com/maulana1k/forum-app/internal/app/container"
 	"github.com/maulana1k/forum-app/internal/app/middleware"
 	"github.com/maulana1k/forum-app/internal/app/routes"
+	"github.com/maulana1k/forum-app/internal/app/rpc"
+	"github.com/maulana1k/forum-app/internal/app/worker"
 	"github.com/maulana1k/forum-app/internal/config"
+	"github.com/maulana1k/forum-app/internal/pkg/pagination"
 	"github.com/maulana1k/forum-app/internal/pkg/utils"
 	"github.com/maulana1k/forum-app/internal/provider/broker"
+	"github.com/maulana1k/forum-app/internal/provider/cache"
 	"github.com/maulana1k/forum-app/internal/provider/database"
+	"github.com/maulana1k/forum-app/internal/provider/engagement"
 	"github.com/maulana1k/forum-app/internal/provider/grpc"
+	"github.com/maulana1k/forum-app/internal/provider/monitoring"
+	"github.com/maulana1k/forum-app/internal/provider/storage"
+	"github.com/maulana1k/forum-app/internal/provider/tracing"
 
 	_ "github.com/maulana1k/forum-app/docs"
 )
@@ -29,55 +36,161 @@ import (
 func Run() {
 
 	cfg := config.LoadConfig()
-	/*
 
-	 */
+	utils.InitLogger(cfg.LogFormat, cfg.LogLevel)
+	pagination.Configure(cfg.Pagination.CursorSecret, cfg.Pagination.AllowOffset)
+
+	shutdownTracer, err := tracing.InitTracer(context.Background(), cfg.Tracing.Exporter, cfg.Tracing.Endpoint, cfg.Tracing.SampleRatio)
+	if err != nil {
+		utils.Logger.WithError(err).Fatal("failed to initialize tracing")
+	}
+	defer func() {
+		ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+		if err := shutdownTracer(ctx); err != nil {
+			utils.Logger.WithError(err).Error("error flushing traces")
+		}
+	}()
+
 	grpc := grpc.NewGRPCClient(cfg.GRPCAddress)
 	db := database.NewDBInstance(cfg.DBUri)
 	broker := broker.NewRabbitMQ(cfg.BrokerAddress)
-	c := container.NewContainer(db.DB, grpc, broker)
-	// prometheus := fiberprometheus.New("forum_app")
+	store := cache.New(cfg.Cache.Driver, cfg.Cache.RedisAddr, cfg.Cache.RedisPassword)
+	defer store.Close()
+	blobs, err := storage.New(storage.Config{
+		Driver:      cfg.Media.Driver,
+		PublicURL:   cfg.Media.PublicURL,
+		LocalDir:    cfg.Media.LocalDir,
+		UploadURL:   cfg.Media.UploadURL,
+		DownloadURL: cfg.Media.DownloadURL,
+		Secret:      cfg.Media.UploadSecret,
+		S3Endpoint:  cfg.Media.S3Endpoint,
+		S3AccessKey: cfg.Media.S3AccessKey,
+		S3SecretKey: cfg.Media.S3SecretKey,
+		S3Bucket:    cfg.Media.S3Bucket,
+		S3Region:    cfg.Media.S3Region,
+		S3UseSSL:    cfg.Media.S3UseSSL,
+	})
+	if err != nil {
+		utils.Logger.WithError(err).Fatal("failed to initialize media storage")
+	}
+	var sinks []engagement.Sink
+	if cfg.Engagement.Broker {
+		sinks = append(sinks, engagement.NewBrokerSink(broker))
+	}
+	if cfg.Engagement.Dir != "" {
+		fileSink, err := engagement.NewFileSink(cfg.Engagement.Dir)
+		if err != nil {
+			utils.Logger.WithError(err).Fatal("failed to initialize engagement sink")
+		}
+		sinks = append(sinks, fileSink)
+	}
+	recorder := engagement.NewRecorder(engagement.Options{
+		Secret:        cfg.Engagement.Secret,
+		BatchSize:     cfg.Engagement.BatchSize,
+		FlushInterval: cfg.Engagement.FlushInterval,
+	}, sinks...)
+	// Runs after the server has stopped, so no event is lost.
+	defer recorder.Close()
+
+	c := container.NewContainer(db.DB, grpc, broker, store, blobs, recorder, cfg)
 	defer db.Close()
 
-	utils.InitLogger()
-
+	cfg.AppConfig.ErrorHandler = middleware.ErrorHandler
 	app := fiber.New(cfg.AppConfig)
-	// prometheus.RegisterAt(app, "/metrics")
 
-	// app.Use(prometheus.Middleware)
+	app.Use(otelfiber.Middleware(otelfiber.WithoutMetrics(true)))
+	app.Use(monitoring.FiberMiddleware())
 	app.Use(middleware.LogrusMiddleware)
 	app.Use(cors.New(cfg.CorsConfig))
-	app.Use(pprof.New())
 
 	routes.Register(app, c)
 
+	// The local driver's objects are served by the app; S3 serves its own.
+	// Private objects are only reachable through signed download links;
+	// the URI path is already decoded and normalised.
+	if local, ok := blobs.(*storage.Local); ok {
+		publicPrefix := strings.TrimRight(cfg.Media.PublicURL, "/") + "/"
+		app.Static(cfg.Media.PublicURL, local.Dir(), fiber.Static{
+			Next: func(c *fiber.Ctx) bool {
+				key := strings.TrimPrefix(string(c.Request().URI().Path()), publicPrefix)
+				return strings.HasPrefix(key, storage.PrivatePrefix)
+			},
+		})
+	}
+
 	app.Get("/swagger/*", swagger.HandlerDefault)
 
-	app.Get("/metrics", monitor.New(monitor.Config{Title: "Forum App Metrics", Refresh: time.Second}))
-	// app.Get("/metrics", adaptor.HTTPHandlerFunc(promhttp.Handler().ServeHTTP))
+	workerCtx, stopWorkers := context.WithCancel(context.Background())
+	defer stopWorkers()
+
+	if err := worker.StartSentimentWorker(broker, c.ModerationService); err != nil {
+		utils.Logger.WithError(err).Error("failed to start sentiment worker")
+	}
+	if err := worker.StartLinkPreviewWorker(broker, c.LinkPreviewService); err != nil {
+		utils.Logger.WithError(err).Error("failed to start link preview worker")
+	}
+	if err := worker.StartDataExportWorker(broker, c.AccountService); err != nil {
+		utils.Logger.WithError(err).Error("failed to start data export worker")
+	}
+	worker.StartCounterReconciler(workerCtx, c.PostService, cfg.CounterReconcileInterval)
+	worker.StartPollCloser(workerCtx, c.PostService, cfg.PollCloseInterval)
+	worker.StartPostScheduler(workerCtx, c.PostService, cfg.PostSchedulerInterval)
+	worker.StartAccountPurger(workerCtx, c.AccountService, cfg.Account.PurgeInterval)
+	worker.StartEmbeddingIndexer(workerCtx, c.RelatedPostService, cfg.Embedding.IndexInterval)
+
+	admin := monitoring.NewAdminServer()
+
+	go func() {
+		if err := admin.Listen(cfg.AdminAddress); err != nil {
+			utils.Logger.WithError(err).Error("admin server stopped")
+		}
+	}()
 
 	go func() {
 		if err := app.Listen(":8080"); err != nil {
-			log.Printf("Server stopped: %v\n", err)
+			utils.Logger.WithError(err).Error("server stopped")
 		}
 	}()
 
-	gracefulShutdown(app, 10*time.Second)
+	var rpcServer *rpc.Server
+	switch {
+	case cfg.GRPCServer.Address == "":
+	case cfg.GRPCServer.Token == "":
+		utils.Logger.Warn("GRPC_SERVER_TOKEN is not set, gRPC server disabled")
+	default:
+		rpcServer = rpc.NewServer(c, cfg.GRPCServer.Token)
+		go func() {
+			if err := rpcServer.Listen(cfg.GRPCServer.Address); err != nil {
+				utils.Logger.WithError(err).Error("gRPC server stopped")
+			}
+		}()
+	}
+
+	gracefulShutdown(10*time.Second, app, admin)
+
+	if rpcServer != nil {
+		ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+		defer cancel()
+		rpcServer.Shutdown(ctx)
+	}
 }
 
-func gracefulShutdown(app *fiber.App, timeout time.Duration) {
+func gracefulShutdown(timeout time.Duration, apps ...*fiber.App) {
 	quit := make(chan os.Signal, 1)
 	signal.Notify(quit, syscall.SIGINT, syscall.SIGTERM)
 
 	<-quit
-	log.Println("Shutting down server gracefully...")
+	utils.Logger.Info("shutting down server gracefully")
 
 	ctx, cancel := context.WithTimeout(context.Background(), timeout)
 	defer cancel()
 
-	if err := app.ShutdownWithContext(ctx); err != nil {
-		log.Printf("Error during shutdown: %v\n", err)
+	for _, app := range apps {
+		if err := app.ShutdownWithContext(ctx); err != nil {
+			utils.Logger.WithError(err).Error("error during shutdown")
+		}
 	}
 
-	log.Println("Server exited properly")
+	utils.Logger.Info("server exited properly")
 }
@@ -1,6 +1,6 @@
 // Code generated by protoc-gen-go. DO NOT EDIT.
 // versions:
-// 	protoc-gen-go v1.36.5
+// 	protoc-gen-go v1.36.9
 // 	protoc        v5.29.3
 // source: proto/recommender.proto
 
@@ -21,13 +21,78 @@ const (
 	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
 )
 
+// ReasonCode says why a post was recommended.
+type ReasonCode int32
+
+const (
+	ReasonCode_REASON_UNSPECIFIED          ReasonCode = 0
+	ReasonCode_REASON_TRENDING             ReasonCode = 1
+	ReasonCode_REASON_TOPIC_MATCH          ReasonCode = 2
+	ReasonCode_REASON_FOLLOWED_AUTHOR      ReasonCode = 3
+	ReasonCode_REASON_SIMILAR_TO_LIKED     ReasonCode = 4
+	ReasonCode_REASON_POPULAR_IN_COMMUNITY ReasonCode = 5
+)
+
+// Enum value maps for ReasonCode.
+var (
+	ReasonCode_name = map[int32]string{
+		0: "REASON_UNSPECIFIED",
+		1: "REASON_TRENDING",
+		2: "REASON_TOPIC_MATCH",
+		3: "REASON_FOLLOWED_AUTHOR",
+		4: "REASON_SIMILAR_TO_LIKED",
+		5: "REASON_POPULAR_IN_COMMUNITY",
+	}
+	ReasonCode_value = map[string]int32{
+		"REASON_UNSPECIFIED":          0,
+		"REASON_TRENDING":             1,
+		"REASON_TOPIC_MATCH":          2,
+		"REASON_FOLLOWED_AUTHOR":      3,
+		"REASON_SIMILAR_TO_LIKED":     4,
+		"REASON_POPULAR_IN_COMMUNITY": 5,
+	}
+)
+
+func (x ReasonCode) Enum() *ReasonCode {
+	p := new(ReasonCode)
+	*p = x
+	return p
+}
+
+func (x ReasonCode) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (ReasonCode) Descriptor() protoreflect.EnumDescriptor {
+	return file_proto_recommender_proto_enumTypes[0].Descriptor()
+}
+
+func (ReasonCode) Type() protoreflect.EnumType {
+	return &file_proto_recommender_proto_enumTypes[0]
+}
+
+func (x ReasonCode) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use ReasonCode.Descriptor instead.
+func (ReasonCode) EnumDescriptor() ([]byte, []int) {
+	return file_proto_recommender_proto_rawDescGZIP(), []int{0}
+}
+
 type RecommendationRequest struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	UserId        string                 `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
-	Topic         string                 `protobuf:"bytes,2,opt,name=topic,proto3" json:"topic,omitempty"`  // optional topic filter
-	Limit         int32                  `protobuf:"varint,3,opt,name=limit,proto3" json:"limit,omitempty"` // optional
-	unknownFields protoimpl.UnknownFields
-	sizeCache     protoimpl.SizeCache
+	state  protoimpl.MessageState `protogen:"open.v1"`
+	UserId string                 `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	Topic  string                 `protobuf:"bytes,2,opt,name=topic,proto3" json:"topic,omitempty"`  // optional topic filter
+	Limit  int32                  `protobuf:"varint,3,opt,name=limit,proto3" json:"limit,omitempty"` // optional
+	// next_page_token of the previous response, to continue the same list.
+	PageToken string `protobuf:"bytes,4,opt,name=page_token,json=pageToken,proto3" json:"page_token,omitempty"`
+	// Posts the user has already seen; they must not be returned.
+	ExcludePostIds []string `protobuf:"bytes,5,rep,name=exclude_post_ids,json=excludePostIds,proto3" json:"exclude_post_ids,omitempty"`
+	// Authors the user blocked or muted; their posts must not be returned.
+	ExcludeAuthorIds []string `protobuf:"bytes,6,rep,name=exclude_author_ids,json=excludeAuthorIds,proto3" json:"exclude_author_ids,omitempty"`
+	unknownFields    protoimpl.UnknownFields
+	sizeCache        protoimpl.SizeCache
 }
 
 func (x *RecommendationRequest) Reset() {
@@ -81,11 +146,36 @@ func (x *RecommendationRequest) GetLimit() int32 {
 	return 0
 }
 
+func (x *RecommendationRequest) GetPageToken() string {
+	if x != nil {
+		return x.PageToken
+	}
+	return ""
+}
+
+func (x *RecommendationRequest) GetExcludePostIds() []string {
+	if x != nil {
+		return x.ExcludePostIds
+	}
+	return nil
+}
+
+func (x *RecommendationRequest) GetExcludeAuthorIds() []string {
+	if x != nil {
+		return x.ExcludeAuthorIds
+	}
+	return nil
+}
+
+// PostItem is one recommended post. Callers load the post itself by ID;
+// content and author_id are informational.
 type PostItem struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	PostId        string                 `protobuf:"bytes,1,opt,name=post_id,json=postId,proto3" json:"post_id,omitempty"`
 	Content       string                 `protobuf:"bytes,2,opt,name=content,proto3" json:"content,omitempty"`
 	AuthorId      string                 `protobuf:"bytes,3,opt,name=author_id,json=authorId,proto3" json:"author_id,omitempty"`
+	Score         float64                `protobuf:"fixed64,4,opt,name=score,proto3" json:"score,omitempty"` // relevance, posts come highest first
+	Reasons       []ReasonCode           `protobuf:"varint,5,rep,packed,name=reasons,proto3,enum=recommender.ReasonCode" json:"reasons,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
@@ -141,9 +231,24 @@ func (x *PostItem) GetAuthorId() string {
 	return ""
 }
 
+func (x *PostItem) GetScore() float64 {
+	if x != nil {
+		return x.Score
+	}
+	return 0
+}
+
+func (x *PostItem) GetReasons() []ReasonCode {
+	if x != nil {
+		return x.Reasons
+	}
+	return nil
+}
+
 type RecommendationResponse struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	Posts         []*PostItem            `protobuf:"bytes,1,rep,name=posts,proto3" json:"posts,omitempty"`
+	NextPageToken string                 `protobuf:"bytes,2,opt,name=next_page_token,json=nextPageToken,proto3" json:"next_page_token,omitempty"` // empty on the last page
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
@@ -185,38 +290,45 @@ func (x *RecommendationResponse) GetPosts() []*PostItem {
 	return nil
 }
 
+func (x *RecommendationResponse) GetNextPageToken() string {
+	if x != nil {
+		return x.NextPageToken
+	}
+	return ""
+}
+
 var File_proto_recommender_proto protoreflect.FileDescriptor
 
-var file_proto_recommender_proto_rawDesc = string([]byte{
-	0x0a, 0x17, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2f, 0x72, 0x65, 0x63, 0x6f, 0x6d, 0x6d, 0x65, 0x6e,
-	0x64, 0x65, 0x72, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x12, 0x0b, 0x72, 0x65, 0x63, 0x6f, 0x6d,
-	0x6d, 0x65, 0x6e, 0x64, 0x65, 0x72, 0x22, 0x5c, 0x0a, 0x15, 0x52, 0x65, 0x63, 0x6f, 0x6d, 0x6d,
-	0x65, 0x6e, 0x64, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12,
-	0x17, 0x0a, 0x07, 0x75, 0x73, 0x65, 0x72, 0x5f, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09,
-	0x52, 0x06, 0x75, 0x73, 0x65, 0x72, 0x49, 0x64, 0x12, 0x14, 0x0a, 0x05, 0x74, 0x6f, 0x70, 0x69,
-	0x63, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x74, 0x6f, 0x70, 0x69, 0x63, 0x12, 0x14,
-	0x0a, 0x05, 0x6c, 0x69, 0x6d, 0x69, 0x74, 0x18, 0x03, 0x20, 0x01, 0x28, 0x05, 0x52, 0x05, 0x6c,
-	0x69, 0x6d, 0x69, 0x74, 0x22, 0x5a, 0x0a, 0x08, 0x50, 0x6f, 0x73, 0x74, 0x49, 0x74, 0x65, 0x6d,
-	0x12, 0x17, 0x0a, 0x07, 0x70, 0x6f, 0x73, 0x74, 0x5f, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28,
-	0x09, 0x52, 0x06, 0x70, 0x6f, 0x73, 0x74, 0x49, 0x64, 0x12, 0x18, 0x0a, 0x07, 0x63, 0x6f, 0x6e,
-	0x74, 0x65, 0x6e, 0x74, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x63, 0x6f, 0x6e, 0x74,
-	0x65, 0x6e, 0x74, 0x12, 0x1b, 0x0a, 0x09, 0x61, 0x75, 0x74, 0x68, 0x6f, 0x72, 0x5f, 0x69, 0x64,
-	0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x61, 0x75, 0x74, 0x68, 0x6f, 0x72, 0x49, 0x64,
-	0x22, 0x45, 0x0a, 0x16, 0x52, 0x65, 0x63, 0x6f, 0x6d, 0x6d, 0x65, 0x6e, 0x64, 0x61, 0x74, 0x69,
-	0x6f, 0x6e, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x2b, 0x0a, 0x05, 0x70, 0x6f,
-	0x73, 0x74, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x15, 0x2e, 0x72, 0x65, 0x63, 0x6f,
-	0x6d, 0x6d, 0x65, 0x6e, 0x64, 0x65, 0x72, 0x2e, 0x50, 0x6f, 0x73, 0x74, 0x49, 0x74, 0x65, 0x6d,
-	0x52, 0x05, 0x70, 0x6f, 0x73, 0x74, 0x73, 0x32, 0x74, 0x0a, 0x12, 0x52, 0x65, 0x63, 0x6f, 0x6d,
-	0x6d, 0x65, 0x6e, 0x64, 0x65, 0x72, 0x53, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x12, 0x5e, 0x0a,
-	0x13, 0x47, 0x65, 0x74, 0x52, 0x65, 0x63, 0x6f, 0x6d, 0x6d, 0x65, 0x6e, 0x64, 0x65, 0x64, 0x50,
-	0x6f, 0x73, 0x74, 0x73, 0x12, 0x22, 0x2e, 0x72, 0x65, 0x63, 0x6f, 0x6d, 0x6d, 0x65, 0x6e, 0x64,
-	0x65, 0x72, 0x2e, 0x52, 0x65, 0x63, 0x6f, 0x6d, 0x6d, 0x65, 0x6e, 0x64, 0x61, 0x74, 0x69, 0x6f,
-	0x6e, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x23, 0x2e, 0x72, 0x65, 0x63, 0x6f, 0x6d,
-	0x6d, 0x65, 0x6e, 0x64, 0x65, 0x72, 0x2e, 0x52, 0x65, 0x63, 0x6f, 0x6d, 0x6d, 0x65, 0x6e, 0x64,
-	0x61, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x42, 0x0e, 0x5a,
-	0x0c, 0x2f, 0x72, 0x65, 0x63, 0x6f, 0x6d, 0x6d, 0x65, 0x6e, 0x64, 0x65, 0x72, 0x62, 0x06, 0x70,
-	0x72, 0x6f, 0x74, 0x6f, 0x33,
-})
+const file_proto_recommender_proto_rawDesc = "" +
+	"\n" +
+	"\x17proto/recommender.proto\x12\vrecommender\"\xd3\x01\n" +
+	"\x15RecommendationRequest\x12\x17\n" +
+	"\auser_id\x18\x01 \x01(\tR\x06userId\x12\x14\n" +
+	"\x05topic\x18\x02 \x01(\tR\x05topic\x12\x14\n" +
+	"\x05limit\x18\x03 \x01(\x05R\x05limit\x12\x1d\n" +
+	"\n" +
+	"page_token\x18\x04 \x01(\tR\tpageToken\x12(\n" +
+	"\x10exclude_post_ids\x18\x05 \x03(\tR\x0eexcludePostIds\x12,\n" +
+	"\x12exclude_author_ids\x18\x06 \x03(\tR\x10excludeAuthorIds\"\xa3\x01\n" +
+	"\bPostItem\x12\x17\n" +
+	"\apost_id\x18\x01 \x01(\tR\x06postId\x12\x18\n" +
+	"\acontent\x18\x02 \x01(\tR\acontent\x12\x1b\n" +
+	"\tauthor_id\x18\x03 \x01(\tR\bauthorId\x12\x14\n" +
+	"\x05score\x18\x04 \x01(\x01R\x05score\x121\n" +
+	"\areasons\x18\x05 \x03(\x0e2\x17.recommender.ReasonCodeR\areasons\"m\n" +
+	"\x16RecommendationResponse\x12+\n" +
+	"\x05posts\x18\x01 \x03(\v2\x15.recommender.PostItemR\x05posts\x12&\n" +
+	"\x0fnext_page_token\x18\x02 \x01(\tR\rnextPageToken*\xab\x01\n" +
+	"\n" +
+	"ReasonCode\x12\x16\n" +
+	"\x12REASON_UNSPECIFIED\x10\x00\x12\x13\n" +
+	"\x0fREASON_TRENDING\x10\x01\x12\x16\n" +
+	"\x12REASON_TOPIC_MATCH\x10\x02\x12\x1a\n" +
+	"\x16REASON_FOLLOWED_AUTHOR\x10\x03\x12\x1b\n" +
+	"\x17REASON_SIMILAR_TO_LIKED\x10\x04\x12\x1f\n" +
+	"\x1bREASON_POPULAR_IN_COMMUNITY\x10\x052t\n" +
+	"\x12RecommenderService\x12^\n" +
+	"\x13GetRecommendedPosts\x12\".recommender.RecommendationRequest\x1a#.recommender.RecommendationResponseB\x0eZ\f/recommenderb\x06proto3"
 
 var (
 	file_proto_recommender_proto_rawDescOnce sync.Once
@@ -230,21 +342,24 @@ func file_proto_recommender_proto_rawDescGZIP() []byte {
 	return file_proto_recommender_proto_rawDescData
 }
 
+var file_proto_recommender_proto_enumTypes = make([]protoimpl.EnumInfo, 1)
 var file_proto_recommender_proto_msgTypes = make([]protoimpl.MessageInfo, 3)
 var file_proto_recommender_proto_goTypes = []any{
-	(*RecommendationRequest)(nil),  // 0: recommender.RecommendationRequest
-	(*PostItem)(nil),               // 1: recommender.PostItem
-	(*RecommendationResponse)(nil), // 2: recommender.RecommendationResponse
+	(ReasonCode)(0),                // 0: recommender.ReasonCode
+	(*RecommendationRequest)(nil),  // 1: recommender.RecommendationRequest
+	(*PostItem)(nil),               // 2: recommender.PostItem
+	(*RecommendationResponse)(nil), // 3: recommender.RecommendationResponse
 }
 var file_proto_recommender_proto_depIdxs = []int32{
-	1, // 0: recommender.RecommendationResponse.posts:type_name -> recommender.PostItem
-	0, // 1: recommender.RecommenderService.GetRecommendedPosts:input_type -> recommender.RecommendationRequest
-	2, // 2: recommender.RecommenderService.GetRecommendedPosts:output_type -> recommender.RecommendationResponse
-	2, // [2:3] is the sub-list for method output_type
-	1, // [1:2] is the sub-list for method input_type
-	1, // [1:1] is the sub-list for extension type_name
-	1, // [1:1] is the sub-list for extension extendee
-	0, // [0:1] is the sub-list for field type_name
+	0, // 0: recommender.PostItem.reasons:type_name -> recommender.ReasonCode
+	2, // 1: recommender.RecommendationResponse.posts:type_name -> recommender.PostItem
+	1, // 2: recommender.RecommenderService.GetRecommendedPosts:input_type -> recommender.RecommendationRequest
+	3, // 3: recommender.RecommenderService.GetRecommendedPosts:output_type -> recommender.RecommendationResponse
+	3, // [3:4] is the sub-list for method output_type
+	2, // [2:3] is the sub-list for method input_type
+	2, // [2:2] is the sub-list for extension type_name
+	2, // [2:2] is the sub-list for extension extendee
+	0, // [0:2] is the sub-list for field type_name
 }
 
 func init() { file_proto_recommender_proto_init() }
@@ -257,13 +372,14 @@ func file_proto_recommender_proto_init() {
 		File: protoimpl.DescBuilder{
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: unsafe.Slice(unsafe.StringData(file_proto_recommender_proto_rawDesc), len(file_proto_recommender_proto_rawDesc)),
-			NumEnums:      0,
+			NumEnums:      1,
 			NumMessages:   3,
 			NumExtensions: 0,
 			NumServices:   1,
 		},
 		GoTypes:           file_proto_recommender_proto_goTypes,
 		DependencyIndexes: file_proto_recommender_proto_depIdxs,
+		EnumInfos:         file_proto_recommender_proto_enumTypes,
 		MessageInfos:      file_proto_recommender_proto_msgTypes,
 	}.Build()
 	File_proto_recommender_proto = out.File
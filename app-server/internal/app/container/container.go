@@ -2,9 +2,17 @@ package container
 
 import (
 	"github.com/maulana1k/forum-app/gen/recommender"
+	"github.com/maulana1k/forum-app/internal/config"
+	"github.com/maulana1k/forum-app/internal/domain/events"
+	"github.com/maulana1k/forum-app/internal/domain/models"
 	"github.com/maulana1k/forum-app/internal/domain/repository"
 	"github.com/maulana1k/forum-app/internal/domain/service"
+	"github.com/maulana1k/forum-app/internal/pkg/unfurl"
 	"github.com/maulana1k/forum-app/internal/provider/broker"
+	"github.com/maulana1k/forum-app/internal/provider/cache"
+	"github.com/maulana1k/forum-app/internal/provider/embedding"
+	"github.com/maulana1k/forum-app/internal/provider/engagement"
+	"github.com/maulana1k/forum-app/internal/provider/storage"
 
 	"google.golang.org/grpc"
 	"gorm.io/gorm"
@@ -15,21 +23,96 @@ type Container struct {
 	service.UserService
 	service.PostService
 	service.RecommendationService
+	service.MediaService
+	service.LinkPreviewService
+	service.CommunityService
+	service.RelationService
+	service.MutedWordService
+	service.SettingsService
+	service.AccountService
+	service.EngagementService
+	service.ModerationService
+	service.RelatedPostService
+
+	Events *events.Bus
 }
 
-func NewContainer(db *gorm.DB, grpc *grpc.ClientConn, broker *broker.RabbitMQ) *Container {
+func NewContainer(db *gorm.DB, grpc *grpc.ClientConn, brokerc *broker.RabbitMQ, store cache.Cache, blobs storage.BlobStore, recorder *engagement.Recorder, cfg *config.Configuration) *Container {
+	bus := events.NewBus()
+
 	authRepo := repository.NewAuthRepository(db)
 	userRepo := repository.NewUserRepository(db)
 	postRepo := repository.NewPostRepository(db)
+	reactionRepo := repository.NewReactionRepository(db)
+	pollRepo := repository.NewPollRepository(db)
+	mediaRepo := repository.NewMediaRepository(db)
+	linkRepo := repository.NewLinkPreviewRepository(db)
+	communityRepo := repository.NewCommunityRepository(db)
+	relationRepo := repository.NewRelationRepository(db)
+	mutedWordRepo := repository.NewMutedWordRepository(db)
+	settingsRepo := repository.NewSettingsRepository(db)
+	accountRepo := repository.NewAccountRepository(db)
+	impressionRepo := repository.NewImpressionRepository(db)
+	moderationRepo := repository.NewModerationRepository(db)
+	embeddingRepo := repository.NewEmbeddingRepository(db)
 
 	recClient := recommender.NewRecommenderServiceClient(grpc)
+	embedder := embedding.New(cfg.Embedding.Driver, grpc, cfg.Embedding.Timeout)
+	linkPreviews := broker.NewProducer(brokerc, service.LinkPreviewQueue)
+
+	recRepo := repository.NewRecommendationRepository(recClient, repository.RecommenderOptions{
+		Timeout:          cfg.Recommender.Timeout,
+		Budget:           cfg.Recommender.Budget,
+		Attempts:         cfg.Recommender.Attempts,
+		BreakerThreshold: cfg.Recommender.BreakerThreshold,
+		BreakerCooldown:  cfg.Recommender.BreakerCooldown,
+	})
 
-	recRepo := repository.NewRecommendationRepository(recClient)
+	postService := service.NewPostService(postRepo, reactionRepo, pollRepo, mediaRepo, userRepo, communityRepo, relationRepo, mutedWordRepo, settingsRepo, brokerc, linkPreviews, bus, store, service.PostOptions{
+		Reactions:  cfg.Reactions,
+		EditWindow: cfg.PostEditWindow,
+	})
 
 	return &Container{
-		AuthService:           service.NewAuthService(authRepo),
-		UserService:           service.NewUserService(userRepo),
-		PostService:           service.NewPostService(postRepo, broker),
-		RecommendationService: service.NewRecommendationService(recRepo),
+		AuthService: service.NewAuthService(authRepo, bus),
+		UserService: service.NewUserService(userRepo, mediaRepo, brokerc, bus, store, service.UserOptions{
+			UsernameCooldown:    cfg.Account.UsernameCooldown,
+			UsernameReservation: cfg.Account.UsernameReservation,
+			EmailTokenTTL:       cfg.Account.EmailTokenTTL,
+		}),
+		PostService: postService,
+		RecommendationService: service.NewRecommendationService(recRepo, impressionRepo, postRepo, postService, relationRepo, userRepo, bus, service.RecommendationOptions{
+			FallbackWindow: cfg.Recommender.FallbackWindow,
+		}),
+		MediaService: service.NewMediaService(mediaRepo, blobs, service.MediaOptions{
+			MaxBytes:      cfg.Media.MaxBytes,
+			ThumbnailSize: cfg.Media.ThumbnailSize,
+			UploadTTL:     cfg.Media.UploadTTL,
+		}),
+		LinkPreviewService: service.NewLinkPreviewService(linkRepo, bus, store, service.LinkPreviewOptions{
+			Fetch: unfurl.Options{
+				Timeout:  cfg.LinkPreview.Timeout,
+				MaxBytes: cfg.LinkPreview.MaxBytes,
+			},
+			TTL: cfg.LinkPreview.TTL,
+		}),
+		CommunityService: service.NewCommunityService(communityRepo),
+		RelationService:  service.NewRelationService(relationRepo, userRepo),
+		MutedWordService: service.NewMutedWordService(mutedWordRepo),
+		SettingsService:  service.NewSettingsService(settingsRepo),
+		AccountService: service.NewAccountService(accountRepo, userRepo, blobs, brokerc, bus, service.AccountOptions{
+			DeletionGrace:   cfg.Account.DeletionGrace,
+			DeletionPolicy:  models.DeletionPolicy(cfg.Account.DeletionPolicy),
+			ExportRetention: cfg.Account.ExportRetention,
+			ExportLinkTTL:   cfg.Account.ExportLinkTTL,
+		}),
+		EngagementService: service.NewEngagementService(recorder, bus),
+		ModerationService: service.NewModerationService(moderationRepo, postRepo),
+		RelatedPostService: service.NewRelatedPostService(embeddingRepo, postService, embedder, service.RelatedPostOptions{
+			BatchSize: cfg.Embedding.BatchSize,
+			// The table is only migrated when pgvector is installed.
+			Disabled: !db.Migrator().HasTable(&models.PostEmbedding{}),
+		}),
+		Events: bus,
 	}
 }
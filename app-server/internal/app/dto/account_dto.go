@@ -0,0 +1,37 @@
+package dto
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// DeleteAccountRequest confirms an account deletion with the current
+// password
+type DeleteAccountRequest struct {
+	Password string `json:"password" validate:"required"`
+}
+
+// AccountDeletionResponse tells when a deactivated account will be
+// deleted. Signing in before then cancels the deletion.
+type AccountDeletionResponse struct {
+	DeleteAfter time.Time `json:"deleteAfter"`
+}
+
+// DataExportResponse is the state of a data export. DownloadURL is only
+// set once the archive is ready and is valid for a short time; fetch the
+// export again for a fresh link.
+type DataExportResponse struct {
+	ID          uuid.UUID  `json:"id"`
+	Status      string     `json:"status"`
+	Size        int64      `json:"size,omitempty"`
+	DownloadURL string     `json:"downloadUrl,omitempty"`
+	CreatedAt   time.Time  `json:"createdAt"`
+	CompletedAt *time.Time `json:"completedAt,omitempty"`
+	ExpiresAt   *time.Time `json:"expiresAt,omitempty"`
+}
+
+// ExportIDParams represents a data export ID route param
+type ExportIDParams struct {
+	ID string `params:"id" validate:"required,uuid_param"`
+}
@@ -2,13 +2,13 @@ package dto
 
 // SignupRequest defines the body for user signup
 type SignupRequest struct {
-	Username string `json:"username" example:"admin"`
-	Email    string `json:"email" example:"admin@example.com"`
-	Password string `json:"password" example:"admin"`
+	Username string `json:"username" example:"admin" validate:"required,username"`
+	Email    string `json:"email" example:"admin@example.com" validate:"required,email"`
+	Password string `json:"password" example:"Admin1234" validate:"required,password"`
 }
 
 // SigninRequest defines the body for user signin
 type SigninRequest struct {
-	Email    string `json:"email" example:"admin@example.com"`
-	Password string `json:"password" example:"admin"`
+	Email    string `json:"email" example:"admin@example.com" validate:"required,email"`
+	Password string `json:"password" example:"Admin1234" validate:"required"`
 }
@@ -0,0 +1,111 @@
+package dto
+
+import "time"
+
+// CreateCommunityRequest represents the request body for creating a
+// community
+type CreateCommunityRequest struct {
+	Slug        string `json:"slug" validate:"required,min=3,max=32,slug"`
+	Name        string `json:"name" validate:"required,min=1,max=100"`
+	Description string `json:"description,omitempty" validate:"max=2000"`
+	Rules       string `json:"rules,omitempty" validate:"max=5000"`
+	// Visibility defaults to public.
+	Visibility string `json:"visibility,omitempty" validate:"omitempty,oneof=public restricted private"`
+}
+
+// UpdateCommunityRequest represents the request body for updating a
+// community. The slug cannot be changed.
+type UpdateCommunityRequest struct {
+	Name        *string `json:"name,omitempty" validate:"omitempty,min=1,max=100"`
+	Description *string `json:"description,omitempty" validate:"omitempty,max=2000"`
+	Rules       *string `json:"rules,omitempty" validate:"omitempty,max=5000"`
+	Visibility  *string `json:"visibility,omitempty" validate:"omitempty,oneof=public restricted private"`
+}
+
+// MembershipResponse is a user's standing in a community
+type MembershipResponse struct {
+	Role   string `json:"role"`
+	Status string `json:"status"`
+}
+
+type CommunityResponse struct {
+	ID           string    `json:"id"`
+	Slug         string    `json:"slug"`
+	Name         string    `json:"name"`
+	Description  string    `json:"description"`
+	Rules        string    `json:"rules"`
+	Visibility   string    `json:"visibility"`
+	OwnerID      string    `json:"owner_id"`
+	MembersCount int       `json:"members_count"`
+	CreatedAt    time.Time `json:"created_at"`
+	UpdatedAt    time.Time `json:"updated_at"`
+	// Membership is the caller's membership, if any.
+	Membership *MembershipResponse `json:"membership,omitempty"`
+}
+
+// CommunitySummary identifies the community a post belongs to
+type CommunitySummary struct {
+	ID         string `json:"id"`
+	Slug       string `json:"slug"`
+	Name       string `json:"name"`
+	Visibility string `json:"visibility"`
+}
+
+// PaginatedCommunitiesResponse represents a page of communities. Cursor
+// pages fill NextCursor/PrevCursor; offset pages (?page=) fill the totals.
+type PaginatedCommunitiesResponse struct {
+	Communities []CommunityResponse `json:"communities"`
+	Total       int                 `json:"total,omitempty"`
+	Page        int                 `json:"page,omitempty"`
+	Limit       int                 `json:"limit"`
+	TotalPages  int                 `json:"total_pages,omitempty"`
+	HasNextPage bool                `json:"has_next_page"`
+	HasPrevPage bool                `json:"has_prev_page"`
+	NextCursor  string              `json:"next_cursor,omitempty"`
+	PrevCursor  string              `json:"prev_cursor,omitempty"`
+}
+
+type CommunityMemberResponse struct {
+	User     PostAuthor `json:"user"`
+	Role     string     `json:"role"`
+	Status   string     `json:"status"`
+	JoinedAt time.Time  `json:"joined_at"`
+}
+
+// PaginatedCommunityMembersResponse represents a page of community
+// members, newest first
+type PaginatedCommunityMembersResponse struct {
+	Members     []CommunityMemberResponse `json:"members"`
+	Limit       int                       `json:"limit"`
+	HasNextPage bool                      `json:"has_next_page"`
+	HasPrevPage bool                      `json:"has_prev_page"`
+	NextCursor  string                    `json:"next_cursor,omitempty"`
+	PrevCursor  string                    `json:"prev_cursor,omitempty"`
+}
+
+// CommunityMembersQuery represents query parameters for listing members.
+// Only moderators may list pending and banned members.
+type CommunityMembersQuery struct {
+	Status string `query:"status" validate:"omitempty,oneof=active pending banned"`
+	Limit  int    `query:"limit" validate:"omitempty,min=1,max=100"`
+	Cursor string `query:"cursor"`
+}
+
+// UpdateMemberRequest changes a member's role or status. Moderators
+// approve pending members by setting status to active, and ban them by
+// setting it to banned; only the owner can change roles.
+type UpdateMemberRequest struct {
+	Role   *string `json:"role,omitempty" validate:"omitempty,oneof=moderator member"`
+	Status *string `json:"status,omitempty" validate:"omitempty,oneof=active banned"`
+}
+
+// CommunitySlugParams represents a community slug route param
+type CommunitySlugParams struct {
+	Slug string `params:"slug" validate:"required,max=32"`
+}
+
+// CommunityMemberParams represents a community member route params
+type CommunityMemberParams struct {
+	Slug   string `params:"slug" validate:"required,max=32"`
+	UserID string `params:"userId" validate:"required,uuid_param"`
+}
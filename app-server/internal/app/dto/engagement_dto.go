@@ -0,0 +1,19 @@
+package dto
+
+// EngagementEvent is something the user did with a post that only the
+// client sees: a post shown on screen, or how long it stayed there.
+type EngagementEvent struct {
+	Kind   string `json:"kind" validate:"required,oneof=view dwell" example:"view"`
+	PostID string `json:"post_id" validate:"required,uuid_param"`
+	// Surface is where the post was shown.
+	Surface string `json:"surface" validate:"omitempty,oneof=feed recommendations community profile post" example:"feed"`
+	// DwellMs is how long the post was on screen; required for dwell.
+	DwellMs int64 `json:"dwell_ms" validate:"required_if=Kind dwell,min=0,max=3600000"`
+	// RecommendationID links the event to a page of recommendations.
+	RecommendationID string `json:"recommendation_id" validate:"omitempty,uuid_param"`
+}
+
+// EngagementEventsRequest reports a batch of engagement events
+type EngagementEventsRequest struct {
+	Events []EngagementEvent `json:"events" validate:"required,min=1,max=100,dive"`
+}
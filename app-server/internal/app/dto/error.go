@@ -1,7 +1,21 @@
 package dto
 
-type ErrorResponse struct {
-	Error   string `json:"message"`
-	Code    int    `json:"code"`
-	Details string `json:"details,omitempty"`
+// ProblemDetails is an RFC 7807 problem document. Every error response is
+// rendered in this shape with the application/problem+json content type.
+type ProblemDetails struct {
+	Type      string       `json:"type" example:"/problems/post_not_found"`
+	Title     string       `json:"title" example:"Not Found"`
+	Status    int          `json:"status" example:"404"`
+	Detail    string       `json:"detail,omitempty" example:"post not found"`
+	Instance  string       `json:"instance,omitempty" example:"/api/v1/posts/0b7c..."`
+	Code      string       `json:"code" example:"post_not_found"`
+	RequestID string       `json:"request_id,omitempty"`
+	Errors    []FieldError `json:"errors,omitempty"`
+}
+
+// FieldError explains why a single request field was rejected.
+type FieldError struct {
+	Field   string `json:"field" example:"email"`
+	Code    string `json:"code" example:"required"`
+	Message string `json:"message" example:"email is required"`
 }
@@ -0,0 +1,42 @@
+package dto
+
+import "time"
+
+// MediaResponse represents an uploaded image
+type MediaResponse struct {
+	ID           string    `json:"id"`
+	Status       string    `json:"status"`
+	ContentType  string    `json:"content_type,omitempty"`
+	Size         int64     `json:"size,omitempty"`
+	Width        int       `json:"width,omitempty"`
+	Height       int       `json:"height,omitempty"`
+	URL          string    `json:"url,omitempty"`
+	ThumbnailURL string    `json:"thumbnail_url,omitempty"`
+	CreatedAt    time.Time `json:"created_at"`
+}
+
+// CreateUploadRequest asks for a presigned upload URL
+type CreateUploadRequest struct {
+	ContentType string `json:"content_type" validate:"required,max=64"`
+	Size        int64  `json:"size" validate:"required,gt=0"`
+}
+
+// UploadResponse tells the client where to send the file. After the PUT
+// succeeds the client completes the upload with POST /v1/media/{id}/complete.
+type UploadResponse struct {
+	Media     MediaResponse `json:"media"`
+	UploadURL string        `json:"upload_url"`
+	Method    string        `json:"method"`
+	ExpiresAt time.Time     `json:"expires_at"`
+}
+
+// MediaIDParams represents a media ID route param
+type MediaIDParams struct {
+	ID string `params:"id" validate:"required,uuid_param"`
+}
+
+// UploadTokenParams represents a signed upload or download token route
+// param
+type UploadTokenParams struct {
+	Token string `params:"token" validate:"required"`
+}
@@ -0,0 +1,12 @@
+package dto
+
+// ModerationResultRequest is a classifier's verdict on a post, reported by
+// the ML services
+type ModerationResultRequest struct {
+	PostID string `json:"post_id" validate:"required,uuid_param"`
+	// Model names the classifier, e.g. "sentiment".
+	Model   string  `json:"model" validate:"required,max=64"`
+	Label   string  `json:"label" validate:"max=64"`
+	Score   float64 `json:"score"`
+	Flagged bool    `json:"flagged"`
+}
@@ -6,17 +6,31 @@ import (
 
 // CreatePostRequest represents the request body for creating a post
 type CreatePostRequest struct {
-	Content      string `json:"content" validate:"required,min=1,max=2000"`
-	Tags         string `json:"tags,omitempty"`
-	ImageURL     string `json:"image_url,omitempty" validate:"omitempty,url"`
-	QuotedPostID string `json:"quoted_post_id,omitempty"`
+	Content      string             `json:"content" validate:"required,min=1,max=2000"`
+	Tags         string             `json:"tags,omitempty"`
+	MediaID      string             `json:"media_id,omitempty" validate:"omitempty,uuid_param"`
+	QuotedPostID string             `json:"quoted_post_id,omitempty" validate:"omitempty,uuid_param"`
+	CommunityID  string             `json:"community_id,omitempty" validate:"omitempty,uuid_param"`
+	Poll         *CreatePollRequest `json:"poll,omitempty" validate:"omitempty"`
+	// Visibility defaults to the author's default_post_visibility setting.
+	Visibility string `json:"visibility,omitempty" validate:"omitempty,oneof=public only_me"`
+	// Draft saves the post unpublished; PublishAt schedules it instead.
+	Draft     bool       `json:"draft,omitempty"`
+	PublishAt *time.Time `json:"publish_at,omitempty"`
+}
+
+// CreatePollRequest attaches a poll to a new post
+type CreatePollRequest struct {
+	Options        []string  `json:"options" validate:"required,min=2,max=6,unique,dive,required,max=100"`
+	MultipleChoice bool      `json:"multiple_choice"`
+	ClosesAt       time.Time `json:"closes_at" validate:"required"`
 }
 
 // UpdatePostRequest represents the request body for updating a post
 type UpdatePostRequest struct {
-	Content  *string `json:"content,omitempty" validate:"omitempty,min=1,max=2000"`
-	Tags     *string `json:"tags,omitempty"`
-	ImageURL *string `json:"image_url,omitempty" validate:"omitempty,url"`
+	Content *string `json:"content,omitempty" validate:"omitempty,min=1,max=2000"`
+	Tags    *string `json:"tags,omitempty"`
+	MediaID *string `json:"media_id,omitempty" validate:"omitempty,uuid_param"`
 }
 
 type PostAuthor struct {
@@ -28,41 +42,155 @@ type PostAuthor struct {
 }
 
 type PostResponse struct {
-	ID           string          `json:"id"`
-	Content      string          `json:"content"`
-	Tags         string          `json:"tags"`
-	ImageURL     string          `json:"image_url"`
-	CreatedAt    time.Time       `json:"created_at"`
-	UpdatedAt    time.Time       `json:"updated_at"`
-	Author       PostAuthor      `json:"author"`
-	Replies      []ReplyResponse `json:"replies"`
-	QuotedPost   string          `json:"quoted_post"`
-	LikesCount   int             `json:"likes_count"`
-	RepliesCount int             `json:"replies_count"`
-	RepostsCount int             `json:"reposts_count"`
-	IsLiked      bool            `json:"is_liked"`
-	IsBookmarked bool            `json:"is_bookmarked"`
-	IsReposted   bool            `json:"is_reposted"`
+	ID            string            `json:"id"`
+	Content       string            `json:"content"`
+	ContentHTML   string            `json:"content_html"`
+	Tags          string            `json:"tags"`
+	ImageURL      string            `json:"image_url"`
+	Media         *MediaResponse    `json:"media,omitempty"`
+	LinkPreview   *LinkPreview      `json:"link_preview,omitempty"`
+	CreatedAt     time.Time         `json:"created_at"`
+	UpdatedAt     time.Time         `json:"updated_at"`
+	Author        PostAuthor        `json:"author"`
+	Replies       []ReplyResponse   `json:"replies"`
+	QuotedPost    string            `json:"quoted_post"`
+	LikesCount    int               `json:"likes_count"`
+	RepliesCount  int               `json:"replies_count"`
+	RepostsCount  int               `json:"reposts_count"`
+	Reactions     map[string]int    `json:"reactions"`
+	MyReaction    string            `json:"my_reaction,omitempty"`
+	Poll          *PollResponse     `json:"poll,omitempty"`
+	Community     *CommunitySummary `json:"community,omitempty"`
+	Filtered      *FilterReason     `json:"filtered,omitempty"`
+	EditedAt      *time.Time        `json:"edited_at,omitempty"`
+	RevisionCount int               `json:"revision_count"`
+	Status        string            `json:"status"`
+	Visibility    string            `json:"visibility"`
+	PublishAt     *time.Time        `json:"publish_at,omitempty"`
+	IsLiked       bool              `json:"is_liked"`
+	IsBookmarked  bool              `json:"is_bookmarked"`
+	IsReposted    bool              `json:"is_reposted"`
+}
+
+// LinkPreview is the card shown for the first link in a post. It is
+// filled in shortly after the post is published.
+type LinkPreview struct {
+	URL         string `json:"url"`
+	Title       string `json:"title,omitempty"`
+	Description string `json:"description,omitempty"`
+	ImageURL    string `json:"image_url,omitempty"`
+	SiteName    string `json:"site_name,omitempty"`
+}
+
+// PostRevisionsResponse lists every version of an edited post, oldest
+// first. Posts that were never edited have no revisions.
+type PostRevisionsResponse struct {
+	PostID    string                 `json:"post_id"`
+	Revisions []PostRevisionResponse `json:"revisions"`
+}
+
+// PostRevisionResponse is one version of a post and what changed from the
+// version before it. The first revision has no diff.
+type PostRevisionResponse struct {
+	Revision    int           `json:"revision"`
+	EditorID    string        `json:"editor_id"`
+	Content     string        `json:"content"`
+	ContentHTML string        `json:"content_html"`
+	Tags        string        `json:"tags"`
+	ImageURL    string        `json:"image_url"`
+	CreatedAt   time.Time     `json:"created_at"`
+	Diff        *RevisionDiff `json:"diff,omitempty"`
+}
+
+// RevisionDiff describes an edit. Content is a word level diff; Tags and
+// ImageURL are only set when they changed.
+type RevisionDiff struct {
+	Content  []DiffSegment `json:"content"`
+	Tags     *FieldChange  `json:"tags,omitempty"`
+	ImageURL *FieldChange  `json:"image_url,omitempty"`
+}
+
+// DiffSegment is a run of text that was kept ("equal"), added ("insert")
+// or removed ("delete")
+type DiffSegment struct {
+	Op   string `json:"op"`
+	Text string `json:"text"`
+}
+
+// FieldChange is the old and new value of an edited field
+type FieldChange struct {
+	From string `json:"from"`
+	To   string `json:"to"`
+}
+
+// PollResponse represents a post's poll. Vote counts are left out until
+// the viewer has voted or the poll has closed (see ResultsVisible).
+type PollResponse struct {
+	ID             string               `json:"id"`
+	MultipleChoice bool                 `json:"multiple_choice"`
+	ClosesAt       time.Time            `json:"closes_at"`
+	Closed         bool                 `json:"closed"`
+	ResultsVisible bool                 `json:"results_visible"`
+	VotersCount    *int                 `json:"voters_count,omitempty"`
+	Options        []PollOptionResponse `json:"options"`
+	MyVotes        []uint               `json:"my_votes,omitempty"`
+}
+
+// PollOptionResponse represents one poll option
+type PollOptionResponse struct {
+	ID    uint   `json:"id"`
+	Text  string `json:"text"`
+	Votes *int   `json:"votes,omitempty"`
+}
+
+// VoteRequest represents the request body for voting in a poll
+type VoteRequest struct {
+	OptionIDs []uint `json:"option_ids" validate:"required,min=1,max=6,unique"`
 }
 
 // ReplyResponse represents a reply in API responses
 type ReplyResponse struct {
-	ID        uint      `json:"id"`
-	Content   string    `json:"content"`
-	Author    string    `json:"author"`
-	CreatedAt time.Time `json:"created_at"`
-	UpdatedAt time.Time `json:"updated_at"`
+	ID        uint          `json:"id"`
+	Content   string        `json:"content"`
+	Author    string        `json:"author"`
+	CreatedAt time.Time     `json:"created_at"`
+	UpdatedAt time.Time     `json:"updated_at"`
+	Filtered  *FilterReason `json:"filtered,omitempty"`
 }
 
-// PaginatedPostsResponse represents paginated posts response
+// FilterReason marks a post or reply that matched the viewer's muted
+// words. The content is still included; clients show it collapsed behind
+// the reason and let the viewer expand it.
+type FilterReason struct {
+	Reason  string   `json:"reason"`
+	Matches []string `json:"matches"`
+}
+
+// PaginatedPostsResponse represents paginated posts response. Cursor
+// pages fill NextCursor/PrevCursor; offset pages (?page=) fill the totals.
 type PaginatedPostsResponse struct {
 	Posts       []PostResponse `json:"posts"`
-	Total       int            `json:"total"`
-	Page        int            `json:"page"`
+	Total       int            `json:"total,omitempty"`
+	Page        int            `json:"page,omitempty"`
 	Limit       int            `json:"limit"`
-	TotalPages  int            `json:"total_pages"`
+	TotalPages  int            `json:"total_pages,omitempty"`
 	HasNextPage bool           `json:"has_next_page"`
 	HasPrevPage bool           `json:"has_prev_page"`
+	NextCursor  string         `json:"next_cursor,omitempty"`
+	PrevCursor  string         `json:"prev_cursor,omitempty"`
+}
+
+// PaginatedRepliesResponse represents a page of replies to a post
+type PaginatedRepliesResponse struct {
+	Replies     []ReplyResponse `json:"replies"`
+	Total       int             `json:"total,omitempty"`
+	Page        int             `json:"page,omitempty"`
+	Limit       int             `json:"limit"`
+	TotalPages  int             `json:"total_pages,omitempty"`
+	HasNextPage bool            `json:"has_next_page"`
+	HasPrevPage bool            `json:"has_prev_page"`
+	NextCursor  string          `json:"next_cursor,omitempty"`
+	PrevCursor  string          `json:"prev_cursor,omitempty"`
 }
 
 // PostActionRequest represents like/bookmark/repost actions
@@ -70,11 +198,60 @@ type PostActionRequest struct {
 	PostID uint `json:"post_id" validate:"required,min=1"`
 }
 
-// PostQueryParams represents query parameters for posts
+// PostQueryParams represents query parameters for post and reply lists.
+// Cursor takes precedence over Page; Page selects legacy offset paging.
 type PostQueryParams struct {
-	Page   int    `query:"page" validate:"omitempty,min=1"`
 	Limit  int    `query:"limit" validate:"omitempty,min=1,max=100"`
-	UserID *uint  `query:"user_id" validate:"omitempty,min=1"`
-	Tags   string `query:"tags"`
-	Search string `query:"search"`
+	Cursor string `query:"cursor"`
+	Page   int    `query:"page" validate:"omitempty,min=1"`
+}
+
+// PostIDParams represents a post ID route param
+type PostIDParams struct {
+	ID string `params:"id" validate:"required,uuid_param"`
+}
+
+// ReactRequest sets the viewer's reaction to a post
+type ReactRequest struct {
+	Type string `json:"type" validate:"required,max=32" example:"love"`
+}
+
+// ReactionUsersParams represents the route params of the reaction users list
+type ReactionUsersParams struct {
+	ID   string `params:"id" validate:"required,uuid_param"`
+	Type string `params:"type" validate:"required,max=32"`
+}
+
+// ReactionUser is a user who reacted to a post
+type ReactionUser struct {
+	User      PostAuthor `json:"user"`
+	ReactedAt time.Time  `json:"reacted_at"`
+}
+
+// PaginatedReactionUsersResponse represents a page of users who reacted
+type PaginatedReactionUsersResponse struct {
+	Users       []ReactionUser `json:"users"`
+	Limit       int            `json:"limit"`
+	HasNextPage bool           `json:"has_next_page"`
+	HasPrevPage bool           `json:"has_prev_page"`
+	NextCursor  string         `json:"next_cursor,omitempty"`
+	PrevCursor  string         `json:"prev_cursor,omitempty"`
+}
+
+// RelatedPostsQueryParams represents query parameters for related posts
+type RelatedPostsQueryParams struct {
+	Limit int `query:"limit" validate:"omitempty,min=1,max=20"`
+}
+
+// RelatedPostResponse is a post similar to the one being viewed
+type RelatedPostResponse struct {
+	PostResponse
+	// Score is the cosine similarity of the two posts; higher is closer.
+	Score float64 `json:"score" example:"0.82"`
+}
+
+// RelatedPostsResponse lists posts similar to a post, closest first. It
+// is empty until the post has been indexed, shortly after publishing.
+type RelatedPostsResponse struct {
+	Posts []RelatedPostResponse `json:"posts"`
 }
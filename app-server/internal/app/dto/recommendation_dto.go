@@ -0,0 +1,41 @@
+package dto
+
+// RecommendationQueryParams represents query parameters for recommendations
+type RecommendationQueryParams struct {
+	// AsUser lets an admin see another user's recommendations while
+	// debugging. Nothing is recorded for such requests.
+	AsUser string `query:"as_user" validate:"omitempty,uuid_param"`
+	Topic  string `query:"topic"`
+	Limit  int    `query:"limit" validate:"omitempty,min=1,max=100"`
+	// Cursor is next_cursor of the previous page.
+	Cursor string `query:"cursor" validate:"max=512"`
+	// Exclude lists posts the client already showed, so they are not
+	// recommended again.
+	Exclude []string `query:"exclude" validate:"max=200,dive,uuid_param"`
+}
+
+// RecommendedPostResponse is a post with why it was recommended
+type RecommendedPostResponse struct {
+	PostResponse
+	// Score ranks the recommendations; higher is more relevant.
+	Score   float64  `json:"score"`
+	Reasons []string `json:"reasons" example:"topic_match,trending"`
+}
+
+// RecommendedPostsResponse represents a page of recommended posts
+type RecommendedPostsResponse struct {
+	Posts      []RecommendedPostResponse `json:"posts"`
+	NextCursor string                    `json:"next_cursor,omitempty"`
+	// RecommendationID identifies this page when reporting clicks.
+	RecommendationID string `json:"recommendation_id,omitempty"`
+	// Source is "recommender" or, while it is unavailable, "fallback" for
+	// popular recent posts.
+	Source string `json:"source" example:"recommender"`
+}
+
+// RecommendationClickRequest reports that the user opened a recommended
+// post
+type RecommendationClickRequest struct {
+	RecommendationID string `json:"recommendation_id" validate:"required,uuid_param"`
+	PostID           string `json:"post_id" validate:"required,uuid_param"`
+}
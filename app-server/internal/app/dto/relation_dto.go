@@ -0,0 +1,40 @@
+package dto
+
+import "time"
+
+// RelatedUserResponse is a user the caller blocked or muted
+type RelatedUserResponse struct {
+	User  PostAuthor `json:"user"`
+	Since time.Time  `json:"since"`
+}
+
+// PaginatedRelatedUsersResponse represents a page of blocked or muted
+// users, most recent first
+type PaginatedRelatedUsersResponse struct {
+	Users       []RelatedUserResponse `json:"users"`
+	Limit       int                   `json:"limit"`
+	HasNextPage bool                  `json:"has_next_page"`
+	HasPrevPage bool                  `json:"has_prev_page"`
+	NextCursor  string                `json:"next_cursor,omitempty"`
+	PrevCursor  string                `json:"prev_cursor,omitempty"`
+}
+
+// CreateMutedWordRequest mutes a word, phrase or "#hashtag". Muting the
+// same phrase again replaces its expiry.
+type CreateMutedWordRequest struct {
+	Phrase string `json:"phrase" validate:"required,max=100"`
+	// ExpiresAt is when the mute lapses; omit it to mute indefinitely.
+	ExpiresAt *time.Time `json:"expires_at,omitempty"`
+}
+
+type MutedWordResponse struct {
+	ID        uint       `json:"id"`
+	Phrase    string     `json:"phrase"`
+	ExpiresAt *time.Time `json:"expires_at,omitempty"`
+	CreatedAt time.Time  `json:"created_at"`
+}
+
+// MutedWordIDParams represents a muted word ID route param
+type MutedWordIDParams struct {
+	ID uint `params:"id" validate:"required"`
+}
@@ -0,0 +1,73 @@
+package dto
+
+import "time"
+
+// NotificationChannels says where one kind of notification is delivered
+type NotificationChannels struct {
+	InApp bool `json:"in_app"`
+	Email bool `json:"email"`
+	Push  bool `json:"push"`
+}
+
+// NotificationSettings holds the delivery channels per event type
+type NotificationSettings struct {
+	Replies     NotificationChannels `json:"replies"`
+	Mentions    NotificationChannels `json:"mentions"`
+	Reactions   NotificationChannels `json:"reactions"`
+	Quotes      NotificationChannels `json:"quotes"`
+	Follows     NotificationChannels `json:"follows"`
+	PollResults NotificationChannels `json:"poll_results"`
+}
+
+type PrivacySettings struct {
+	// HideLikes leaves the user out of other users' reactor lists.
+	HideLikes bool `json:"hide_likes"`
+}
+
+// SettingsResponse represents the caller's settings. Users who never
+// changed them get the defaults with version 0.
+type SettingsResponse struct {
+	Theme         string               `json:"theme"`
+	Language      string               `json:"language"`
+	Notifications NotificationSettings `json:"notifications"`
+	// DefaultPostVisibility is used for new posts that don't set one.
+	DefaultPostVisibility string          `json:"default_post_visibility"`
+	Privacy               PrivacySettings `json:"privacy"`
+	// Version must be sent back when updating the settings.
+	Version   int        `json:"version"`
+	UpdatedAt *time.Time `json:"updated_at,omitempty"`
+}
+
+// NotificationChannelsPatch changes the channels of one event type.
+// Omitted channels are left as they are.
+type NotificationChannelsPatch struct {
+	InApp *bool `json:"in_app,omitempty"`
+	Email *bool `json:"email,omitempty"`
+	Push  *bool `json:"push,omitempty"`
+}
+
+type NotificationSettingsPatch struct {
+	Replies     *NotificationChannelsPatch `json:"replies,omitempty"`
+	Mentions    *NotificationChannelsPatch `json:"mentions,omitempty"`
+	Reactions   *NotificationChannelsPatch `json:"reactions,omitempty"`
+	Quotes      *NotificationChannelsPatch `json:"quotes,omitempty"`
+	Follows     *NotificationChannelsPatch `json:"follows,omitempty"`
+	PollResults *NotificationChannelsPatch `json:"poll_results,omitempty"`
+}
+
+type PrivacySettingsPatch struct {
+	HideLikes *bool `json:"hide_likes,omitempty"`
+}
+
+// UpdateSettingsRequest represents the request body for updating the
+// caller's settings. Omitted fields are left as they are. Version is the
+// version the client last read; the update is rejected if the settings
+// changed since.
+type UpdateSettingsRequest struct {
+	Version               *int                       `json:"version" validate:"required,min=0"`
+	Theme                 *string                    `json:"theme,omitempty" validate:"omitempty,oneof=light dark system"`
+	Language              *string                    `json:"language,omitempty" validate:"omitempty,oneof=en id"`
+	Notifications         *NotificationSettingsPatch `json:"notifications,omitempty"`
+	DefaultPostVisibility *string                    `json:"default_post_visibility,omitempty" validate:"omitempty,oneof=public only_me"`
+	Privacy               *PrivacySettingsPatch      `json:"privacy,omitempty"`
+}
@@ -4,16 +4,76 @@ import (
 	"time"
 
 	"github.com/google/uuid"
+	"github.com/maulana1k/forum-app/internal/pkg/nullable"
 )
 
 type UserResponse struct {
-	ID          uuid.UUID `json:"id"`
-	DisplayName string    `json:"displayName"`
-	AvatarURL   string    `json:"avatarUrl"`
-	Bio         string    `json:"bio"`
-	Location    string    `json:"location"`
-	CreatedAt   time.Time `json:"createdAt"`
-	UpdatedAt   time.Time `json:"updatedAt"`
+	ID            uuid.UUID  `json:"id"`
+	Username      string     `json:"username"`
+	DisplayName   string     `json:"displayName"`
+	AvatarURL     string     `json:"avatarUrl"`
+	AvatarMediaID *uuid.UUID `json:"avatarMediaId,omitempty"`
+	Bio           string     `json:"bio"`
+	Location      string     `json:"location"`
+	CreatedAt     time.Time  `json:"createdAt"`
+	UpdatedAt     time.Time  `json:"updatedAt"`
 }
 
 type UsersResponse []UserResponse
+
+// UserIDParams represents a user ID route param
+type UserIDParams struct {
+	ID string `params:"id" validate:"required,uuid_param"`
+}
+
+// SetAvatarRequest points the caller's avatar at an uploaded image
+type SetAvatarRequest struct {
+	MediaID string `json:"mediaId" validate:"required,uuid_param"`
+}
+
+// MeResponse is the caller's own profile, including private account
+// details
+type MeResponse struct {
+	UserResponse
+	Email         string `json:"email"`
+	EmailVerified bool   `json:"emailVerified"`
+	// PendingEmail is an email change waiting for verification.
+	PendingEmail *PendingEmailResponse `json:"pendingEmail,omitempty"`
+	// UsernameChangeableAt is when the username may next be changed, if
+	// that is still in the future.
+	UsernameChangeableAt *time.Time `json:"usernameChangeableAt,omitempty"`
+}
+
+type PendingEmailResponse struct {
+	Email     string    `json:"email"`
+	ExpiresAt time.Time `json:"expiresAt"`
+}
+
+// UpdateProfileRequest represents the request body for updating the
+// caller's profile. Omitted fields are left as they are; null clears
+// displayName, bio and location. A cleared display name falls back to the
+// username.
+type UpdateProfileRequest struct {
+	Username    *string                `json:"username,omitempty" validate:"omitempty,username"`
+	DisplayName nullable.Field[string] `json:"displayName" swaggertype:"string" validate:"omitempty,min=1,max=50"`
+	Bio         nullable.Field[string] `json:"bio" swaggertype:"string" validate:"omitempty,max=500"`
+	Location    nullable.Field[string] `json:"location" swaggertype:"string" validate:"omitempty,max=100"`
+}
+
+// ChangeEmailRequest starts an email change. The current password is
+// required, and the change only takes effect once verified.
+type ChangeEmailRequest struct {
+	Email    string `json:"email" example:"new@example.com" validate:"required,email"`
+	Password string `json:"password" validate:"required"`
+}
+
+// VerifyEmailRequest confirms an email change with the token sent to the
+// new address
+type VerifyEmailRequest struct {
+	Token string `json:"token" validate:"required,max=100"`
+}
+
+// UsernameParams represents a username route param
+type UsernameParams struct {
+	Username string `params:"username" validate:"required,username"`
+}
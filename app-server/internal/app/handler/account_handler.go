@@ -0,0 +1,120 @@
+package handler
+
+import (
+	"github.com/gofiber/fiber/v2"
+	"github.com/maulana1k/forum-app/internal/app/dto"
+	"github.com/maulana1k/forum-app/internal/domain/service"
+	"github.com/maulana1k/forum-app/internal/pkg/validator"
+)
+
+type AccountHandler struct {
+	service service.AccountService
+}
+
+func NewAccountHandler(service service.AccountService) *AccountHandler {
+	return &AccountHandler{service: service}
+}
+
+// Deactivate godoc
+//
+//	@Summary		Deactivate the caller's account
+//	@Description	Hide the caller's profile and posts and stop accepting their tokens. Signing in again reactivates the account.
+//	@Tags			Users
+//	@Security		BearerAuth
+//	@Success		204
+//	@Failure		401	{object}	dto.ProblemDetails
+//	@Failure		500	{object}	dto.ProblemDetails
+//	@Router			/v1/me/deactivate [post]
+func (h *AccountHandler) Deactivate(c *fiber.Ctx) error {
+	userID := c.Locals("userID").(string)
+
+	if err := h.service.Deactivate(c.UserContext(), userID); err != nil {
+		return err
+	}
+
+	return c.SendStatus(fiber.StatusNoContent)
+}
+
+// DeleteAccount godoc
+//
+//	@Summary		Delete the caller's account
+//	@Description	Deactivate the account and delete it for good once the grace period is over. Signing in before then cancels the deletion. Depending on server policy, published posts and replies are either kept under a placeholder "[deleted]" author or deleted too.
+//	@Tags			Users
+//	@Accept			json
+//	@Produce		json
+//	@Security		BearerAuth
+//	@Param			confirmation	body		dto.DeleteAccountRequest	true	"Current password"
+//	@Success		202				{object}	dto.AccountDeletionResponse
+//	@Failure		400				{object}	dto.ProblemDetails
+//	@Failure		401				{object}	dto.ProblemDetails
+//	@Failure		403				{object}	dto.ProblemDetails
+//	@Failure		500				{object}	dto.ProblemDetails
+//	@Router			/v1/me [delete]
+func (h *AccountHandler) DeleteAccount(c *fiber.Ctx) error {
+	userID := c.Locals("userID").(string)
+
+	req, err := validator.ParseAndValidateBody[dto.DeleteAccountRequest](c)
+	if err != nil {
+		return err
+	}
+
+	deletion, err := h.service.ScheduleDeletion(c.UserContext(), userID, req)
+	if err != nil {
+		return err
+	}
+
+	return c.Status(fiber.StatusAccepted).JSON(deletion)
+}
+
+// RequestExport godoc
+//
+//	@Summary		Export the caller's data
+//	@Description	Start building a ZIP archive of the caller's profile, posts, replies, interactions and settings as JSON. Poll the returned export until it is ready to get its download link.
+//	@Tags			Users
+//	@Produce		json
+//	@Security		BearerAuth
+//	@Success		202	{object}	dto.DataExportResponse
+//	@Failure		401	{object}	dto.ProblemDetails
+//	@Failure		409	{object}	dto.ProblemDetails
+//	@Failure		500	{object}	dto.ProblemDetails
+//	@Router			/v1/me/exports [post]
+func (h *AccountHandler) RequestExport(c *fiber.Ctx) error {
+	userID := c.Locals("userID").(string)
+
+	export, err := h.service.RequestExport(c.UserContext(), userID)
+	if err != nil {
+		return err
+	}
+
+	return c.Status(fiber.StatusAccepted).JSON(export)
+}
+
+// GetExport godoc
+//
+//	@Summary		Get a data export
+//	@Description	Retrieve the state of one of the caller's data exports. Ready exports include a signed download link that expires shortly; fetch the export again for a new one.
+//	@Tags			Users
+//	@Produce		json
+//	@Security		BearerAuth
+//	@Param			id	path		string	true	"Export UUID"
+//	@Success		200	{object}	dto.DataExportResponse
+//	@Failure		400	{object}	dto.ProblemDetails
+//	@Failure		401	{object}	dto.ProblemDetails
+//	@Failure		404	{object}	dto.ProblemDetails
+//	@Failure		500	{object}	dto.ProblemDetails
+//	@Router			/v1/me/exports/{id} [get]
+func (h *AccountHandler) GetExport(c *fiber.Ctx) error {
+	userID := c.Locals("userID").(string)
+
+	params, err := validator.ParseAndValidateParams[dto.ExportIDParams](c)
+	if err != nil {
+		return err
+	}
+
+	export, err := h.service.GetExport(c.UserContext(), userID, params.ID)
+	if err != nil {
+		return err
+	}
+
+	return c.JSON(export)
+}
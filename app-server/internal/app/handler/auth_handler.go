@@ -4,6 +4,7 @@ import (
 	"github.com/gofiber/fiber/v2"
 	"github.com/maulana1k/forum-app/internal/app/dto"
 	"github.com/maulana1k/forum-app/internal/domain/service"
+	"github.com/maulana1k/forum-app/internal/pkg/validator"
 )
 
 type AuthHandler struct {
@@ -27,20 +28,19 @@ func NewAuthHandler(authService service.AuthService, userService service.UserSer
 //	@Produce		json
 //	@Param			user	body		dto.SignupRequest	true	"User info"
 //	@Success		200		{object}	map[string]string
+//	@Failure		400		{object}	dto.ProblemDetails
+//	@Failure		409		{object}	dto.ProblemDetails
+//	@Failure		500		{object}	dto.ProblemDetails
 //	@Router			/v1/auth/signup [post]
 func (h *AuthHandler) SignUp(c *fiber.Ctx) error {
-	var body dto.SignupRequest
-	if err := c.BodyParser(&body); err != nil {
-		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
-			"error": "Cannot parse body",
-		})
+	body, err := validator.ParseAndValidateBody[dto.SignupRequest](c)
+	if err != nil {
+		return err
 	}
 
-	user, token, err := h.AuthService.SignUp(body.Username, body.Email, body.Password)
+	user, token, err := h.AuthService.SignUp(c.UserContext(), body.Username, body.Email, body.Password)
 	if err != nil {
-		return c.Status(fiber.StatusBadRequest).JSON(dto.ErrorResponse{
-			Error: err.Error(),
-		})
+		return err
 	}
 
 	return c.JSON(fiber.Map{
@@ -58,21 +58,19 @@ func (h *AuthHandler) SignUp(c *fiber.Ctx) error {
 //	@Produce		json
 //	@Param			credentials	body		dto.SigninRequest	true	"User credentials"
 //	@Success		200			{object}	map[string]string
+//	@Failure		400			{object}	dto.ProblemDetails
+//	@Failure		401			{object}	dto.ProblemDetails
+//	@Failure		500			{object}	dto.ProblemDetails
 //	@Router			/v1/auth/signin [post]
 func (h *AuthHandler) SignIn(c *fiber.Ctx) error {
-
-	var body dto.SigninRequest
-	if err := c.BodyParser(&body); err != nil {
-		return c.Status(fiber.StatusBadRequest).JSON(dto.ErrorResponse{
-			Error: "Cannot parse body",
-		})
+	body, err := validator.ParseAndValidateBody[dto.SigninRequest](c)
+	if err != nil {
+		return err
 	}
 
-	token, err := h.AuthService.SignIn(body.Email, body.Password)
+	token, err := h.AuthService.SignIn(c.UserContext(), body.Email, body.Password)
 	if err != nil {
-		return c.Status(fiber.StatusUnauthorized).JSON(dto.ErrorResponse{
-			Error: err.Error(),
-		})
+		return err
 	}
 
 	return c.JSON(fiber.Map{
@@ -0,0 +1,310 @@
+package handler
+
+import (
+	"github.com/gofiber/fiber/v2"
+	"github.com/maulana1k/forum-app/internal/app/dto"
+	"github.com/maulana1k/forum-app/internal/domain/service"
+	"github.com/maulana1k/forum-app/internal/pkg/utils"
+	"github.com/maulana1k/forum-app/internal/pkg/validator"
+)
+
+type CommunityHandler struct {
+	service     service.CommunityService
+	postService service.PostService
+}
+
+func NewCommunityHandler(service service.CommunityService, postService service.PostService) *CommunityHandler {
+	return &CommunityHandler{service: service, postService: postService}
+}
+
+// CreateCommunity godoc
+//
+//	@Summary		Create a community
+//	@Description	Create a community owned by the caller, who becomes its first member
+//	@Tags			Communities
+//	@Accept			json
+//	@Produce		json
+//	@Security		BearerAuth
+//	@Param			community	body		dto.CreateCommunityRequest	true	"Community details"
+//	@Success		201			{object}	dto.CommunityResponse
+//	@Failure		400			{object}	dto.ProblemDetails
+//	@Failure		401			{object}	dto.ProblemDetails
+//	@Failure		409			{object}	dto.ProblemDetails
+//	@Failure		500			{object}	dto.ProblemDetails
+//	@Router			/v1/communities/ [post]
+func (h *CommunityHandler) CreateCommunity(c *fiber.Ctx) error {
+	userID := c.Locals("userID").(string)
+
+	req, err := validator.ParseAndValidateBody[dto.CreateCommunityRequest](c)
+	if err != nil {
+		return err
+	}
+
+	community, err := h.service.CreateCommunity(c.UserContext(), userID, req)
+	if err != nil {
+		return err
+	}
+
+	return c.Status(fiber.StatusCreated).JSON(community)
+}
+
+// ListCommunities godoc
+//
+//	@Summary		List communities
+//	@Description	Retrieve communities, newest first. Private communities are only listed for their members.
+//	@Tags			Communities
+//	@Produce		json
+//	@Param			cursor	query		string	false	"Opaque cursor from next_cursor or prev_cursor"
+//	@Param			page	query		int		false	"Page number (legacy offset mode)"
+//	@Param			limit	query		int		false	"Number of communities per page"	default(10)
+//	@Success		200		{object}	dto.PaginatedCommunitiesResponse
+//	@Failure		400		{object}	dto.ProblemDetails
+//	@Failure		500		{object}	dto.ProblemDetails
+//	@Router			/v1/communities/ [get]
+func (h *CommunityHandler) ListCommunities(c *fiber.Ctx) error {
+	query, err := validator.ParseAndValidateQuery[dto.PostQueryParams](c)
+	if err != nil {
+		return err
+	}
+
+	communities, err := h.service.ListCommunities(c.UserContext(), query, utils.ViewerID(c))
+	if err != nil {
+		return err
+	}
+
+	return c.JSON(communities)
+}
+
+// GetCommunity godoc
+//
+//	@Summary		Get a community
+//	@Description	Retrieve a community by slug, with the caller's membership if signed in
+//	@Tags			Communities
+//	@Produce		json
+//	@Param			slug	path		string	true	"Community slug"
+//	@Success		200		{object}	dto.CommunityResponse
+//	@Failure		400		{object}	dto.ProblemDetails
+//	@Failure		404		{object}	dto.ProblemDetails
+//	@Failure		500		{object}	dto.ProblemDetails
+//	@Router			/v1/communities/{slug} [get]
+func (h *CommunityHandler) GetCommunity(c *fiber.Ctx) error {
+	params, err := validator.ParseAndValidateParams[dto.CommunitySlugParams](c)
+	if err != nil {
+		return err
+	}
+
+	community, err := h.service.GetCommunity(c.UserContext(), params.Slug, utils.ViewerID(c))
+	if err != nil {
+		return err
+	}
+
+	return c.JSON(community)
+}
+
+// UpdateCommunity godoc
+//
+//	@Summary		Update a community
+//	@Description	Update a community's details. Moderators may edit the name, description and rules; only the owner may change visibility.
+//	@Tags			Communities
+//	@Accept			json
+//	@Produce		json
+//	@Security		BearerAuth
+//	@Param			slug		path		string						true	"Community slug"
+//	@Param			community	body		dto.UpdateCommunityRequest	true	"Updated details"
+//	@Success		200			{object}	dto.CommunityResponse
+//	@Failure		400			{object}	dto.ProblemDetails
+//	@Failure		401			{object}	dto.ProblemDetails
+//	@Failure		403			{object}	dto.ProblemDetails
+//	@Failure		404			{object}	dto.ProblemDetails
+//	@Failure		500			{object}	dto.ProblemDetails
+//	@Router			/v1/communities/{slug} [patch]
+func (h *CommunityHandler) UpdateCommunity(c *fiber.Ctx) error {
+	userID := c.Locals("userID").(string)
+
+	params, err := validator.ParseAndValidateParams[dto.CommunitySlugParams](c)
+	if err != nil {
+		return err
+	}
+
+	req, err := validator.ParseAndValidateBody[dto.UpdateCommunityRequest](c)
+	if err != nil {
+		return err
+	}
+
+	community, err := h.service.UpdateCommunity(c.UserContext(), params.Slug, userID, req)
+	if err != nil {
+		return err
+	}
+
+	return c.JSON(community)
+}
+
+// JoinCommunity godoc
+//
+//	@Summary		Join a community
+//	@Description	Join a public community, or ask to join a restricted or private one. Requests stay pending until a moderator approves them.
+//	@Tags			Communities
+//	@Produce		json
+//	@Security		BearerAuth
+//	@Param			slug	path		string	true	"Community slug"
+//	@Success		201		{object}	dto.MembershipResponse
+//	@Failure		400		{object}	dto.ProblemDetails
+//	@Failure		401		{object}	dto.ProblemDetails
+//	@Failure		403		{object}	dto.ProblemDetails
+//	@Failure		404		{object}	dto.ProblemDetails
+//	@Failure		409		{object}	dto.ProblemDetails
+//	@Failure		500		{object}	dto.ProblemDetails
+//	@Router			/v1/communities/{slug}/join [post]
+func (h *CommunityHandler) JoinCommunity(c *fiber.Ctx) error {
+	userID := c.Locals("userID").(string)
+
+	params, err := validator.ParseAndValidateParams[dto.CommunitySlugParams](c)
+	if err != nil {
+		return err
+	}
+
+	membership, err := h.service.JoinCommunity(c.UserContext(), params.Slug, userID)
+	if err != nil {
+		return err
+	}
+
+	return c.Status(fiber.StatusCreated).JSON(membership)
+}
+
+// LeaveCommunity godoc
+//
+//	@Summary		Leave a community
+//	@Description	End the caller's membership or withdraw a pending join request. The owner cannot leave.
+//	@Tags			Communities
+//	@Security		BearerAuth
+//	@Param			slug	path	string	true	"Community slug"
+//	@Success		204		"Left the community"
+//	@Failure		400		{object}	dto.ProblemDetails
+//	@Failure		401		{object}	dto.ProblemDetails
+//	@Failure		404		{object}	dto.ProblemDetails
+//	@Failure		409		{object}	dto.ProblemDetails
+//	@Failure		500		{object}	dto.ProblemDetails
+//	@Router			/v1/communities/{slug}/membership [delete]
+func (h *CommunityHandler) LeaveCommunity(c *fiber.Ctx) error {
+	userID := c.Locals("userID").(string)
+
+	params, err := validator.ParseAndValidateParams[dto.CommunitySlugParams](c)
+	if err != nil {
+		return err
+	}
+
+	if err := h.service.LeaveCommunity(c.UserContext(), params.Slug, userID); err != nil {
+		return err
+	}
+
+	return c.SendStatus(fiber.StatusNoContent)
+}
+
+// ListMembers godoc
+//
+//	@Summary		List community members
+//	@Description	Retrieve members, newest first. Moderators may also list pending join requests and bans.
+//	@Tags			Communities
+//	@Produce		json
+//	@Param			slug	path		string	true	"Community slug"
+//	@Param			status	query		string	false	"Membership status"	Enums(active, pending, banned)	default(active)
+//	@Param			cursor	query		string	false	"Opaque cursor from next_cursor or prev_cursor"
+//	@Param			limit	query		int		false	"Number of members per page"	default(10)
+//	@Success		200		{object}	dto.PaginatedCommunityMembersResponse
+//	@Failure		400		{object}	dto.ProblemDetails
+//	@Failure		403		{object}	dto.ProblemDetails
+//	@Failure		404		{object}	dto.ProblemDetails
+//	@Failure		500		{object}	dto.ProblemDetails
+//	@Router			/v1/communities/{slug}/members [get]
+func (h *CommunityHandler) ListMembers(c *fiber.Ctx) error {
+	params, err := validator.ParseAndValidateParams[dto.CommunitySlugParams](c)
+	if err != nil {
+		return err
+	}
+
+	query, err := validator.ParseAndValidateQuery[dto.CommunityMembersQuery](c)
+	if err != nil {
+		return err
+	}
+
+	members, err := h.service.ListCommunityMembers(c.UserContext(), params.Slug, utils.ViewerID(c), query)
+	if err != nil {
+		return err
+	}
+
+	return c.JSON(members)
+}
+
+// UpdateMember godoc
+//
+//	@Summary		Moderate a community member
+//	@Description	Approve a join request or lift a ban (status active), ban a user (status banned), or, as the owner, appoint or dismiss moderators (role). Users who never joined can be banned too.
+//	@Tags			Communities
+//	@Accept			json
+//	@Produce		json
+//	@Security		BearerAuth
+//	@Param			slug	path		string					true	"Community slug"
+//	@Param			userId	path		string					true	"User ID"
+//	@Param			member	body		dto.UpdateMemberRequest	true	"New role or status"
+//	@Success		200		{object}	dto.CommunityMemberResponse
+//	@Failure		400		{object}	dto.ProblemDetails
+//	@Failure		401		{object}	dto.ProblemDetails
+//	@Failure		403		{object}	dto.ProblemDetails
+//	@Failure		404		{object}	dto.ProblemDetails
+//	@Failure		500		{object}	dto.ProblemDetails
+//	@Router			/v1/communities/{slug}/members/{userId} [put]
+func (h *CommunityHandler) UpdateMember(c *fiber.Ctx) error {
+	userID := c.Locals("userID").(string)
+
+	params, err := validator.ParseAndValidateParams[dto.CommunityMemberParams](c)
+	if err != nil {
+		return err
+	}
+
+	req, err := validator.ParseAndValidateBody[dto.UpdateMemberRequest](c)
+	if err != nil {
+		return err
+	}
+
+	member, err := h.service.UpdateCommunityMember(c.UserContext(), params.Slug, userID, params.UserID, req)
+	if err != nil {
+		return err
+	}
+
+	return c.JSON(member)
+}
+
+// GetCommunityPosts godoc
+//
+//	@Summary		Get community posts
+//	@Description	Retrieve the posts of a community, newest first. Private communities are only readable by members.
+//	@Tags			Communities
+//	@Produce		json
+//	@Param			slug	path		string	true	"Community slug"
+//	@Param			cursor	query		string	false	"Opaque cursor from next_cursor or prev_cursor"
+//	@Param			page	query		int		false	"Page number (legacy offset mode)"
+//	@Param			limit	query		int		false	"Number of posts per page"	default(10)
+//	@Success		200		{object}	dto.PaginatedPostsResponse
+//	@Failure		400		{object}	dto.ProblemDetails
+//	@Failure		403		{object}	dto.ProblemDetails
+//	@Failure		404		{object}	dto.ProblemDetails
+//	@Failure		500		{object}	dto.ProblemDetails
+//	@Router			/v1/communities/{slug}/posts [get]
+func (h *CommunityHandler) GetCommunityPosts(c *fiber.Ctx) error {
+	params, err := validator.ParseAndValidateParams[dto.CommunitySlugParams](c)
+	if err != nil {
+		return err
+	}
+
+	query, err := validator.ParseAndValidateQuery[dto.PostQueryParams](c)
+	if err != nil {
+		return err
+	}
+
+	posts, err := h.postService.GetCommunityPosts(c.UserContext(), params.Slug, query, utils.ViewerID(c))
+	if err != nil {
+		return err
+	}
+
+	return c.JSON(posts)
+}
@@ -0,0 +1,44 @@
+package handler
+
+import (
+	"github.com/gofiber/fiber/v2"
+	"github.com/maulana1k/forum-app/internal/app/dto"
+	"github.com/maulana1k/forum-app/internal/domain/service"
+	"github.com/maulana1k/forum-app/internal/pkg/validator"
+)
+
+type EngagementHandler struct {
+	service service.EngagementService
+}
+
+func NewEngagementHandler(s service.EngagementService) *EngagementHandler {
+	return &EngagementHandler{service: s}
+}
+
+// RecordEvents godoc
+//
+//	@Summary		Report post views and dwell times
+//	@Description	Report up to 100 views and dwell times seen by the client. They join the server's own reactions and recommendation clicks in the pseudonymous engagement stream used to train the recommender. Events are accepted without waiting for them to be stored.
+//	@Tags			Engagement
+//	@Accept			json
+//	@Security		BearerAuth
+//	@Param			events	body	dto.EngagementEventsRequest	true	"Events"
+//	@Success		202
+//	@Failure		400	{object}	dto.ProblemDetails
+//	@Failure		401	{object}	dto.ProblemDetails
+//	@Failure		500	{object}	dto.ProblemDetails
+//	@Router			/v1/engagement/events [post]
+func (h *EngagementHandler) RecordEvents(c *fiber.Ctx) error {
+	userID := c.Locals("userID").(string)
+
+	req, err := validator.ParseAndValidateBody[dto.EngagementEventsRequest](c)
+	if err != nil {
+		return err
+	}
+
+	if err := h.service.RecordEvents(c.UserContext(), userID, req); err != nil {
+		return err
+	}
+
+	return c.SendStatus(fiber.StatusAccepted)
+}
@@ -0,0 +1,195 @@
+package handler
+
+import (
+	"bytes"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/maulana1k/forum-app/internal/app/dto"
+	"github.com/maulana1k/forum-app/internal/domain/errs"
+	"github.com/maulana1k/forum-app/internal/domain/service"
+	"github.com/maulana1k/forum-app/internal/pkg/validator"
+)
+
+type MediaHandler struct {
+	service service.MediaService
+}
+
+func NewMediaHandler(service service.MediaService) *MediaHandler {
+	return &MediaHandler{service: service}
+}
+
+// Upload godoc
+//
+//	@Summary		Upload an image
+//	@Description	Upload a JPEG, PNG, GIF or WebP image as multipart form field "file". The type is sniffed from the bytes and a thumbnail is generated.
+//	@Tags			Media
+//	@Accept			multipart/form-data
+//	@Produce		json
+//	@Security		BearerAuth
+//	@Param			file	formData	file	true	"Image file"
+//	@Success		201		{object}	dto.MediaResponse
+//	@Failure		400		{object}	dto.ProblemDetails
+//	@Failure		401		{object}	dto.ProblemDetails
+//	@Failure		500		{object}	dto.ProblemDetails
+//	@Router			/v1/media/ [post]
+func (h *MediaHandler) Upload(c *fiber.Ctx) error {
+	userID := c.Locals("userID").(string)
+
+	header, err := c.FormFile("file")
+	if err != nil {
+		return errs.ErrMediaFileMissing.Wrap(err)
+	}
+	file, err := header.Open()
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+
+	media, err := h.service.Upload(c.UserContext(), userID, file)
+	if err != nil {
+		return err
+	}
+
+	return c.Status(fiber.StatusCreated).JSON(media)
+}
+
+// CreateUpload godoc
+//
+//	@Summary		Start a presigned upload
+//	@Description	Reserve a media ID and get a URL to PUT the image to. Complete the upload with POST /v1/media/{id}/complete.
+//	@Tags			Media
+//	@Accept			json
+//	@Produce		json
+//	@Security		BearerAuth
+//	@Param			upload	body		dto.CreateUploadRequest	true	"Upload details"
+//	@Success		201		{object}	dto.UploadResponse
+//	@Failure		400		{object}	dto.ProblemDetails
+//	@Failure		401		{object}	dto.ProblemDetails
+//	@Failure		500		{object}	dto.ProblemDetails
+//	@Router			/v1/media/uploads [post]
+func (h *MediaHandler) CreateUpload(c *fiber.Ctx) error {
+	userID := c.Locals("userID").(string)
+
+	req, err := validator.ParseAndValidateBody[dto.CreateUploadRequest](c)
+	if err != nil {
+		return err
+	}
+
+	upload, err := h.service.CreateUpload(c.UserContext(), userID, req)
+	if err != nil {
+		return err
+	}
+
+	return c.Status(fiber.StatusCreated).JSON(upload)
+}
+
+// ReceiveUpload godoc
+//
+//	@Summary		Receive a presigned upload
+//	@Description	Target of upload URLs issued by the local storage driver. The signed token authorises the request while the media is still pending; the body must be an accepted image.
+//	@Tags			Media
+//	@Accept			octet-stream
+//	@Param			token	path	string	true	"Signed upload token"
+//	@Success		204		"Upload stored"
+//	@Failure		400		{object}	dto.ProblemDetails
+//	@Failure		401		{object}	dto.ProblemDetails
+//	@Failure		500		{object}	dto.ProblemDetails
+//	@Router			/v1/media/uploads/{token} [put]
+func (h *MediaHandler) ReceiveUpload(c *fiber.Ctx) error {
+	params, err := validator.ParseAndValidateParams[dto.UploadTokenParams](c)
+	if err != nil {
+		return err
+	}
+
+	if err := h.service.ReceiveUpload(c.UserContext(), params.Token, bytes.NewReader(c.Body())); err != nil {
+		return err
+	}
+
+	return c.SendStatus(fiber.StatusNoContent)
+}
+
+// Download godoc
+//
+//	@Summary		Download a private file
+//	@Description	Target of download links issued by the local storage driver, such as data exports. The signed token authorises the request.
+//	@Tags			Media
+//	@Produce		octet-stream
+//	@Param			token	path	string	true	"Signed download token"
+//	@Success		200		{file}		file
+//	@Failure		400		{object}	dto.ProblemDetails
+//	@Failure		401		{object}	dto.ProblemDetails
+//	@Failure		500		{object}	dto.ProblemDetails
+//	@Router			/v1/media/downloads/{token} [get]
+func (h *MediaHandler) Download(c *fiber.Ctx) error {
+	params, err := validator.ParseAndValidateParams[dto.UploadTokenParams](c)
+	if err != nil {
+		return err
+	}
+
+	body, filename, err := h.service.OpenDownload(c.UserContext(), params.Token)
+	if err != nil {
+		return err
+	}
+
+	c.Attachment(filename)
+	c.Set(fiber.HeaderCacheControl, "private, no-store")
+	return c.SendStream(body)
+}
+
+// CompleteUpload godoc
+//
+//	@Summary		Complete a presigned upload
+//	@Description	Validate and process an image uploaded to a presigned URL
+//	@Tags			Media
+//	@Produce		json
+//	@Security		BearerAuth
+//	@Param			id	path		string	true	"Media ID"
+//	@Success		200	{object}	dto.MediaResponse
+//	@Failure		400	{object}	dto.ProblemDetails
+//	@Failure		401	{object}	dto.ProblemDetails
+//	@Failure		403	{object}	dto.ProblemDetails
+//	@Failure		404	{object}	dto.ProblemDetails
+//	@Failure		409	{object}	dto.ProblemDetails
+//	@Failure		500	{object}	dto.ProblemDetails
+//	@Router			/v1/media/{id}/complete [post]
+func (h *MediaHandler) CompleteUpload(c *fiber.Ctx) error {
+	userID := c.Locals("userID").(string)
+
+	params, err := validator.ParseAndValidateParams[dto.MediaIDParams](c)
+	if err != nil {
+		return err
+	}
+
+	media, err := h.service.CompleteUpload(c.UserContext(), userID, params.ID)
+	if err != nil {
+		return err
+	}
+
+	return c.JSON(media)
+}
+
+// GetMedia godoc
+//
+//	@Summary		Get media by ID
+//	@Description	Retrieve an uploaded image's metadata and URLs
+//	@Tags			Media
+//	@Produce		json
+//	@Param			id	path		string	true	"Media ID"
+//	@Success		200	{object}	dto.MediaResponse
+//	@Failure		400	{object}	dto.ProblemDetails
+//	@Failure		404	{object}	dto.ProblemDetails
+//	@Failure		500	{object}	dto.ProblemDetails
+//	@Router			/v1/media/{id} [get]
+func (h *MediaHandler) GetMedia(c *fiber.Ctx) error {
+	params, err := validator.ParseAndValidateParams[dto.MediaIDParams](c)
+	if err != nil {
+		return err
+	}
+
+	media, err := h.service.GetMedia(c.UserContext(), params.ID)
+	if err != nil {
+		return err
+	}
+
+	return c.JSON(media)
+}
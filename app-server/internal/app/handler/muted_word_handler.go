@@ -0,0 +1,95 @@
+package handler
+
+import (
+	"github.com/gofiber/fiber/v2"
+	"github.com/maulana1k/forum-app/internal/app/dto"
+	"github.com/maulana1k/forum-app/internal/domain/service"
+	"github.com/maulana1k/forum-app/internal/pkg/validator"
+)
+
+type MutedWordHandler struct {
+	service service.MutedWordService
+}
+
+func NewMutedWordHandler(service service.MutedWordService) *MutedWordHandler {
+	return &MutedWordHandler{service: service}
+}
+
+// AddMutedWord godoc
+//
+//	@Summary		Mute a word, phrase or hashtag
+//	@Description	Posts and replies containing the term come back in feeds with a "filtered" reason so clients can collapse them. Matching ignores case and punctuation and only hits whole words; "#tag" only matches the hashtag.
+//	@Tags			Relations
+//	@Accept			json
+//	@Produce		json
+//	@Security		BearerAuth
+//	@Param			word	body		dto.CreateMutedWordRequest	true	"Term to mute"
+//	@Success		201		{object}	dto.MutedWordResponse
+//	@Failure		400		{object}	dto.ProblemDetails
+//	@Failure		401		{object}	dto.ProblemDetails
+//	@Failure		500		{object}	dto.ProblemDetails
+//	@Router			/v1/me/muted-words [post]
+func (h *MutedWordHandler) AddMutedWord(c *fiber.Ctx) error {
+	userID := c.Locals("userID").(string)
+
+	req, err := validator.ParseAndValidateBody[dto.CreateMutedWordRequest](c)
+	if err != nil {
+		return err
+	}
+
+	word, err := h.service.AddMutedWord(c.UserContext(), userID, req)
+	if err != nil {
+		return err
+	}
+
+	return c.Status(fiber.StatusCreated).JSON(word)
+}
+
+// ListMutedWords godoc
+//
+//	@Summary		List muted words
+//	@Description	Retrieve the caller's muted words, phrases and hashtags that have not expired, newest first
+//	@Tags			Relations
+//	@Produce		json
+//	@Security		BearerAuth
+//	@Success		200	{array}		dto.MutedWordResponse
+//	@Failure		401	{object}	dto.ProblemDetails
+//	@Failure		500	{object}	dto.ProblemDetails
+//	@Router			/v1/me/muted-words [get]
+func (h *MutedWordHandler) ListMutedWords(c *fiber.Ctx) error {
+	userID := c.Locals("userID").(string)
+
+	words, err := h.service.ListMutedWords(c.UserContext(), userID)
+	if err != nil {
+		return err
+	}
+
+	return c.JSON(words)
+}
+
+// RemoveMutedWord godoc
+//
+//	@Summary		Unmute a word
+//	@Tags			Relations
+//	@Security		BearerAuth
+//	@Param			id	path	int	true	"Muted word ID"
+//	@Success		204	"Word unmuted"
+//	@Failure		400	{object}	dto.ProblemDetails
+//	@Failure		401	{object}	dto.ProblemDetails
+//	@Failure		404	{object}	dto.ProblemDetails
+//	@Failure		500	{object}	dto.ProblemDetails
+//	@Router			/v1/me/muted-words/{id} [delete]
+func (h *MutedWordHandler) RemoveMutedWord(c *fiber.Ctx) error {
+	userID := c.Locals("userID").(string)
+
+	params, err := validator.ParseAndValidateParams[dto.MutedWordIDParams](c)
+	if err != nil {
+		return err
+	}
+
+	if err := h.service.RemoveMutedWord(c.UserContext(), userID, params.ID); err != nil {
+		return err
+	}
+
+	return c.SendStatus(fiber.StatusNoContent)
+}
@@ -1,11 +1,11 @@
 package handler
 
 import (
-	"strconv"
-
 	"github.com/gofiber/fiber/v2"
 	"github.com/maulana1k/forum-app/internal/app/dto"
 	"github.com/maulana1k/forum-app/internal/domain/service"
+	"github.com/maulana1k/forum-app/internal/pkg/utils"
+	"github.com/maulana1k/forum-app/internal/pkg/validator"
 )
 
 type IPostHandler interface {
@@ -14,8 +14,17 @@ type IPostHandler interface {
 	GetPostByID(c *fiber.Ctx) error
 	UpdatePost(c *fiber.Ctx) error
 	GetUserPosts(c *fiber.Ctx) error
+	GetReplies(c *fiber.Ctx) error
+	GetRevisions(c *fiber.Ctx) error
+	GetDrafts(c *fiber.Ctx) error
+	PublishPost(c *fiber.Ctx) error
 	LikePost(c *fiber.Ctx) error
 	UnlikePost(c *fiber.Ctx) error
+	React(c *fiber.Ctx) error
+	Unreact(c *fiber.Ctx) error
+	GetReactionUsers(c *fiber.Ctx) error
+	Vote(c *fiber.Ctx) error
+	Unvote(c *fiber.Ctx) error
 	// BookmarkPost(c *fiber.Ctx) error
 	// UnbookmarkPost(c *fiber.Ctx) error
 	DeletePost(c *fiber.Ctx) error
@@ -41,25 +50,21 @@ func NewPostHandler(postService service.PostService) IPostHandler {
 //	@Security		BearerAuth
 //	@Param			post	body		dto.CreatePostRequest	true	"Post data"
 //	@Success		201		{object}	dto.PostResponse
-//	@Failure		400		{object}	dto.ErrorResponse
-//	@Failure		401		{object}	dto.ErrorResponse
-//	@Failure		500		{object}	dto.ErrorResponse
+//	@Failure		400		{object}	dto.ProblemDetails
+//	@Failure		401		{object}	dto.ProblemDetails
+//	@Failure		500		{object}	dto.ProblemDetails
 //	@Router			/v1/posts/ [post]
 func (h *PostHandler) CreatePost(c *fiber.Ctx) error {
 	userID := c.Locals("userID").(string)
 
-	var req dto.CreatePostRequest
-	if err := c.BodyParser(&req); err != nil {
-		return c.Status(fiber.StatusBadRequest).JSON(dto.ErrorResponse{
-			Error: "Cannot parse request body",
-		})
+	req, err := validator.ParseAndValidateBody[dto.CreatePostRequest](c)
+	if err != nil {
+		return err
 	}
 
-	post, err := h.postService.CreatePost(userID, &req)
+	post, err := h.postService.CreatePost(c.UserContext(), userID, req)
 	if err != nil {
-		return c.Status(fiber.StatusBadRequest).JSON(dto.ErrorResponse{
-			Error: err.Error(),
-		})
+		return err
 	}
 
 	return c.Status(fiber.StatusCreated).JSON(post)
@@ -72,21 +77,22 @@ func (h *PostHandler) CreatePost(c *fiber.Ctx) error {
 //	@Tags			Posts
 //	@Accept			json
 //	@Produce		json
-//	@Param			page	query		int	false	"Page number"				default(1)
-//	@Param			limit	query		int	false	"Number of posts per page"	default(10)
+//	@Param			cursor	query		string	false	"Opaque cursor from next_cursor or prev_cursor"
+//	@Param			page	query		int		false	"Page number (legacy offset mode)"
+//	@Param			limit	query		int		false	"Number of posts per page"	default(10)
 //	@Success		200		{object}	dto.PaginatedPostsResponse
-//	@Failure		400		{object}	dto.ErrorResponse
-//	@Failure		500		{object}	dto.ErrorResponse
+//	@Failure		400		{object}	dto.ProblemDetails
+//	@Failure		500		{object}	dto.ProblemDetails
 //	@Router			/v1/posts/ [get]
 func (h *PostHandler) GetAllPosts(c *fiber.Ctx) error {
-	page, _ := strconv.Atoi(c.Query("page", "1"))
-	limit, _ := strconv.Atoi(c.Query("limit", "10"))
+	query, err := validator.ParseAndValidateQuery[dto.PostQueryParams](c)
+	if err != nil {
+		return err
+	}
 
-	posts, err := h.postService.GetAllPosts(page, limit)
+	posts, err := h.postService.GetAllPosts(c.UserContext(), query, utils.ViewerID(c))
 	if err != nil {
-		return c.Status(fiber.StatusInternalServerError).JSON(dto.ErrorResponse{
-			Error: err.Error(),
-		})
+		return err
 	}
 
 	return c.JSON(posts)
@@ -101,28 +107,19 @@ func (h *PostHandler) GetAllPosts(c *fiber.Ctx) error {
 //	@Produce		json
 //	@Param			id	path		string	true	"Post ID"
 //	@Success		200	{object}	dto.PostResponse
-//	@Failure		400	{object}	dto.ErrorResponse
-//	@Failure		404	{object}	dto.ErrorResponse
-//	@Failure		500	{object}	dto.ErrorResponse
+//	@Failure		400	{object}	dto.ProblemDetails
+//	@Failure		404	{object}	dto.ProblemDetails
+//	@Failure		500	{object}	dto.ProblemDetails
 //	@Router			/v1/posts/{id} [get]
 func (h *PostHandler) GetPostByID(c *fiber.Ctx) error {
-	id := c.Params("id")
-	if id == "" {
-		return c.Status(fiber.StatusBadRequest).JSON(dto.ErrorResponse{
-			Error: "Invalid post ID",
-		})
+	params, err := validator.ParseAndValidateParams[dto.PostIDParams](c)
+	if err != nil {
+		return err
 	}
 
-	post, err := h.postService.GetPostByID(id)
+	post, err := h.postService.GetPostByID(c.UserContext(), params.ID, utils.ViewerID(c))
 	if err != nil {
-		if err.Error() == "post not found" {
-			return c.Status(fiber.StatusNotFound).JSON(dto.ErrorResponse{
-				Error: err.Error(),
-			})
-		}
-		return c.Status(fiber.StatusInternalServerError).JSON(dto.ErrorResponse{
-			Error: err.Error(),
-		})
+		return err
 	}
 
 	return c.JSON(post)
@@ -139,45 +136,28 @@ func (h *PostHandler) GetPostByID(c *fiber.Ctx) error {
 //	@Param			id		path		string						true	"Post ID"
 //	@Param			post	body		dto.UpdatePostRequest	true	"Updated post data"
 //	@Success		200		{object}	dto.PostResponse
-//	@Failure		400		{object}	dto.ErrorResponse
-//	@Failure		401		{object}	dto.ErrorResponse
-//	@Failure		403		{object}	dto.ErrorResponse
-//	@Failure		404		{object}	dto.ErrorResponse
-//	@Failure		500		{object}	dto.ErrorResponse
+//	@Failure		400		{object}	dto.ProblemDetails
+//	@Failure		401		{object}	dto.ProblemDetails
+//	@Failure		403		{object}	dto.ProblemDetails
+//	@Failure		404		{object}	dto.ProblemDetails
+//	@Failure		500		{object}	dto.ProblemDetails
 //	@Router			/v1/posts/{id} [put]
 func (h *PostHandler) UpdatePost(c *fiber.Ctx) error {
 	userID := c.Locals("userID").(string) // From JWT middleware
 
-	id := c.Params("id")
-	if id == "" {
-		return c.Status(fiber.StatusBadRequest).JSON(dto.ErrorResponse{
-			Error: "Invalid post ID",
-		})
+	params, err := validator.ParseAndValidateParams[dto.PostIDParams](c)
+	if err != nil {
+		return err
 	}
 
-	var req dto.UpdatePostRequest
-	if err := c.BodyParser(&req); err != nil {
-		return c.Status(fiber.StatusBadRequest).JSON(dto.ErrorResponse{
-			Error: "Cannot parse request body",
-		})
+	req, err := validator.ParseAndValidateBody[dto.UpdatePostRequest](c)
+	if err != nil {
+		return err
 	}
 
-	post, err := h.postService.UpdatePost(id, userID, &req)
+	post, err := h.postService.UpdatePost(c.UserContext(), params.ID, userID, req)
 	if err != nil {
-		switch err.Error() {
-		case "post not found":
-			return c.Status(fiber.StatusNotFound).JSON(dto.ErrorResponse{
-				Error: err.Error(),
-			})
-		case "unauthorized to update this post":
-			return c.Status(fiber.StatusForbidden).JSON(dto.ErrorResponse{
-				Error: err.Error(),
-			})
-		default:
-			return c.Status(fiber.StatusInternalServerError).JSON(dto.ErrorResponse{
-				Error: err.Error(),
-			})
-		}
+		return err
 	}
 
 	return c.JSON(post)
@@ -193,38 +173,22 @@ func (h *PostHandler) UpdatePost(c *fiber.Ctx) error {
 //	@Security		BearerAuth
 //	@Param			id	path	string	true	"Post ID"
 //	@Success		204	"Post deleted successfully"
-//	@Failure		400	{object}	dto.ErrorResponse
-//	@Failure		401	{object}	dto.ErrorResponse
-//	@Failure		403	{object}	dto.ErrorResponse
-//	@Failure		404	{object}	dto.ErrorResponse
-//	@Failure		500	{object}	dto.ErrorResponse
+//	@Failure		400	{object}	dto.ProblemDetails
+//	@Failure		401	{object}	dto.ProblemDetails
+//	@Failure		403	{object}	dto.ProblemDetails
+//	@Failure		404	{object}	dto.ProblemDetails
+//	@Failure		500	{object}	dto.ProblemDetails
 //	@Router			/v1/posts/{id} [delete]
 func (h *PostHandler) DeletePost(c *fiber.Ctx) error {
 	userID := c.Locals("userID").(string) // From JWT middleware
 
-	id := c.Params("id")
-	if id != "" {
-		return c.Status(fiber.StatusBadRequest).JSON(dto.ErrorResponse{
-			Error: "Invalid post ID",
-		})
+	params, err := validator.ParseAndValidateParams[dto.PostIDParams](c)
+	if err != nil {
+		return err
 	}
 
-	err := h.postService.DeletePost(id, userID)
-	if err != nil {
-		switch err.Error() {
-		case "post not found":
-			return c.Status(fiber.StatusNotFound).JSON(dto.ErrorResponse{
-				Error: err.Error(),
-			})
-		case "unauthorized to delete this post":
-			return c.Status(fiber.StatusForbidden).JSON(dto.ErrorResponse{
-				Error: err.Error(),
-			})
-		default:
-			return c.Status(fiber.StatusInternalServerError).JSON(dto.ErrorResponse{
-				Error: err.Error(),
-			})
-		}
+	if err := h.postService.DeletePost(c.UserContext(), params.ID, userID); err != nil {
+		return err
 	}
 
 	return c.SendStatus(fiber.StatusNoContent)
@@ -238,28 +202,27 @@ func (h *PostHandler) DeletePost(c *fiber.Ctx) error {
 //	@Accept			json
 //	@Produce		json
 //	@Param			id	path		string	true	"User ID"
-//	@Param			page	query		int	false	"Page number"				default(1)
-//	@Param			limit	query		int	false	"Number of posts per page"	default(10)
+//	@Param			cursor	query		string	false	"Opaque cursor from next_cursor or prev_cursor"
+//	@Param			page	query		int		false	"Page number (legacy offset mode)"
+//	@Param			limit	query		int		false	"Number of posts per page"	default(10)
 //	@Success		200		{object}	dto.PaginatedPostsResponse
-//	@Failure		400		{object}	dto.ErrorResponse
-//	@Failure		500		{object}	dto.ErrorResponse
+//	@Failure		400		{object}	dto.ProblemDetails
+//	@Failure		500		{object}	dto.ProblemDetails
 //	@Router			/v1/posts/user/{id} [get]
 func (h *PostHandler) GetUserPosts(c *fiber.Ctx) error {
-	userID := c.Params("id")
-	if userID == "" {
-		return c.Status(fiber.StatusBadRequest).JSON(dto.ErrorResponse{
-			Error: "Invalid user ID",
-		})
+	params, err := validator.ParseAndValidateParams[dto.UserIDParams](c)
+	if err != nil {
+		return err
 	}
 
-	page, _ := strconv.Atoi(c.Query("page", "1"))
-	limit, _ := strconv.Atoi(c.Query("limit", "10"))
+	query, err := validator.ParseAndValidateQuery[dto.PostQueryParams](c)
+	if err != nil {
+		return err
+	}
 
-	posts, err := h.postService.GetPostsByUserID(userID, page, limit)
+	posts, err := h.postService.GetPostsByUserID(c.UserContext(), params.ID, query, utils.ViewerID(c))
 	if err != nil {
-		return c.Status(fiber.StatusInternalServerError).JSON(dto.ErrorResponse{
-			Error: err.Error(),
-		})
+		return err
 	}
 
 	return c.JSON(posts)
@@ -275,37 +238,22 @@ func (h *PostHandler) GetUserPosts(c *fiber.Ctx) error {
 //	@Security		BearerAuth
 //	@Param			id	path		string	true	"Post ID"
 //	@Success		200	{object}	map[string]string
-//	@Failure		400	{object}	dto.ErrorResponse
-//	@Failure		401	{object}	dto.ErrorResponse
-//	@Failure		404	{object}	dto.ErrorResponse
-//	@Failure		500	{object}	dto.ErrorResponse
+//	@Failure		400	{object}	dto.ProblemDetails
+//	@Failure		401	{object}	dto.ProblemDetails
+//	@Failure		404	{object}	dto.ProblemDetails
+//	@Failure		409	{object}	dto.ProblemDetails
+//	@Failure		500	{object}	dto.ProblemDetails
 //	@Router			/v1/posts/{id}/like [post]
 func (h *PostHandler) LikePost(c *fiber.Ctx) error {
 	userID := c.Locals("userID").(string) // From JWT middleware
 
-	postID := c.Params("id")
-	if postID == "" {
-		return c.Status(fiber.StatusBadRequest).JSON(dto.ErrorResponse{
-			Error: "Invalid post ID",
-		})
+	params, err := validator.ParseAndValidateParams[dto.PostIDParams](c)
+	if err != nil {
+		return err
 	}
 
-	err := h.postService.LikePost(postID, userID)
-	if err != nil {
-		switch err.Error() {
-		case "post not found":
-			return c.Status(fiber.StatusNotFound).JSON(dto.ErrorResponse{
-				Error: err.Error(),
-			})
-		case "post already liked":
-			return c.Status(fiber.StatusBadRequest).JSON(dto.ErrorResponse{
-				Error: err.Error(),
-			})
-		default:
-			return c.Status(fiber.StatusInternalServerError).JSON(dto.ErrorResponse{
-				Error: err.Error(),
-			})
-		}
+	if err := h.postService.LikePost(c.UserContext(), params.ID, userID); err != nil {
+		return err
 	}
 
 	return c.JSON(fiber.Map{"message": "Post liked successfully"})
@@ -321,37 +269,323 @@ func (h *PostHandler) LikePost(c *fiber.Ctx) error {
 //	@Security		BearerAuth
 //	@Param			id	path		string	true	"Post ID"
 //	@Success		200	{object}	map[string]string
-//	@Failure		400	{object}	dto.ErrorResponse
-//	@Failure		401	{object}	dto.ErrorResponse
-//	@Failure		500	{object}	dto.ErrorResponse
+//	@Failure		400	{object}	dto.ProblemDetails
+//	@Failure		401	{object}	dto.ProblemDetails
+//	@Failure		409	{object}	dto.ProblemDetails
+//	@Failure		500	{object}	dto.ProblemDetails
 //	@Router			/v1/posts/{id}/unlike [delete]
 func (h *PostHandler) UnlikePost(c *fiber.Ctx) error {
 	userID := c.Locals("userID").(string) // From JWT middleware
 
-	postID := c.Params("id")
-	if postID == "" {
-		return c.Status(fiber.StatusBadRequest).JSON(dto.ErrorResponse{
-			Error: "Invalid post ID",
-		})
+	params, err := validator.ParseAndValidateParams[dto.PostIDParams](c)
+	if err != nil {
+		return err
 	}
 
-	err := h.postService.UnlikePost(postID, userID)
-	if err != nil {
-		switch err.Error() {
-		case "post not liked":
-			return c.Status(fiber.StatusBadRequest).JSON(dto.ErrorResponse{
-				Error: err.Error(),
-			})
-		default:
-			return c.Status(fiber.StatusInternalServerError).JSON(dto.ErrorResponse{
-				Error: err.Error(),
-			})
-		}
+	if err := h.postService.UnlikePost(c.UserContext(), params.ID, userID); err != nil {
+		return err
 	}
 
 	return c.JSON(fiber.Map{"message": "Post unliked successfully"})
 }
 
+// React godoc
+//
+//	@Summary		React to a post
+//	@Description	Set the caller's reaction on a post, replacing any previous one
+//	@Tags			Posts
+//	@Accept			json
+//	@Produce		json
+//	@Security		BearerAuth
+//	@Param			id			path		string				true	"Post ID"
+//	@Param			reaction	body		dto.ReactRequest	true	"Reaction"
+//	@Success		200			{object}	map[string]string
+//	@Failure		400			{object}	dto.ProblemDetails
+//	@Failure		401			{object}	dto.ProblemDetails
+//	@Failure		404			{object}	dto.ProblemDetails
+//	@Failure		500			{object}	dto.ProblemDetails
+//	@Router			/v1/posts/{id}/reactions [put]
+func (h *PostHandler) React(c *fiber.Ctx) error {
+	userID := c.Locals("userID").(string) // From JWT middleware
+
+	params, err := validator.ParseAndValidateParams[dto.PostIDParams](c)
+	if err != nil {
+		return err
+	}
+
+	req, err := validator.ParseAndValidateBody[dto.ReactRequest](c)
+	if err != nil {
+		return err
+	}
+
+	if err := h.postService.React(c.UserContext(), params.ID, userID, req.Type); err != nil {
+		return err
+	}
+
+	return c.JSON(fiber.Map{"message": "Reaction saved"})
+}
+
+// Unreact godoc
+//
+//	@Summary		Remove a reaction
+//	@Description	Remove the caller's reaction from a post
+//	@Tags			Posts
+//	@Accept			json
+//	@Produce		json
+//	@Security		BearerAuth
+//	@Param			id	path		string	true	"Post ID"
+//	@Success		200	{object}	map[string]string
+//	@Failure		400	{object}	dto.ProblemDetails
+//	@Failure		401	{object}	dto.ProblemDetails
+//	@Failure		404	{object}	dto.ProblemDetails
+//	@Failure		500	{object}	dto.ProblemDetails
+//	@Router			/v1/posts/{id}/reactions [delete]
+func (h *PostHandler) Unreact(c *fiber.Ctx) error {
+	userID := c.Locals("userID").(string) // From JWT middleware
+
+	params, err := validator.ParseAndValidateParams[dto.PostIDParams](c)
+	if err != nil {
+		return err
+	}
+
+	if err := h.postService.Unreact(c.UserContext(), params.ID, userID); err != nil {
+		return err
+	}
+
+	return c.JSON(fiber.Map{"message": "Reaction removed"})
+}
+
+// GetReactionUsers godoc
+//
+//	@Summary		List users who reacted
+//	@Description	Retrieve the users who left a given reaction on a post, newest first, with cursor pagination. Users the caller blocked or was blocked by are left out.
+//	@Tags			Posts
+//	@Accept			json
+//	@Produce		json
+//	@Param			id		path		string	true	"Post ID"
+//	@Param			type	path		string	true	"Reaction type"
+//	@Param			cursor	query		string	false	"Opaque cursor from next_cursor or prev_cursor"
+//	@Param			limit	query		int		false	"Number of users per page"	default(10)
+//	@Success		200		{object}	dto.PaginatedReactionUsersResponse
+//	@Failure		400		{object}	dto.ProblemDetails
+//	@Failure		404		{object}	dto.ProblemDetails
+//	@Failure		500		{object}	dto.ProblemDetails
+//	@Router			/v1/posts/{id}/reactions/{type}/users [get]
+func (h *PostHandler) GetReactionUsers(c *fiber.Ctx) error {
+	params, err := validator.ParseAndValidateParams[dto.ReactionUsersParams](c)
+	if err != nil {
+		return err
+	}
+
+	query, err := validator.ParseAndValidateQuery[dto.PostQueryParams](c)
+	if err != nil {
+		return err
+	}
+
+	users, err := h.postService.GetReactionUsers(c.UserContext(), params.ID, params.Type, query, utils.ViewerID(c))
+	if err != nil {
+		return err
+	}
+
+	return c.JSON(users)
+}
+
+// Vote godoc
+//
+//	@Summary		Vote in a poll
+//	@Description	Vote for one option, or several in a multiple choice poll. Returns the poll with its results.
+//	@Tags			Posts
+//	@Accept			json
+//	@Produce		json
+//	@Security		BearerAuth
+//	@Param			id		path		string			true	"Post ID"
+//	@Param			vote	body		dto.VoteRequest	true	"Chosen options"
+//	@Success		200		{object}	dto.PollResponse
+//	@Failure		400		{object}	dto.ProblemDetails
+//	@Failure		401		{object}	dto.ProblemDetails
+//	@Failure		404		{object}	dto.ProblemDetails
+//	@Failure		409		{object}	dto.ProblemDetails
+//	@Failure		500		{object}	dto.ProblemDetails
+//	@Router			/v1/posts/{id}/poll/votes [post]
+func (h *PostHandler) Vote(c *fiber.Ctx) error {
+	userID := c.Locals("userID").(string) // From JWT middleware
+
+	params, err := validator.ParseAndValidateParams[dto.PostIDParams](c)
+	if err != nil {
+		return err
+	}
+
+	req, err := validator.ParseAndValidateBody[dto.VoteRequest](c)
+	if err != nil {
+		return err
+	}
+
+	poll, err := h.postService.Vote(c.UserContext(), params.ID, userID, req)
+	if err != nil {
+		return err
+	}
+
+	return c.JSON(poll)
+}
+
+// Unvote godoc
+//
+//	@Summary		Withdraw a poll vote
+//	@Description	Withdraw the caller's vote from a poll that is still open
+//	@Tags			Posts
+//	@Accept			json
+//	@Produce		json
+//	@Security		BearerAuth
+//	@Param			id	path		string	true	"Post ID"
+//	@Success		200	{object}	dto.PollResponse
+//	@Failure		400	{object}	dto.ProblemDetails
+//	@Failure		401	{object}	dto.ProblemDetails
+//	@Failure		404	{object}	dto.ProblemDetails
+//	@Failure		409	{object}	dto.ProblemDetails
+//	@Failure		500	{object}	dto.ProblemDetails
+//	@Router			/v1/posts/{id}/poll/votes [delete]
+func (h *PostHandler) Unvote(c *fiber.Ctx) error {
+	userID := c.Locals("userID").(string) // From JWT middleware
+
+	params, err := validator.ParseAndValidateParams[dto.PostIDParams](c)
+	if err != nil {
+		return err
+	}
+
+	poll, err := h.postService.Unvote(c.UserContext(), params.ID, userID)
+	if err != nil {
+		return err
+	}
+
+	return c.JSON(poll)
+}
+
+// GetReplies godoc
+//
+//	@Summary		Get replies to a post
+//	@Description	Retrieve the replies to a post, newest first, with cursor pagination
+//	@Tags			Posts
+//	@Accept			json
+//	@Produce		json
+//	@Param			id		path		string	true	"Post ID"
+//	@Param			cursor	query		string	false	"Opaque cursor from next_cursor or prev_cursor"
+//	@Param			page	query		int		false	"Page number (legacy offset mode)"
+//	@Param			limit	query		int		false	"Number of replies per page"	default(10)
+//	@Success		200		{object}	dto.PaginatedRepliesResponse
+//	@Failure		400		{object}	dto.ProblemDetails
+//	@Failure		404		{object}	dto.ProblemDetails
+//	@Failure		500		{object}	dto.ProblemDetails
+//	@Router			/v1/posts/{id}/replies [get]
+func (h *PostHandler) GetReplies(c *fiber.Ctx) error {
+	params, err := validator.ParseAndValidateParams[dto.PostIDParams](c)
+	if err != nil {
+		return err
+	}
+
+	query, err := validator.ParseAndValidateQuery[dto.PostQueryParams](c)
+	if err != nil {
+		return err
+	}
+
+	replies, err := h.postService.GetReplies(c.UserContext(), params.ID, query, utils.ViewerID(c))
+	if err != nil {
+		return err
+	}
+
+	return c.JSON(replies)
+}
+
+// GetRevisions godoc
+//
+//	@Summary		Get post edit history
+//	@Description	Retrieve every version of an edited post, oldest first, each with a diff against the previous one
+//	@Tags			Posts
+//	@Accept			json
+//	@Produce		json
+//	@Param			id	path		string	true	"Post ID"
+//	@Success		200	{object}	dto.PostRevisionsResponse
+//	@Failure		400	{object}	dto.ProblemDetails
+//	@Failure		404	{object}	dto.ProblemDetails
+//	@Failure		500	{object}	dto.ProblemDetails
+//	@Router			/v1/posts/{id}/revisions [get]
+func (h *PostHandler) GetRevisions(c *fiber.Ctx) error {
+	params, err := validator.ParseAndValidateParams[dto.PostIDParams](c)
+	if err != nil {
+		return err
+	}
+
+	revisions, err := h.postService.GetRevisions(c.UserContext(), params.ID, utils.ViewerID(c))
+	if err != nil {
+		return err
+	}
+
+	return c.JSON(revisions)
+}
+
+// GetDrafts godoc
+//
+//	@Summary		Get my drafts
+//	@Description	Retrieve the caller's drafts and scheduled posts, newest first, with cursor pagination
+//	@Tags			Posts
+//	@Accept			json
+//	@Produce		json
+//	@Security		BearerAuth
+//	@Param			cursor	query		string	false	"Opaque cursor from next_cursor or prev_cursor"
+//	@Param			limit	query		int		false	"Number of posts per page"	default(10)
+//	@Success		200		{object}	dto.PaginatedPostsResponse
+//	@Failure		400		{object}	dto.ProblemDetails
+//	@Failure		401		{object}	dto.ProblemDetails
+//	@Failure		500		{object}	dto.ProblemDetails
+//	@Router			/v1/me/drafts [get]
+func (h *PostHandler) GetDrafts(c *fiber.Ctx) error {
+	userID := c.Locals("userID").(string) // From JWT middleware
+
+	query, err := validator.ParseAndValidateQuery[dto.PostQueryParams](c)
+	if err != nil {
+		return err
+	}
+
+	drafts, err := h.postService.GetDrafts(c.UserContext(), userID, query)
+	if err != nil {
+		return err
+	}
+
+	return c.JSON(drafts)
+}
+
+// PublishPost godoc
+//
+//	@Summary		Publish a draft
+//	@Description	Publish a draft or scheduled post immediately
+//	@Tags			Posts
+//	@Accept			json
+//	@Produce		json
+//	@Security		BearerAuth
+//	@Param			id	path		string	true	"Post ID"
+//	@Success		200	{object}	dto.PostResponse
+//	@Failure		400	{object}	dto.ProblemDetails
+//	@Failure		401	{object}	dto.ProblemDetails
+//	@Failure		403	{object}	dto.ProblemDetails
+//	@Failure		404	{object}	dto.ProblemDetails
+//	@Failure		409	{object}	dto.ProblemDetails
+//	@Failure		500	{object}	dto.ProblemDetails
+//	@Router			/v1/posts/{id}/publish [post]
+func (h *PostHandler) PublishPost(c *fiber.Ctx) error {
+	userID := c.Locals("userID").(string) // From JWT middleware
+
+	params, err := validator.ParseAndValidateParams[dto.PostIDParams](c)
+	if err != nil {
+		return err
+	}
+
+	post, err := h.postService.PublishPost(c.UserContext(), params.ID, userID)
+	if err != nil {
+		return err
+	}
+
+	return c.JSON(post)
+}
+
 // BookmarkPost godoc
 //
 //	@Summary		Bookmark a post
@@ -362,17 +596,17 @@ func (h *PostHandler) UnlikePost(c *fiber.Ctx) error {
 //	@Security		Bearer
 //	@Param			id	path		int	true	"Post ID"
 //	@Success		200	{object}	map[string]string
-//	@Failure		400	{object}	dto.ErrorResponse
-//	@Failure		401	{object}	dto.ErrorResponse
-//	@Failure		404	{object}	dto.ErrorResponse
-//	@Failure		500	{object}	dto.ErrorResponse
+//	@Failure		400	{object}	dto.ProblemDetails
+//	@Failure		401	{object}	dto.ProblemDetails
+//	@Failure		404	{object}	dto.ProblemDetails
+//	@Failure		500	{object}	dto.ProblemDetails
 //	@Router			/v1/posts/{id}/bookmark [post]
 // func (h *PostHandler) BookmarkPost(c *fiber.Ctx) error {
 // 	userID := c.Locals("userID").(string) // From JWT middleware
 
 // 	postID := c.Params("id")
 // 	if postID != "" {
-// 		return c.Status(fiber.StatusBadRequest).JSON(dto.ErrorResponse{
+// 		return c.Status(fiber.StatusBadRequest).JSON(dto.ProblemDetails{
 // 			Error: "Invalid post ID",
 // 		})
 // 	}
@@ -381,15 +615,15 @@ func (h *PostHandler) UnlikePost(c *fiber.Ctx) error {
 // 	if err != nil {
 // 		switch err.Error() {
 // 		case "post not found":
-// 			return c.Status(fiber.StatusNotFound).JSON(dto.ErrorResponse{
+// 			return c.Status(fiber.StatusNotFound).JSON(dto.ProblemDetails{
 // 				Error: err.Error(),
 // 			})
 // 		case "post already bookmarked":
-// 			return c.Status(fiber.StatusBadRequest).JSON(dto.ErrorResponse{
+// 			return c.Status(fiber.StatusBadRequest).JSON(dto.ProblemDetails{
 // 				Error: err.Error(),
 // 			})
 // 		default:
-// 			return c.Status(fiber.StatusInternalServerError).JSON(dto.ErrorResponse{
+// 			return c.Status(fiber.StatusInternalServerError).JSON(dto.ProblemDetails{
 // 				Error: err.Error(),
 // 			})
 // 		}
@@ -408,16 +642,16 @@ func (h *PostHandler) UnlikePost(c *fiber.Ctx) error {
 //	@Security		Bearer
 //	@Param			id	path		int	true	"Post ID"
 //	@Success		200	{object}	map[string]string
-//	@Failure		400	{object}	dto.ErrorResponse
-//	@Failure		401	{object}	dto.ErrorResponse
-//	@Failure		500	{object}	dto.ErrorResponse
+//	@Failure		400	{object}	dto.ProblemDetails
+//	@Failure		401	{object}	dto.ProblemDetails
+//	@Failure		500	{object}	dto.ProblemDetails
 //	@Router			/v1/posts/{id}/unbookmark [delete]
 // func (h *PostHandler) UnbookmarkPost(c *fiber.Ctx) error {
 // 	userID := c.Locals("userID").(string) // From JWT middleware
 
 // 	postID := c.Params("id")
 // 	if postID != "" {
-// 		return c.Status(fiber.StatusBadRequest).JSON(dto.ErrorResponse{
+// 		return c.Status(fiber.StatusBadRequest).JSON(dto.ProblemDetails{
 // 			Error: "Invalid post ID",
 // 		})
 // 	}
@@ -426,11 +660,11 @@ func (h *PostHandler) UnlikePost(c *fiber.Ctx) error {
 // 	if err != nil {
 // 		switch err.Error() {
 // 		case "post not bookmarked":
-// 			return c.Status(fiber.StatusBadRequest).JSON(dto.ErrorResponse{
+// 			return c.Status(fiber.StatusBadRequest).JSON(dto.ProblemDetails{
 // 				Error: err.Error(),
 // 			})
 // 		default:
-// 			return c.Status(fiber.StatusInternalServerError).JSON(dto.ErrorResponse{
+// 			return c.Status(fiber.StatusInternalServerError).JSON(dto.ProblemDetails{
 // 				Error: err.Error(),
 // 			})
 // 		}
@@ -449,17 +683,17 @@ func (h *PostHandler) UnlikePost(c *fiber.Ctx) error {
 //	@Security		Bearer
 //	@Param			id	path		int	true	"Post ID"
 //	@Success		200	{object}	map[string]string
-//	@Failure		400	{object}	dto.ErrorResponse
-//	@Failure		401	{object}	dto.ErrorResponse
-//	@Failure		404	{object}	dto.ErrorResponse
-//	@Failure		500	{object}	dto.ErrorResponse
+//	@Failure		400	{object}	dto.ProblemDetails
+//	@Failure		401	{object}	dto.ProblemDetails
+//	@Failure		404	{object}	dto.ProblemDetails
+//	@Failure		500	{object}	dto.ProblemDetails
 //	@Router			/v1/posts/{id}/repost [post]
 // func (h *PostHandler) RepostPost(c *fiber.Ctx) error {
 // 	userID := c.Locals("userID").(uint) // From JWT middleware
 
 // 	postID, err := strconv.ParseUint(c.Params("id"), 10, 32)
 // 	if err != nil {
-// 		return c.Status(fiber.StatusBadRequest).JSON(dto.ErrorResponse{
+// 		return c.Status(fiber.StatusBadRequest).JSON(dto.ProblemDetails{
 // 			Error: "Invalid post ID",
 // 		})
 // 	}
@@ -468,15 +702,15 @@ func (h *PostHandler) UnlikePost(c *fiber.Ctx) error {
 // 	if err != nil {
 // 		switch err.Error() {
 // 		case "post not found":
-// 			return c.Status(fiber.StatusNotFound).JSON(dto.ErrorResponse{
+// 			return c.Status(fiber.StatusNotFound).JSON(dto.ProblemDetails{
 // 				Error: err.Error(),
 // 			})
 // 		case "post already reposted":
-// 			return c.Status(fiber.StatusBadRequest).JSON(dto.ErrorResponse{
+// 			return c.Status(fiber.StatusBadRequest).JSON(dto.ProblemDetails{
 // 				Error: err.Error(),
 // 			})
 // 		default:
-// 			return c.Status(fiber.StatusInternalServerError).JSON(dto.ErrorResponse{
+// 			return c.Status(fiber.StatusInternalServerError).JSON(dto.ProblemDetails{
 // 				Error: err.Error(),
 // 			})
 // 		}
@@ -495,16 +729,16 @@ func (h *PostHandler) UnlikePost(c *fiber.Ctx) error {
 //	@Security		Bearer
 //	@Param			id	path		int	true	"Post ID"
 //	@Success		200	{object}	map[string]string
-//	@Failure		400	{object}	dto.ErrorResponse
-//	@Failure		401	{object}	dto.ErrorResponse
-//	@Failure		500	{object}	dto.ErrorResponse
+//	@Failure		400	{object}	dto.ProblemDetails
+//	@Failure		401	{object}	dto.ProblemDetails
+//	@Failure		500	{object}	dto.ProblemDetails
 //	@Router			/v1/posts/{id}/unrepost [delete]
 // func (h *PostHandler) UnrepostPost(c *fiber.Ctx) error {
 // 	userID := c.Locals("userID").(uint) // From JWT middleware
 
 // 	postID, err := strconv.ParseUint(c.Params("id"), 10, 32)
 // 	if err != nil {
-// 		return c.Status(fiber.StatusBadRequest).JSON(dto.ErrorResponse{
+// 		return c.Status(fiber.StatusBadRequest).JSON(dto.ProblemDetails{
 // 			Error: "Invalid post ID",
 // 		})
 // 	}
@@ -513,11 +747,11 @@ func (h *PostHandler) UnlikePost(c *fiber.Ctx) error {
 // 	if err != nil {
 // 		switch err.Error() {
 // 		case "post not reposted":
-// 			return c.Status(fiber.StatusBadRequest).JSON(dto.ErrorResponse{
+// 			return c.Status(fiber.StatusBadRequest).JSON(dto.ProblemDetails{
 // 				Error: err.Error(),
 // 			})
 // 		default:
-// 			return c.Status(fiber.StatusInternalServerError).JSON(dto.ErrorResponse{
+// 			return c.Status(fiber.StatusInternalServerError).JSON(dto.ProblemDetails{
 // 				Error: err.Error(),
 // 			})
 // 		}
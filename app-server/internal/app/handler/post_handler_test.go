@@ -3,9 +3,11 @@ package handler_test
 import (
 	"bytes"
 	"encoding/json"
+	"io"
 	"net/http"
 	"net/http/httptest"
 	"testing"
+	"time"
 
 	"github.com/gofiber/fiber/v2"
 	"github.com/stretchr/testify/assert"
@@ -54,6 +56,29 @@ func parsePostResponse(t *testing.T, resp *http.Response) dto.PostResponse {
 	return post
 }
 
+// send makes a request to the app. A non-nil body is sent as JSON and a
+// non-empty token as a bearer token.
+func (s *PostHandlerTestSuite) send(method, path, token string, body any) *http.Response {
+	var reader io.Reader
+	if body != nil {
+		data, err := json.Marshal(body)
+		s.Require().NoError(err)
+		reader = bytes.NewReader(data)
+	}
+
+	req := httptest.NewRequest(method, path, reader)
+	if body != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+	if token != "" {
+		req.Header.Set("Authorization", "Bearer "+token)
+	}
+
+	resp, err := s.App.Test(req, -1)
+	s.Require().NoError(err)
+	return resp
+}
+
 // --------------------------
 // Tests
 // --------------------------
@@ -71,6 +96,111 @@ func (s *PostHandlerTestSuite) TestCreatePost() {
 	assert.Equal(s.T(), "Hello singleton", post.Content)
 }
 
+func (s *PostHandlerTestSuite) TestReactToPost() {
+	resp := s.send(http.MethodPost, "/api/v1/posts/", s.Token, dto.CreatePostRequest{Content: "React to me"})
+	s.Require().Equal(http.StatusCreated, resp.StatusCode)
+	post := parsePostResponse(s.T(), resp)
+
+	resp = s.send(http.MethodPut, "/api/v1/posts/"+post.ID+"/reactions", s.Token, dto.ReactRequest{Type: "love"})
+	assert.Equal(s.T(), http.StatusOK, resp.StatusCode)
+
+	resp = s.send(http.MethodGet, "/api/v1/posts/"+post.ID, s.Token, nil)
+	s.Require().Equal(http.StatusOK, resp.StatusCode)
+
+	post = parsePostResponse(s.T(), resp)
+	assert.Equal(s.T(), 1, post.Reactions["love"])
+	assert.Equal(s.T(), "love", post.MyReaction)
+}
+
+func (s *PostHandlerTestSuite) TestPollResultsHiddenUntilVoted() {
+	resp := s.send(http.MethodPost, "/api/v1/posts/", s.Token, dto.CreatePostRequest{
+		Content: "Tabs or spaces?",
+		Poll: &dto.CreatePollRequest{
+			Options:  []string{"tabs", "spaces"},
+			ClosesAt: time.Now().Add(time.Hour),
+		},
+	})
+	s.Require().Equal(http.StatusCreated, resp.StatusCode)
+
+	post := parsePostResponse(s.T(), resp)
+	s.Require().NotNil(post.Poll)
+	assert.False(s.T(), post.Poll.ResultsVisible)
+	assert.Nil(s.T(), post.Poll.Options[0].Votes)
+
+	resp = s.send(http.MethodPost, "/api/v1/posts/"+post.ID+"/poll/votes", s.Token,
+		dto.VoteRequest{OptionIDs: []uint{post.Poll.Options[0].ID}})
+	s.Require().Equal(http.StatusOK, resp.StatusCode)
+
+	var poll dto.PollResponse
+	s.Require().NoError(json.NewDecoder(resp.Body).Decode(&poll))
+	assert.True(s.T(), poll.ResultsVisible)
+	if assert.NotNil(s.T(), poll.Options[0].Votes) {
+		assert.Equal(s.T(), 1, *poll.Options[0].Votes)
+	}
+	assert.Equal(s.T(), []uint{post.Poll.Options[0].ID}, poll.MyVotes)
+}
+
+func (s *PostHandlerTestSuite) TestEditKeepsRevisions() {
+	resp := s.send(http.MethodPost, "/api/v1/posts/", s.Token, dto.CreatePostRequest{Content: "first draft"})
+	s.Require().Equal(http.StatusCreated, resp.StatusCode)
+	post := parsePostResponse(s.T(), resp)
+
+	content := "second draft"
+	resp = s.send(http.MethodPut, "/api/v1/posts/"+post.ID, s.Token, dto.UpdatePostRequest{Content: &content})
+	s.Require().Equal(http.StatusOK, resp.StatusCode)
+
+	updated := parsePostResponse(s.T(), resp)
+	assert.Equal(s.T(), 1, updated.RevisionCount)
+	assert.NotNil(s.T(), updated.EditedAt)
+
+	resp = s.send(http.MethodGet, "/api/v1/posts/"+post.ID+"/revisions", "", nil)
+	s.Require().Equal(http.StatusOK, resp.StatusCode)
+
+	var history dto.PostRevisionsResponse
+	s.Require().NoError(json.NewDecoder(resp.Body).Decode(&history))
+	if assert.Len(s.T(), history.Revisions, 2) {
+		assert.Equal(s.T(), "first draft", history.Revisions[0].Content)
+		assert.Nil(s.T(), history.Revisions[0].Diff)
+		assert.Equal(s.T(), []dto.DiffSegment{
+			{Op: "delete", Text: "first"},
+			{Op: "insert", Text: "second"},
+			{Op: "equal", Text: " draft"},
+		}, history.Revisions[1].Diff.Content)
+	}
+}
+
+func (s *PostHandlerTestSuite) TestDraftHiddenUntilPublished() {
+	resp := s.send(http.MethodPost, "/api/v1/posts/", s.Token, dto.CreatePostRequest{Content: "work in progress", Draft: true})
+	s.Require().Equal(http.StatusCreated, resp.StatusCode)
+
+	draft := parsePostResponse(s.T(), resp)
+	assert.Equal(s.T(), "draft", draft.Status)
+
+	resp = s.send(http.MethodGet, "/api/v1/posts/"+draft.ID, "", nil)
+	assert.Equal(s.T(), http.StatusNotFound, resp.StatusCode)
+
+	resp = s.send(http.MethodGet, "/api/v1/me/drafts", s.Token, nil)
+	s.Require().Equal(http.StatusOK, resp.StatusCode)
+
+	var drafts dto.PaginatedPostsResponse
+	s.Require().NoError(json.NewDecoder(resp.Body).Decode(&drafts))
+	assert.Condition(s.T(), func() bool {
+		for _, p := range drafts.Posts {
+			if p.ID == draft.ID {
+				return true
+			}
+		}
+		return false
+	}, "draft listed in /api/v1/me/drafts")
+
+	resp = s.send(http.MethodPost, "/api/v1/posts/"+draft.ID+"/publish", s.Token, nil)
+	s.Require().Equal(http.StatusOK, resp.StatusCode)
+	assert.Equal(s.T(), "published", parsePostResponse(s.T(), resp).Status)
+
+	resp = s.send(http.MethodGet, "/api/v1/posts/"+draft.ID, "", nil)
+	assert.Equal(s.T(), http.StatusOK, resp.StatusCode)
+}
+
 // --------------------------
 // Entry point
 // --------------------------
@@ -1,11 +1,10 @@
 package handler
 
 import (
-	"strconv"
-
 	"github.com/gofiber/fiber/v2"
 	"github.com/maulana1k/forum-app/internal/app/dto"
 	"github.com/maulana1k/forum-app/internal/domain/service"
+	"github.com/maulana1k/forum-app/internal/pkg/validator"
 )
 
 type RecommendationHandler struct {
@@ -18,34 +17,72 @@ func NewRecommendationHandler(s service.RecommendationService) *RecommendationHa
 
 // GetRecommendedPosts godoc
 //
-// @Summary      Get recommended posts for user
-// @Description  Retrieve personalized posts feed based on userID and topic
+// @Summary      Get recommended posts for the caller
+// @Description  Retrieve a page of personalized posts for the signed-in user, with the score and reasons behind each. Posts render like the main feed. Pass next_cursor as cursor for the following page, and the IDs of posts already shown as exclude. Report opened posts with recommendation_id to /v1/recommendation/clicks. When the recommendation service is slow or down, popular recent posts are served instead and source is "fallback". Admins can pass as_user to see another user's page; nothing is recorded then.
 // @Tags         Recommendations
 // @Produce      json
 //
 //	@Security		BearerAuth
 //
-// @Param        user_id query string true  "User ID"
-// @Param        topic   query string false "Topic filter"
-// @Param        limit   query int    false "Max number of posts"
-// @Success      200 {array} dto.PostResponse
-// @Failure      400 {object} dto.ErrorResponse
-// @Failure      500 {object} dto.ErrorResponse
+// @Param        as_user query string   false "User ID to recommend for (admins only)"
+// @Param        topic   query string   false "Topic filter"
+// @Param        limit   query int      false "Max number of posts"
+// @Param        cursor  query string   false "Cursor from the previous page"
+// @Param        exclude query []string false "IDs of posts already seen" collectionFormat(multi)
+// @Success      200 {object} dto.RecommendedPostsResponse
+// @Failure      400 {object} dto.ProblemDetails
+// @Failure      401 {object} dto.ProblemDetails
+// @Failure      403 {object} dto.ProblemDetails
+// @Failure      500 {object} dto.ProblemDetails
+// @Failure      503 {object} dto.ProblemDetails
 // @Router       /v1/recommendation/posts [get]
 func (h *RecommendationHandler) GetRecommendedPosts(c *fiber.Ctx) error {
-	userID := c.Query("user_id")
-	if userID == "" {
-		return c.Status(fiber.StatusBadRequest).JSON(dto.ErrorResponse{Error: "user_id is required"})
+	userID := c.Locals("userID").(string)
+
+	query, err := validator.ParseAndValidateQuery[dto.RecommendationQueryParams](c)
+	if err != nil {
+		return err
 	}
 
-	topic := c.Query("topic")
-	limitStr := c.Query("limit", "10")
-	limit, _ := strconv.Atoi(limitStr)
+	if query.Limit == 0 {
+		query.Limit = 10
+	}
 
-	posts, err := h.service.GetRecommendedPosts(userID, topic, limit)
+	posts, err := h.service.GetRecommendedPosts(c.UserContext(), userID, query)
 	if err != nil {
-		return c.Status(fiber.StatusInternalServerError).JSON(dto.ErrorResponse{Error: err.Error()})
+		return err
 	}
 
 	return c.JSON(posts)
 }
+
+// RecordClick godoc
+//
+// @Summary      Report a recommended post as opened
+// @Description  Record that the caller opened a post from a page of recommendations, identified by its recommendation_id. Repeated reports are ignored.
+// @Tags         Recommendations
+// @Accept       json
+//
+//	@Security		BearerAuth
+//
+// @Param        click body dto.RecommendationClickRequest true "Opened post"
+// @Success      204
+// @Failure      400 {object} dto.ProblemDetails
+// @Failure      401 {object} dto.ProblemDetails
+// @Failure      404 {object} dto.ProblemDetails
+// @Failure      500 {object} dto.ProblemDetails
+// @Router       /v1/recommendation/clicks [post]
+func (h *RecommendationHandler) RecordClick(c *fiber.Ctx) error {
+	userID := c.Locals("userID").(string)
+
+	req, err := validator.ParseAndValidateBody[dto.RecommendationClickRequest](c)
+	if err != nil {
+		return err
+	}
+
+	if err := h.service.RecordClick(c.UserContext(), userID, req); err != nil {
+		return err
+	}
+
+	return c.SendStatus(fiber.StatusNoContent)
+}
@@ -0,0 +1,49 @@
+package handler
+
+import (
+	"github.com/gofiber/fiber/v2"
+	"github.com/maulana1k/forum-app/internal/app/dto"
+	"github.com/maulana1k/forum-app/internal/domain/service"
+	"github.com/maulana1k/forum-app/internal/pkg/utils"
+	"github.com/maulana1k/forum-app/internal/pkg/validator"
+)
+
+type RelatedPostHandler struct {
+	service service.RelatedPostService
+}
+
+func NewRelatedPostHandler(s service.RelatedPostService) *RelatedPostHandler {
+	return &RelatedPostHandler{service: s}
+}
+
+// GetRelatedPosts godoc
+//
+//	@Summary		Get related posts
+//	@Description	Retrieve posts similar in content to a post, closest first, for a "related discussions" section. Posts by the same author and posts the caller has hidden, blocked or muted are left out. The list is empty until the post has been indexed, shortly after it is published.
+//	@Tags			Posts
+//	@Accept			json
+//	@Produce		json
+//	@Param			id		path		string	true	"Post ID"
+//	@Param			limit	query		int		false	"Number of posts"	default(5)
+//	@Success		200		{object}	dto.RelatedPostsResponse
+//	@Failure		400		{object}	dto.ProblemDetails
+//	@Failure		404		{object}	dto.ProblemDetails
+//	@Failure		500		{object}	dto.ProblemDetails
+//	@Router			/v1/posts/{id}/related [get]
+func (h *RelatedPostHandler) GetRelatedPosts(c *fiber.Ctx) error {
+	params, err := validator.ParseAndValidateParams[dto.PostIDParams](c)
+	if err != nil {
+		return err
+	}
+	query, err := validator.ParseAndValidateQuery[dto.RelatedPostsQueryParams](c)
+	if err != nil {
+		return err
+	}
+
+	related, err := h.service.GetRelatedPosts(c.UserContext(), params.ID, utils.ViewerID(c), query)
+	if err != nil {
+		return err
+	}
+
+	return c.JSON(related)
+}
@@ -0,0 +1,149 @@
+package handler
+
+import (
+	"context"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/maulana1k/forum-app/internal/app/dto"
+	"github.com/maulana1k/forum-app/internal/domain/service"
+	"github.com/maulana1k/forum-app/internal/pkg/validator"
+)
+
+type RelationHandler struct {
+	service service.RelationService
+}
+
+func NewRelationHandler(service service.RelationService) *RelationHandler {
+	return &RelationHandler{service: service}
+}
+
+// Block godoc
+//
+//	@Summary		Block a user
+//	@Description	Block a user. Neither of you will see the other's posts or be able to react to or quote them. Blocking twice is not an error.
+//	@Tags			Relations
+//	@Security		BearerAuth
+//	@Param			id	path	string	true	"User ID"
+//	@Success		204	"User blocked"
+//	@Failure		400	{object}	dto.ProblemDetails
+//	@Failure		401	{object}	dto.ProblemDetails
+//	@Failure		404	{object}	dto.ProblemDetails
+//	@Failure		500	{object}	dto.ProblemDetails
+//	@Router			/v1/me/blocks/{id} [put]
+func (h *RelationHandler) Block(c *fiber.Ctx) error {
+	return h.apply(c, h.service.Block)
+}
+
+// Unblock godoc
+//
+//	@Summary		Unblock a user
+//	@Tags			Relations
+//	@Security		BearerAuth
+//	@Param			id	path	string	true	"User ID"
+//	@Success		204	"User unblocked"
+//	@Failure		400	{object}	dto.ProblemDetails
+//	@Failure		401	{object}	dto.ProblemDetails
+//	@Failure		409	{object}	dto.ProblemDetails
+//	@Failure		500	{object}	dto.ProblemDetails
+//	@Router			/v1/me/blocks/{id} [delete]
+func (h *RelationHandler) Unblock(c *fiber.Ctx) error {
+	return h.apply(c, h.service.Unblock)
+}
+
+// Mute godoc
+//
+//	@Summary		Mute a user
+//	@Description	Keep a user's posts out of your feeds and recommendations. They are not told and can still see your posts. Muting twice is not an error.
+//	@Tags			Relations
+//	@Security		BearerAuth
+//	@Param			id	path	string	true	"User ID"
+//	@Success		204	"User muted"
+//	@Failure		400	{object}	dto.ProblemDetails
+//	@Failure		401	{object}	dto.ProblemDetails
+//	@Failure		404	{object}	dto.ProblemDetails
+//	@Failure		500	{object}	dto.ProblemDetails
+//	@Router			/v1/me/mutes/{id} [put]
+func (h *RelationHandler) Mute(c *fiber.Ctx) error {
+	return h.apply(c, h.service.Mute)
+}
+
+// Unmute godoc
+//
+//	@Summary		Unmute a user
+//	@Tags			Relations
+//	@Security		BearerAuth
+//	@Param			id	path	string	true	"User ID"
+//	@Success		204	"User unmuted"
+//	@Failure		400	{object}	dto.ProblemDetails
+//	@Failure		401	{object}	dto.ProblemDetails
+//	@Failure		409	{object}	dto.ProblemDetails
+//	@Failure		500	{object}	dto.ProblemDetails
+//	@Router			/v1/me/mutes/{id} [delete]
+func (h *RelationHandler) Unmute(c *fiber.Ctx) error {
+	return h.apply(c, h.service.Unmute)
+}
+
+// ListBlocked godoc
+//
+//	@Summary		List blocked users
+//	@Tags			Relations
+//	@Produce		json
+//	@Security		BearerAuth
+//	@Param			cursor	query		string	false	"Opaque cursor from next_cursor or prev_cursor"
+//	@Param			limit	query		int		false	"Number of users per page"	default(10)
+//	@Success		200		{object}	dto.PaginatedRelatedUsersResponse
+//	@Failure		400		{object}	dto.ProblemDetails
+//	@Failure		401		{object}	dto.ProblemDetails
+//	@Failure		500		{object}	dto.ProblemDetails
+//	@Router			/v1/me/blocks [get]
+func (h *RelationHandler) ListBlocked(c *fiber.Ctx) error {
+	return h.list(c, h.service.ListBlocked)
+}
+
+// ListMuted godoc
+//
+//	@Summary		List muted users
+//	@Tags			Relations
+//	@Produce		json
+//	@Security		BearerAuth
+//	@Param			cursor	query		string	false	"Opaque cursor from next_cursor or prev_cursor"
+//	@Param			limit	query		int		false	"Number of users per page"	default(10)
+//	@Success		200		{object}	dto.PaginatedRelatedUsersResponse
+//	@Failure		400		{object}	dto.ProblemDetails
+//	@Failure		401		{object}	dto.ProblemDetails
+//	@Failure		500		{object}	dto.ProblemDetails
+//	@Router			/v1/me/mutes [get]
+func (h *RelationHandler) ListMuted(c *fiber.Ctx) error {
+	return h.list(c, h.service.ListMuted)
+}
+
+func (h *RelationHandler) apply(c *fiber.Ctx, action func(ctx context.Context, userID, targetID string) error) error {
+	userID := c.Locals("userID").(string)
+
+	params, err := validator.ParseAndValidateParams[dto.UserIDParams](c)
+	if err != nil {
+		return err
+	}
+
+	if err := action(c.UserContext(), userID, params.ID); err != nil {
+		return err
+	}
+
+	return c.SendStatus(fiber.StatusNoContent)
+}
+
+func (h *RelationHandler) list(c *fiber.Ctx, list func(ctx context.Context, userID string, query *dto.PostQueryParams) (*dto.PaginatedRelatedUsersResponse, error)) error {
+	userID := c.Locals("userID").(string)
+
+	query, err := validator.ParseAndValidateQuery[dto.PostQueryParams](c)
+	if err != nil {
+		return err
+	}
+
+	users, err := list(c.UserContext(), userID, query)
+	if err != nil {
+		return err
+	}
+
+	return c.JSON(users)
+}
@@ -0,0 +1,69 @@
+package handler
+
+import (
+	"github.com/gofiber/fiber/v2"
+	"github.com/maulana1k/forum-app/internal/app/dto"
+	"github.com/maulana1k/forum-app/internal/domain/service"
+	"github.com/maulana1k/forum-app/internal/pkg/validator"
+)
+
+type SettingsHandler struct {
+	service service.SettingsService
+}
+
+func NewSettingsHandler(service service.SettingsService) *SettingsHandler {
+	return &SettingsHandler{service: service}
+}
+
+// GetSettings godoc
+//
+//	@Summary		Get settings
+//	@Description	Retrieve the caller's preferences. Users who never changed them get the defaults with version 0.
+//	@Tags			Users
+//	@Produce		json
+//	@Security		BearerAuth
+//	@Success		200	{object}	dto.SettingsResponse
+//	@Failure		401	{object}	dto.ProblemDetails
+//	@Failure		500	{object}	dto.ProblemDetails
+//	@Router			/v1/me/settings [get]
+func (h *SettingsHandler) GetSettings(c *fiber.Ctx) error {
+	userID := c.Locals("userID").(string)
+
+	settings, err := h.service.GetSettings(c.UserContext(), userID)
+	if err != nil {
+		return err
+	}
+
+	return c.JSON(settings)
+}
+
+// UpdateSettings godoc
+//
+//	@Summary		Update settings
+//	@Description	Change some of the caller's preferences; omitted fields keep their value. Send the version last read: if the settings changed since, the update is rejected with 409 and should be retried after reloading them.
+//	@Tags			Users
+//	@Accept			json
+//	@Produce		json
+//	@Security		BearerAuth
+//	@Param			settings	body		dto.UpdateSettingsRequest	true	"Changed preferences"
+//	@Success		200			{object}	dto.SettingsResponse
+//	@Failure		400			{object}	dto.ProblemDetails
+//	@Failure		401			{object}	dto.ProblemDetails
+//	@Failure		409			{object}	dto.ProblemDetails
+//	@Failure		500			{object}	dto.ProblemDetails
+//	@Router			/v1/me/settings [patch]
+func (h *SettingsHandler) UpdateSettings(c *fiber.Ctx) error {
+	userID := c.Locals("userID").(string)
+
+	req, err := validator.ParseAndValidateBody[dto.UpdateSettingsRequest](c)
+	if err != nil {
+		return err
+	}
+
+	settings, err := h.service.UpdateSettings(c.UserContext(), userID, req)
+	if err != nil {
+		return err
+	}
+
+	return c.JSON(settings)
+}
@@ -4,7 +4,10 @@ import (
 	"github.com/gofiber/fiber/v2"
 	"github.com/google/uuid"
 	"github.com/maulana1k/forum-app/internal/app/dto"
+	"github.com/maulana1k/forum-app/internal/domain/errs"
+	"github.com/maulana1k/forum-app/internal/domain/models"
 	"github.com/maulana1k/forum-app/internal/domain/service"
+	"github.com/maulana1k/forum-app/internal/pkg/validator"
 )
 
 type UserHandler struct {
@@ -23,26 +26,18 @@ func NewUserHandler(service service.UserService) *UserHandler {
 //	@Accept			json
 //	@Produce		json
 //	@Success		200	{array}	dto.UserResponse
-//	@Failure		500	{object}	dto.ErrorResponse
+//	@Failure		500	{object}	dto.ProblemDetails
 //	@Router			/v1/users/ [get]
 func (h *UserHandler) GetAllUsers(c *fiber.Ctx) error {
-	users, err := h.service.GetAllUsers()
+	users, err := h.service.GetAllUsers(c.UserContext())
 	if err != nil {
-		return c.Status(fiber.StatusInternalServerError).JSON(dto.ErrorResponse{Error: err.Error()})
+		return err
 	}
 
 	// Map GORM models to DTO
 	var response dto.UsersResponse
-	for _, u := range users {
-		response = append(response, dto.UserResponse{
-			ID:          u.ID,
-			DisplayName: u.DisplayName,
-			AvatarURL:   u.AvatarURL,
-			Bio:         u.Bio,
-			Location:    u.Location,
-			CreatedAt:   u.CreatedAt,
-			UpdatedAt:   u.UpdatedAt,
-		})
+	for i := range users {
+		response = append(response, mapUser(&users[i]))
 	}
 
 	return c.JSON(response)
@@ -57,31 +52,250 @@ func (h *UserHandler) GetAllUsers(c *fiber.Ctx) error {
 //	@Produce		json
 //	@Param			id	path		string	true	"User UUID"
 //	@Success		200	{object}	dto.UserResponse
-//	@Failure		400	{object}	dto.ErrorResponse
-//	@Failure		404	{object}	dto.ErrorResponse
-//	@Failure		500	{object}	dto.ErrorResponse
+//	@Failure		400	{object}	dto.ProblemDetails
+//	@Failure		404	{object}	dto.ProblemDetails
+//	@Failure		500	{object}	dto.ProblemDetails
 //	@Router			/v1/users/{id} [get]
 func (h *UserHandler) GetUserByID(c *fiber.Ctx) error {
-	idParam := c.Params("id")
-	id, err := uuid.Parse(idParam)
+	params, err := validator.ParseAndValidateParams[dto.UserIDParams](c)
 	if err != nil {
-		return c.Status(fiber.StatusBadRequest).JSON(dto.ErrorResponse{Error: "invalid UUID"})
+		return err
 	}
+	id := uuid.MustParse(params.ID) // checked by uuid_param
 
-	user, err := h.service.GetUserProfile(id)
+	user, err := h.service.GetUserProfile(c.UserContext(), id)
 	if err != nil {
-		return c.Status(fiber.StatusNotFound).JSON(dto.ErrorResponse{Error: "user not found"})
+		return err
 	}
 
-	response := dto.UserResponse{
-		ID:          user.ID,
-		DisplayName: user.DisplayName,
-		AvatarURL:   user.AvatarURL,
-		Bio:         user.Bio,
-		Location:    user.Location,
-		CreatedAt:   user.CreatedAt,
-		UpdatedAt:   user.UpdatedAt,
+	return c.JSON(mapUser(user))
+}
+
+// GetUserByUsername godoc
+//
+//	@Summary		Get a user by username
+//	@Description	Retrieve a user profile by its current username
+//	@Tags			Users
+//	@Produce		json
+//	@Param			username	path		string	true	"Username"
+//	@Success		200			{object}	dto.UserResponse
+//	@Failure		400			{object}	dto.ProblemDetails
+//	@Failure		404			{object}	dto.ProblemDetails
+//	@Failure		500			{object}	dto.ProblemDetails
+//	@Router			/v1/users/by-username/{username} [get]
+func (h *UserHandler) GetUserByUsername(c *fiber.Ctx) error {
+	params, err := validator.ParseAndValidateParams[dto.UsernameParams](c)
+	if err != nil {
+		return err
 	}
 
-	return c.JSON(response)
+	user, err := h.service.GetUserByUsername(c.UserContext(), params.Username)
+	if err != nil {
+		return err
+	}
+
+	return c.JSON(mapUser(user))
+}
+
+// GetMe godoc
+//
+//	@Summary		Get the caller's profile
+//	@Description	Retrieve the caller's profile with their email, any pending email change and when the username may next be changed
+//	@Tags			Users
+//	@Produce		json
+//	@Security		BearerAuth
+//	@Success		200	{object}	dto.MeResponse
+//	@Failure		401	{object}	dto.ProblemDetails
+//	@Failure		404	{object}	dto.ProblemDetails
+//	@Failure		500	{object}	dto.ProblemDetails
+//	@Router			/v1/me [get]
+func (h *UserHandler) GetMe(c *fiber.Ctx) error {
+	userID, err := uuid.Parse(c.Locals("userID").(string))
+	if err != nil {
+		return errs.ErrInvalidToken.Wrap(err)
+	}
+
+	user, pending, err := h.service.GetAccount(c.UserContext(), userID)
+	if err != nil {
+		return err
+	}
+
+	return c.JSON(h.mapMe(user, pending))
+}
+
+// UpdateMe godoc
+//
+//	@Summary		Update the caller's profile
+//	@Description	Change some profile fields; omitted fields keep their value and null clears displayName, bio or location. The username can only be changed once per cooldown period, and the old one stays reserved for the caller for a while.
+//	@Tags			Users
+//	@Accept			json
+//	@Produce		json
+//	@Security		BearerAuth
+//	@Param			profile	body		dto.UpdateProfileRequest	true	"Changed fields"
+//	@Success		200		{object}	dto.MeResponse
+//	@Failure		400		{object}	dto.ProblemDetails
+//	@Failure		401		{object}	dto.ProblemDetails
+//	@Failure		403		{object}	dto.ProblemDetails
+//	@Failure		409		{object}	dto.ProblemDetails
+//	@Failure		500		{object}	dto.ProblemDetails
+//	@Router			/v1/me [patch]
+func (h *UserHandler) UpdateMe(c *fiber.Ctx) error {
+	userID, err := uuid.Parse(c.Locals("userID").(string))
+	if err != nil {
+		return errs.ErrInvalidToken.Wrap(err)
+	}
+
+	req, err := validator.ParseAndValidateBody[dto.UpdateProfileRequest](c)
+	if err != nil {
+		return err
+	}
+
+	if _, err := h.service.UpdateUserProfile(c.UserContext(), userID, req); err != nil {
+		return err
+	}
+
+	user, pending, err := h.service.GetAccount(c.UserContext(), userID)
+	if err != nil {
+		return err
+	}
+
+	return c.JSON(h.mapMe(user, pending))
+}
+
+// ChangeEmail godoc
+//
+//	@Summary		Change the caller's email
+//	@Description	Send a verification token to a new email address. The address only changes once the token is confirmed; asking again replaces the pending change.
+//	@Tags			Users
+//	@Accept			json
+//	@Produce		json
+//	@Security		BearerAuth
+//	@Param			email	body		dto.ChangeEmailRequest	true	"New email and current password"
+//	@Success		202		{object}	dto.PendingEmailResponse
+//	@Failure		400		{object}	dto.ProblemDetails
+//	@Failure		401		{object}	dto.ProblemDetails
+//	@Failure		403		{object}	dto.ProblemDetails
+//	@Failure		409		{object}	dto.ProblemDetails
+//	@Failure		500		{object}	dto.ProblemDetails
+//	@Router			/v1/me/email [post]
+func (h *UserHandler) ChangeEmail(c *fiber.Ctx) error {
+	userID, err := uuid.Parse(c.Locals("userID").(string))
+	if err != nil {
+		return errs.ErrInvalidToken.Wrap(err)
+	}
+
+	req, err := validator.ParseAndValidateBody[dto.ChangeEmailRequest](c)
+	if err != nil {
+		return err
+	}
+
+	change, err := h.service.RequestEmailChange(c.UserContext(), userID, req)
+	if err != nil {
+		return err
+	}
+
+	return c.Status(fiber.StatusAccepted).JSON(dto.PendingEmailResponse{
+		Email:     change.Email,
+		ExpiresAt: change.ExpiresAt,
+	})
+}
+
+// VerifyEmail godoc
+//
+//	@Summary		Confirm an email change
+//	@Description	Complete the caller's pending email change with the token sent to the new address
+//	@Tags			Users
+//	@Accept			json
+//	@Produce		json
+//	@Security		BearerAuth
+//	@Param			token	body		dto.VerifyEmailRequest	true	"Verification token"
+//	@Success		200		{object}	dto.MeResponse
+//	@Failure		400		{object}	dto.ProblemDetails
+//	@Failure		401		{object}	dto.ProblemDetails
+//	@Failure		409		{object}	dto.ProblemDetails
+//	@Failure		500		{object}	dto.ProblemDetails
+//	@Router			/v1/me/email/verify [post]
+func (h *UserHandler) VerifyEmail(c *fiber.Ctx) error {
+	userID, err := uuid.Parse(c.Locals("userID").(string))
+	if err != nil {
+		return errs.ErrInvalidToken.Wrap(err)
+	}
+
+	req, err := validator.ParseAndValidateBody[dto.VerifyEmailRequest](c)
+	if err != nil {
+		return err
+	}
+
+	user, err := h.service.VerifyEmailChange(c.UserContext(), userID, req.Token)
+	if err != nil {
+		return err
+	}
+
+	return c.JSON(h.mapMe(user, nil))
+}
+
+// SetAvatar godoc
+//
+//	@Summary		Set the caller's avatar
+//	@Description	Use an uploaded image as the caller's avatar. The media must belong to the caller and be fully uploaded.
+//	@Tags			Users
+//	@Accept			json
+//	@Produce		json
+//	@Security		BearerAuth
+//	@Param			avatar	body		dto.SetAvatarRequest	true	"Uploaded media"
+//	@Success		200		{object}	dto.UserResponse
+//	@Failure		400		{object}	dto.ProblemDetails
+//	@Failure		401		{object}	dto.ProblemDetails
+//	@Failure		403		{object}	dto.ProblemDetails
+//	@Failure		404		{object}	dto.ProblemDetails
+//	@Failure		409		{object}	dto.ProblemDetails
+//	@Failure		500		{object}	dto.ProblemDetails
+//	@Router			/v1/me/avatar [put]
+func (h *UserHandler) SetAvatar(c *fiber.Ctx) error {
+	userID, err := uuid.Parse(c.Locals("userID").(string))
+	if err != nil {
+		return errs.ErrInvalidToken.Wrap(err)
+	}
+
+	req, err := validator.ParseAndValidateBody[dto.SetAvatarRequest](c)
+	if err != nil {
+		return err
+	}
+
+	user, err := h.service.SetAvatar(c.UserContext(), userID, req.MediaID)
+	if err != nil {
+		return err
+	}
+
+	return c.JSON(mapUser(user))
+}
+
+func mapUser(u *models.User) dto.UserResponse {
+	return dto.UserResponse{
+		ID:            u.ID,
+		Username:      u.Username,
+		DisplayName:   u.DisplayName,
+		AvatarURL:     u.AvatarURL,
+		AvatarMediaID: u.AvatarMediaID,
+		Bio:           u.Bio,
+		Location:      u.Location,
+		CreatedAt:     u.CreatedAt,
+		UpdatedAt:     u.UpdatedAt,
+	}
+}
+
+func (h *UserHandler) mapMe(u *models.User, pending *models.EmailChange) dto.MeResponse {
+	resp := dto.MeResponse{
+		UserResponse:  mapUser(u),
+		Email:         u.Email,
+		EmailVerified: u.EmailVerifiedAt != nil,
+	}
+	if pending != nil {
+		resp.PendingEmail = &dto.PendingEmailResponse{
+			Email:     pending.Email,
+			ExpiresAt: pending.ExpiresAt,
+		}
+	}
+	resp.UsernameChangeableAt = h.service.UsernameChangeableAt(u)
+	return resp
 }
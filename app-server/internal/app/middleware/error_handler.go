@@ -0,0 +1,77 @@
+package middleware
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/maulana1k/forum-app/internal/app/dto"
+	"github.com/maulana1k/forum-app/internal/domain/errs"
+)
+
+const (
+	ProblemContentType = "application/problem+json"
+	problemTypePrefix  = "/problems/"
+)
+
+// ErrorHandler renders every error returned from a handler as an RFC 7807
+// problem document. Typed domain errors map to their status and stable
+// code; anything else is reported as an opaque internal error so that
+// driver or upstream messages never leak to clients.
+func ErrorHandler(c *fiber.Ctx, err error) error {
+	problem := problemFor(err)
+	problem.Instance = c.OriginalURL()
+	if requestID, ok := c.Locals("requestID").(string); ok {
+		problem.RequestID = requestID
+	}
+
+	return c.Status(problem.Status).JSON(problem, ProblemContentType)
+}
+
+func problemFor(err error) dto.ProblemDetails {
+	if appErr, ok := errs.As(err); ok {
+		status := statusFor(appErr)
+		problem := newProblem(status, appErr.Code, appErr.Message)
+		for _, f := range appErr.Fields {
+			problem.Errors = append(problem.Errors, dto.FieldError(f))
+		}
+		return problem
+	}
+
+	var fiberErr *fiber.Error
+	if errors.As(err, &fiberErr) {
+		return newProblem(fiberErr.Code, "http_"+strconv.Itoa(fiberErr.Code), fiberErr.Message)
+	}
+
+	return newProblem(fiber.StatusInternalServerError, "internal_error", "internal server error")
+}
+
+func statusFor(err *errs.Error) int {
+	switch {
+	case errors.Is(err, errs.ErrValidation):
+		return fiber.StatusBadRequest
+	case errors.Is(err, errs.ErrUnauthorized):
+		return fiber.StatusUnauthorized
+	case errors.Is(err, errs.ErrForbidden):
+		return fiber.StatusForbidden
+	case errors.Is(err, errs.ErrNotFound):
+		return fiber.StatusNotFound
+	case errors.Is(err, errs.ErrConflict):
+		return fiber.StatusConflict
+	case errors.Is(err, errs.ErrUnavailable):
+		return fiber.StatusServiceUnavailable
+	default:
+		return fiber.StatusInternalServerError
+	}
+}
+
+func newProblem(status int, code, detail string) dto.ProblemDetails {
+	return dto.ProblemDetails{
+		Type:   problemTypePrefix + code,
+		Title:  http.StatusText(status),
+		Status: status,
+		Detail: detail,
+		Code:   code,
+	}
+}
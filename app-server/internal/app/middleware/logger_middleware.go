@@ -0,0 +1,98 @@
+package middleware
+
+import (
+	"strings"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+	"github.com/maulana1k/forum-app/internal/pkg/utils"
+	"github.com/sirupsen/logrus"
+)
+
+const (
+	RequestIDHeader = "X-Request-ID"
+
+	// maxRequestIDLength bounds client supplied IDs so they can't be used to
+	// bloat log lines.
+	maxRequestIDLength = 128
+)
+
+// LogrusMiddleware assigns a request ID, attaches a request scoped logger
+// to the context and writes one access log line when the request ends.
+func LogrusMiddleware(c *fiber.Ctx) error {
+	start := time.Now()
+
+	requestID := requestIDFrom(c.Get(RequestIDHeader))
+	c.Set(RequestIDHeader, requestID)
+	c.Locals("requestID", requestID)
+
+	utils.SetRequestLogger(c, utils.Logger.WithFields(logrus.Fields{
+		"component":  "web-server",
+		"request_id": requestID,
+		"method":     c.Method(),
+		"path":       c.Path(),
+	}))
+
+	err := c.Next()
+
+	// Render the error here rather than leaving it to the app, so the status
+	// below (and in outer middleware) is the one the client actually gets.
+	if err != nil {
+		if handlerErr := c.App().ErrorHandler(c, err); handlerErr != nil {
+			_ = c.SendStatus(fiber.StatusInternalServerError)
+		}
+	}
+
+	// Skip logging for static assets
+	if shouldSkipLog(c.Path()) {
+		return nil
+	}
+
+	// Re-read the logger: auth middleware may have added the user ID.
+	logger := utils.RequestLogger(c).WithFields(logrus.Fields{
+		"latency": time.Since(start).Round(time.Millisecond).String(),
+		"status":  c.Response().StatusCode(),
+	})
+
+	switch {
+	case err != nil && c.Response().StatusCode() >= fiber.StatusInternalServerError:
+		logger.WithError(err).Error("response error")
+	case err != nil:
+		logger.WithError(err).Warn("response error")
+	default:
+		logger.Info("response success")
+	}
+
+	return nil
+}
+
+// requestIDFrom honours a client supplied request ID when it is reasonable
+// and generates a new UUID otherwise.
+func requestIDFrom(header string) string {
+	header = strings.TrimSpace(header)
+	if header == "" || len(header) > maxRequestIDLength {
+		return uuid.NewString()
+	}
+	for _, r := range header {
+		if !isRequestIDRune(r) {
+			return uuid.NewString()
+		}
+	}
+	return header
+}
+
+func isRequestIDRune(r rune) bool {
+	return r >= 'a' && r <= 'z' || r >= 'A' && r <= 'Z' || r >= '0' && r <= '9' ||
+		r == '-' || r == '_' || r == '.' || r == ':'
+}
+
+func shouldSkipLog(path string) bool {
+	skipExtensions := []string{".css", ".js", ".png", ".jpg", ".ico", ".svg"}
+	for _, ext := range skipExtensions {
+		if strings.HasSuffix(path, ext) {
+			return true
+		}
+	}
+	return false
+}
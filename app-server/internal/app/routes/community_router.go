@@ -0,0 +1,25 @@
+package routes
+
+import (
+	"github.com/gofiber/fiber/v2"
+	"github.com/maulana1k/forum-app/internal/app/container"
+	"github.com/maulana1k/forum-app/internal/app/handler"
+)
+
+// RegisterCommunityRoutes registers community routes. Reads are public;
+// viewer identifies signed-in callers so members see private communities.
+func RegisterCommunityRoutes(api fiber.Router, c *container.Container, viewer, middleware fiber.Handler) {
+	communityHandler := handler.NewCommunityHandler(c.CommunityService, c.PostService)
+
+	v1 := api.Group("/v1/communities")
+	v1.Get("/", viewer, communityHandler.ListCommunities)
+	v1.Get("/:slug", viewer, communityHandler.GetCommunity)
+	v1.Get("/:slug/members", viewer, communityHandler.ListMembers)
+	v1.Get("/:slug/posts", viewer, communityHandler.GetCommunityPosts)
+
+	v1.Post("/", middleware, communityHandler.CreateCommunity)
+	v1.Patch("/:slug", middleware, communityHandler.UpdateCommunity)
+	v1.Post("/:slug/join", middleware, communityHandler.JoinCommunity)
+	v1.Delete("/:slug/membership", middleware, communityHandler.LeaveCommunity)
+	v1.Put("/:slug/members/:userId", middleware, communityHandler.UpdateMember)
+}
@@ -0,0 +1,17 @@
+package routes
+
+import (
+	"github.com/gofiber/fiber/v2"
+	"github.com/maulana1k/forum-app/internal/app/container"
+	"github.com/maulana1k/forum-app/internal/app/handler"
+)
+
+func RegisterEngagementRoutes(app fiber.Router, c *container.Container, middleware fiber.Handler) {
+	engagementHandler := handler.NewEngagementHandler(c.EngagementService)
+
+	v1 := app.Group("/v1/engagement")
+
+	v1.Use(middleware)
+
+	v1.Post("/events", engagementHandler.RecordEvents)
+}
@@ -1,9 +1,10 @@
 package routes
 
 import (
-	"log"
 	"runtime"
 
+	"github.com/maulana1k/forum-app/internal/pkg/utils"
+
 	"github.com/gofiber/fiber/v2"
 	"github.com/maulana1k/forum-app/internal/provider/database"
 )
@@ -28,7 +29,7 @@ func HealthCheck(c *fiber.Ctx) error {
 	if err != nil {
 		status = "error"
 		dbStatus = "down"
-		log.Printf("Database ping failed: %v", err)
+		utils.RequestLogger(c).WithError(err).Error("database ping failed")
 	} else {
 		dbLatency = latency.String()
 	}
@@ -0,0 +1,23 @@
+package routes
+
+import (
+	"github.com/gofiber/fiber/v2"
+	"github.com/maulana1k/forum-app/internal/app/container"
+	"github.com/maulana1k/forum-app/internal/app/handler"
+)
+
+// RegisterMediaRoutes registers upload and media lookup routes. Presigned
+// upload bodies and downloads are authorised by their signed token, not the
+// session.
+func RegisterMediaRoutes(api fiber.Router, c *container.Container, middleware fiber.Handler) {
+	mediaHandler := handler.NewMediaHandler(c.MediaService)
+
+	v1 := api.Group("/v1/media")
+	v1.Put("/uploads/:token", mediaHandler.ReceiveUpload)
+	v1.Get("/downloads/:token", mediaHandler.Download)
+	v1.Get("/:id", mediaHandler.GetMedia)
+
+	v1.Post("/", middleware, mediaHandler.Upload)
+	v1.Post("/uploads", middleware, mediaHandler.CreateUpload)
+	v1.Post("/:id/complete", middleware, mediaHandler.CompleteUpload)
+}
@@ -6,14 +6,20 @@ import (
 	"github.com/maulana1k/forum-app/internal/app/handler"
 )
 
-// RegisterPublicPostRoutes registers only public post routes (no auth required)
-func RegisterPublicPostRoutes(api fiber.Router, c *container.Container) {
+// RegisterPublicPostRoutes registers only public post routes (no auth
+// required). viewer identifies signed-in callers when they send a token.
+func RegisterPublicPostRoutes(api fiber.Router, c *container.Container, viewer fiber.Handler) {
 	postHandler := handler.NewPostHandler(c.PostService)
+	relatedHandler := handler.NewRelatedPostHandler(c.RelatedPostService)
 
 	v1 := api.Group("/v1/posts")
-	v1.Get("/", postHandler.GetAllPosts)
-	v1.Get("/:id", postHandler.GetPostByID)
-	v1.Get("/user/:id", postHandler.GetUserPosts)
+	v1.Get("/", viewer, postHandler.GetAllPosts)
+	v1.Get("/:id", viewer, postHandler.GetPostByID)
+	v1.Get("/:id/replies", viewer, postHandler.GetReplies)
+	v1.Get("/:id/revisions", viewer, postHandler.GetRevisions)
+	v1.Get("/:id/related", viewer, relatedHandler.GetRelatedPosts)
+	v1.Get("/:id/reactions/:type/users", viewer, postHandler.GetReactionUsers)
+	v1.Get("/user/:id", viewer, postHandler.GetUserPosts)
 }
 
 // RegisterProtectedPostRoutes registers routes that require authentication
@@ -29,5 +35,13 @@ func RegisterProtectedPostRoutes(api fiber.Router, c *container.Container, middl
 	v1.Put("/:id", postHandler.UpdatePost)
 	v1.Delete("/:id", postHandler.DeletePost)
 	v1.Delete("/:id/unlike", postHandler.UnlikePost)
+	v1.Put("/:id/reactions", postHandler.React)
+	v1.Delete("/:id/reactions", postHandler.Unreact)
+	v1.Post("/:id/poll/votes", postHandler.Vote)
+	v1.Delete("/:id/poll/votes", postHandler.Unvote)
+	v1.Post("/:id/publish", postHandler.PublishPost)
 	// v1.Delete("/:id/unbookmark", postHandler.UnbookmarkPost)
+
+	me := api.Group("/v1/me", middleware)
+	me.Get("/drafts", postHandler.GetDrafts)
 }
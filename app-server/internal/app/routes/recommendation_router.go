@@ -14,4 +14,5 @@ func RegisterRecommendationRoutes(app fiber.Router, c *container.Container, midd
 	v1.Use(middleware)
 
 	v1.Get("/posts", recHandler.GetRecommendedPosts)
+	v1.Post("/clicks", recHandler.RecordClick)
 }
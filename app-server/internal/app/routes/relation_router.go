@@ -0,0 +1,25 @@
+package routes
+
+import (
+	"github.com/gofiber/fiber/v2"
+	"github.com/maulana1k/forum-app/internal/app/container"
+	"github.com/maulana1k/forum-app/internal/app/handler"
+)
+
+// RegisterRelationRoutes registers the caller's block and mute lists and
+// muted words.
+func RegisterRelationRoutes(api fiber.Router, c *container.Container, middleware fiber.Handler) {
+	relationHandler := handler.NewRelationHandler(c.RelationService)
+	mutedWordHandler := handler.NewMutedWordHandler(c.MutedWordService)
+
+	me := api.Group("/v1/me", middleware)
+	me.Get("/blocks", relationHandler.ListBlocked)
+	me.Put("/blocks/:id", relationHandler.Block)
+	me.Delete("/blocks/:id", relationHandler.Unblock)
+	me.Get("/mutes", relationHandler.ListMuted)
+	me.Put("/mutes/:id", relationHandler.Mute)
+	me.Delete("/mutes/:id", relationHandler.Unmute)
+	me.Get("/muted-words", mutedWordHandler.ListMutedWords)
+	me.Post("/muted-words", mutedWordHandler.AddMutedWord)
+	me.Delete("/muted-words/:id", mutedWordHandler.RemoveMutedWord)
+}
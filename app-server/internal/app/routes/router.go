@@ -19,10 +19,21 @@ func Register(app *fiber.App, c *container.Container) {
 
 	RegisterAuthRoutes(api, c)
 
-	RegisterUserRoutes(api, c)
+	// Tokens stop working once their user deactivates the account; signing
+	// in again reactivates it and issues a new one.
+	protected := utils.Protected(c.AccountService.CheckActive)
+	viewer := utils.OptionalAuth(c.AccountService.CheckActive)
 
-	RegisterRecommendationRoutes(api, c, utils.Protected())
+	RegisterUserRoutes(api, c, protected)
+	RegisterRelationRoutes(api, c, protected)
 
-	RegisterPublicPostRoutes(api, c)
-	RegisterProtectedPostRoutes(api, c, utils.Protected())
+	RegisterMediaRoutes(api, c, protected)
+
+	RegisterRecommendationRoutes(api, c, protected)
+	RegisterEngagementRoutes(api, c, protected)
+
+	RegisterCommunityRoutes(api, c, viewer, protected)
+
+	RegisterPublicPostRoutes(api, c, viewer)
+	RegisterProtectedPostRoutes(api, c, protected)
 }
@@ -6,10 +6,26 @@ import (
 	"github.com/maulana1k/forum-app/internal/app/handler"
 )
 
-func RegisterUserRoutes(app fiber.Router, c *container.Container) {
+func RegisterUserRoutes(app fiber.Router, c *container.Container, middleware fiber.Handler) {
 	userHandler := handler.NewUserHandler(c.UserService)
+	settingsHandler := handler.NewSettingsHandler(c.SettingsService)
+	accountHandler := handler.NewAccountHandler(c.AccountService)
 
 	v1 := app.Group("/v1/users")
 	v1.Get("/", userHandler.GetAllUsers)
+	v1.Get("/by-username/:username", userHandler.GetUserByUsername)
 	v1.Get("/:id", userHandler.GetUserByID)
+
+	me := app.Group("/v1/me", middleware)
+	me.Get("", userHandler.GetMe)
+	me.Patch("", userHandler.UpdateMe)
+	me.Delete("", accountHandler.DeleteAccount)
+	me.Post("/deactivate", accountHandler.Deactivate)
+	me.Post("/email", userHandler.ChangeEmail)
+	me.Post("/email/verify", userHandler.VerifyEmail)
+	me.Put("/avatar", userHandler.SetAvatar)
+	me.Get("/settings", settingsHandler.GetSettings)
+	me.Patch("/settings", settingsHandler.UpdateSettings)
+	me.Post("/exports", accountHandler.RequestExport)
+	me.Get("/exports/:id", accountHandler.GetExport)
 }
@@ -0,0 +1,188 @@
+package rpc
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/maulana1k/forum-app/gen/forum"
+	"github.com/maulana1k/forum-app/internal/app/dto"
+	"github.com/maulana1k/forum-app/internal/domain/errs"
+	"github.com/maulana1k/forum-app/internal/domain/models"
+	"github.com/maulana1k/forum-app/internal/domain/service"
+	"github.com/maulana1k/forum-app/internal/pkg/validator"
+	"google.golang.org/grpc"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+const (
+	// maxBatchIDs bounds GetPosts and BatchGetUsers.
+	maxBatchIDs = 100
+	// streamPageSize posts are loaded at a time by StreamPostsSince.
+	streamPageSize = 500
+)
+
+// forumServer serves ForumService from the same services as the HTTP API.
+// Posts are returned as the main feed shows them to a signed-out visitor.
+type forumServer struct {
+	forum.UnimplementedForumServiceServer
+
+	posts      service.PostService
+	users      service.UserService
+	moderation service.ModerationService
+}
+
+func (s *forumServer) GetPosts(ctx context.Context, req *forum.GetPostsRequest) (*forum.GetPostsResponse, error) {
+	ids, err := parseIDs(req.GetIds())
+	if err != nil {
+		return nil, err
+	}
+
+	posts, err := s.posts.GetPostsByIDs(ctx, ids, "")
+	if err != nil {
+		return nil, err
+	}
+
+	resp := &forum.GetPostsResponse{Posts: make([]*forum.Post, len(posts))}
+	for i := range posts {
+		resp.Posts[i] = toPost(&posts[i])
+	}
+	return resp, nil
+}
+
+func (s *forumServer) BatchGetUsers(ctx context.Context, req *forum.BatchGetUsersRequest) (*forum.BatchGetUsersResponse, error) {
+	ids, err := parseIDs(req.GetIds())
+	if err != nil {
+		return nil, err
+	}
+
+	users, err := s.users.GetUserProfiles(ctx, ids)
+	if err != nil {
+		return nil, err
+	}
+
+	resp := &forum.BatchGetUsersResponse{Users: make([]*forum.User, len(users))}
+	for i := range users {
+		resp.Users[i] = toUser(&users[i])
+	}
+	return resp, nil
+}
+
+func (s *forumServer) StreamPostsSince(req *forum.StreamPostsSinceRequest, stream grpc.ServerStreamingServer[forum.Post]) error {
+	ctx := stream.Context()
+
+	since := req.GetSince().AsTime()
+	after := uuid.Nil
+	if req.GetAfterId() != "" {
+		id, err := uuid.Parse(req.GetAfterId())
+		if err != nil {
+			return errs.ErrInvalidID.Wrap(err)
+		}
+		after = id
+	}
+	remaining := int(req.GetLimit())
+	if remaining < 0 {
+		return errs.ErrInvalidQuery
+	}
+
+	for {
+		pageSize := streamPageSize
+		if req.GetLimit() > 0 {
+			pageSize = min(pageSize, remaining)
+		}
+
+		posts, err := s.posts.ListPostsSince(ctx, since, after, pageSize)
+		if err != nil {
+			return err
+		}
+		for i := range posts {
+			if err := stream.Send(toPost(&posts[i])); err != nil {
+				return err
+			}
+		}
+
+		if req.GetLimit() > 0 {
+			remaining -= len(posts)
+			if remaining == 0 {
+				return nil
+			}
+		}
+		if len(posts) < pageSize {
+			return nil
+		}
+		last := posts[len(posts)-1]
+		since = last.CreatedAt
+		after = uuid.MustParse(last.ID)
+	}
+}
+
+func (s *forumServer) ReportModerationResult(ctx context.Context, req *forum.ModerationResult) (*forum.ReportModerationResultResponse, error) {
+	result := &dto.ModerationResultRequest{
+		PostID:  req.GetPostId(),
+		Model:   req.GetModel(),
+		Label:   req.GetLabel(),
+		Score:   req.GetScore(),
+		Flagged: req.GetFlagged(),
+	}
+	if err := validator.Struct(result, "en"); err != nil {
+		return nil, err
+	}
+	if err := s.moderation.ReportResult(ctx, result); err != nil {
+		return nil, err
+	}
+	return &forum.ReportModerationResultResponse{}, nil
+}
+
+func parseIDs(raw []string) ([]uuid.UUID, error) {
+	if len(raw) > maxBatchIDs {
+		return nil, errs.ErrTooManyIDs
+	}
+	ids := make([]uuid.UUID, len(raw))
+	for i, r := range raw {
+		id, err := uuid.Parse(r)
+		if err != nil {
+			return nil, errs.ErrInvalidID.Wrap(err)
+		}
+		ids[i] = id
+	}
+	return ids, nil
+}
+
+func toPost(p *dto.PostResponse) *forum.Post {
+	post := &forum.Post{
+		Id:             p.ID,
+		AuthorId:       p.Author.ID,
+		AuthorUsername: p.Author.Username,
+		Content:        p.Content,
+		Tags:           p.Tags,
+		QuotedPostId:   p.QuotedPost,
+		LikesCount:     int32(p.LikesCount),
+		RepliesCount:   int32(p.RepliesCount),
+		RepostsCount:   int32(p.RepostsCount),
+		CreatedAt:      timestamppb.New(p.CreatedAt),
+		UpdatedAt:      timestamppb.New(p.UpdatedAt),
+		EditedAt:       optionalTimestamp(p.EditedAt),
+	}
+	if p.Community != nil {
+		post.CommunityId = p.Community.ID
+	}
+	return post
+}
+
+func toUser(u *models.User) *forum.User {
+	return &forum.User{
+		Id:          u.ID.String(),
+		Username:    u.Username,
+		DisplayName: u.DisplayName,
+		Bio:         u.Bio,
+		AvatarUrl:   u.AvatarURL,
+		CreatedAt:   timestamppb.New(u.CreatedAt),
+	}
+}
+
+func optionalTimestamp(t *time.Time) *timestamppb.Timestamp {
+	if t == nil {
+		return nil
+	}
+	return timestamppb.New(*t)
+}
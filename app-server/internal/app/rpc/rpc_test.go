@@ -0,0 +1,59 @@
+package rpc
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/maulana1k/forum-app/internal/domain/errs"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+func TestTokenAuth(t *testing.T) {
+	auth := tokenAuth{token: []byte("secret")}
+	withAuth := func(value string) context.Context {
+		return metadata.NewIncomingContext(context.Background(), metadata.Pairs("authorization", value))
+	}
+
+	assert.NoError(t, auth.check(withAuth("Bearer secret")))
+	assert.NoError(t, auth.check(withAuth("bearer secret")))
+
+	for _, ctx := range []context.Context{
+		context.Background(),
+		withAuth("Bearer wrong"),
+		withAuth("secret"),
+		withAuth("Basic secret"),
+	} {
+		assert.Equal(t, codes.Unauthenticated, status.Code(auth.check(ctx)))
+	}
+}
+
+func TestToStatus(t *testing.T) {
+	ctx := context.Background()
+
+	st := status.Convert(toStatus(ctx, errs.ErrPostNotFound))
+	assert.Equal(t, codes.NotFound, st.Code())
+	assert.Equal(t, "post not found", st.Message())
+	require.Len(t, st.Details(), 1)
+	assert.Equal(t, "post_not_found", st.Details()[0].(*errdetails.ErrorInfo).GetReason())
+
+	st = status.Convert(toStatus(ctx, errs.Validation("validation_failed", "request validation failed",
+		errs.FieldError{Field: "model", Code: "required", Message: "model is required"})))
+	assert.Equal(t, codes.InvalidArgument, st.Code())
+	require.Len(t, st.Details(), 2)
+	violations := st.Details()[1].(*errdetails.BadRequest).GetFieldViolations()
+	require.Len(t, violations, 1)
+	assert.Equal(t, "model", violations[0].GetField())
+
+	// Driver errors must not leak.
+	st = status.Convert(toStatus(ctx, errors.New("pq: connection refused")))
+	assert.Equal(t, codes.Internal, st.Code())
+	assert.Equal(t, "internal server error", st.Message())
+
+	assert.Equal(t, codes.Canceled, status.Code(toStatus(ctx, context.Canceled)))
+}
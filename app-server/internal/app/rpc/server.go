@@ -0,0 +1,68 @@
+// Package rpc serves the gRPC API that internal services, such as the
+// recommender and the Airflow jobs, use instead of reading Postgres. It is
+// defined in proto/forum.proto and runs next to the HTTP API, on top of
+// the same services.
+package rpc
+
+import (
+	"context"
+	"net"
+
+	"github.com/maulana1k/forum-app/gen/forum"
+	"github.com/maulana1k/forum-app/internal/app/container"
+	"github.com/maulana1k/forum-app/internal/provider/monitoring"
+	"go.opentelemetry.io/contrib/instrumentation/google.golang.org/grpc/otelgrpc"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/reflection"
+)
+
+// Server is the gRPC API server.
+type Server struct {
+	grpc *grpc.Server
+}
+
+// NewServer builds the server. Every call, reflection included, must
+// carry token as a bearer token.
+func NewServer(c *container.Container, token string) *Server {
+	auth := tokenAuth{token: []byte(token)}
+
+	srv := grpc.NewServer(
+		grpc.StatsHandler(otelgrpc.NewServerHandler()),
+		// Metrics come first so they see the status the caller gets.
+		grpc.ChainUnaryInterceptor(monitoring.UnaryServerInterceptor(), auth.unary, unaryErrors),
+		grpc.ChainStreamInterceptor(monitoring.StreamServerInterceptor(), auth.stream, streamErrors),
+	)
+	forum.RegisterForumServiceServer(srv, &forumServer{
+		posts:      c.PostService,
+		users:      c.UserService,
+		moderation: c.ModerationService,
+	})
+	reflection.Register(srv)
+
+	return &Server{grpc: srv}
+}
+
+// Listen serves on addr until Shutdown.
+func (s *Server) Listen(addr string) error {
+	lis, err := net.Listen("tcp", addr)
+	if err != nil {
+		return err
+	}
+	return s.grpc.Serve(lis)
+}
+
+// Shutdown stops accepting calls and waits for running ones, cutting off
+// those still running, such as long backfill streams, when ctx is done.
+func (s *Server) Shutdown(ctx context.Context) {
+	done := make(chan struct{})
+	go func() {
+		s.grpc.GracefulStop()
+		close(done)
+	}()
+
+	select {
+	case <-done:
+	case <-ctx.Done():
+		s.grpc.Stop()
+	}
+}
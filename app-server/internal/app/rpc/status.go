@@ -0,0 +1,86 @@
+package rpc
+
+import (
+	"context"
+	"errors"
+
+	"github.com/maulana1k/forum-app/internal/domain/errs"
+	"github.com/maulana1k/forum-app/internal/pkg/utils"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/protoadapt"
+)
+
+// errorDomain qualifies the stable error codes sent in ErrorInfo details.
+const errorDomain = "forum-app"
+
+// toStatus is the gRPC counterpart of middleware.ErrorHandler: typed
+// domain errors keep their code, carried as an ErrorInfo reason, and
+// their field errors as BadRequest details; anything else becomes an
+// opaque Internal error.
+func toStatus(ctx context.Context, err error) error {
+	if _, ok := status.FromError(err); ok {
+		return err
+	}
+
+	if errors.Is(err, context.Canceled) || errors.Is(err, context.DeadlineExceeded) {
+		return status.FromContextError(err).Err()
+	}
+
+	appErr, ok := errs.As(err)
+	if !ok {
+		utils.LoggerFromContext(ctx).WithError(err).Error("gRPC call failed")
+		return status.Error(codes.Internal, "internal server error")
+	}
+
+	st := status.New(codeFor(appErr), appErr.Message)
+	details := []protoadapt.MessageV1{&errdetails.ErrorInfo{Reason: appErr.Code, Domain: errorDomain}}
+	if len(appErr.Fields) > 0 {
+		violations := make([]*errdetails.BadRequest_FieldViolation, len(appErr.Fields))
+		for i, f := range appErr.Fields {
+			violations[i] = &errdetails.BadRequest_FieldViolation{Field: f.Field, Description: f.Message}
+		}
+		details = append(details, &errdetails.BadRequest{FieldViolations: violations})
+	}
+	if withDetails, err := st.WithDetails(details...); err == nil {
+		st = withDetails
+	}
+	return st.Err()
+}
+
+func codeFor(err *errs.Error) codes.Code {
+	switch {
+	case errors.Is(err, errs.ErrValidation):
+		return codes.InvalidArgument
+	case errors.Is(err, errs.ErrUnauthorized):
+		return codes.Unauthenticated
+	case errors.Is(err, errs.ErrForbidden):
+		return codes.PermissionDenied
+	case errors.Is(err, errs.ErrNotFound):
+		return codes.NotFound
+	case errors.Is(err, errs.ErrConflict):
+		return codes.AlreadyExists
+	case errors.Is(err, errs.ErrUnavailable):
+		return codes.Unavailable
+	default:
+		return codes.Internal
+	}
+}
+
+// unaryErrors and streamErrors run toStatus on whatever a handler returns.
+func unaryErrors(ctx context.Context, req any, _ *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+	resp, err := handler(ctx, req)
+	if err != nil {
+		return nil, toStatus(ctx, err)
+	}
+	return resp, nil
+}
+
+func streamErrors(srv any, ss grpc.ServerStream, _ *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+	if err := handler(srv, ss); err != nil {
+		return toStatus(ss.Context(), err)
+	}
+	return nil
+}
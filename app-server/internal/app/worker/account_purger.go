@@ -0,0 +1,46 @@
+package worker
+
+import (
+	"context"
+	"time"
+
+	"github.com/maulana1k/forum-app/internal/domain/service"
+	"github.com/maulana1k/forum-app/internal/pkg/utils"
+)
+
+// StartAccountPurger periodically deletes accounts whose deletion grace
+// period is over, along with expired data exports. Every replica may run
+// it; each account is purged by exactly one of them. It stops when ctx is
+// cancelled.
+func StartAccountPurger(ctx context.Context, accounts service.AccountService, interval time.Duration) {
+	if interval <= 0 {
+		utils.Logger.Info("account purger disabled")
+		return
+	}
+
+	go func() {
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+				purgeAccounts(ctx, accounts)
+			}
+		}
+	}()
+}
+
+func purgeAccounts(ctx context.Context, accounts service.AccountService) {
+	logger := utils.Logger.WithField("component", "account-purger")
+
+	purged, err := accounts.PurgeDueAccounts(utils.ContextWithLogger(ctx, logger))
+	if err != nil {
+		logger.WithError(err).Error("purging accounts failed")
+	}
+	if purged > 0 {
+		logger.WithField("purged", purged).Info("purged deleted accounts")
+	}
+}
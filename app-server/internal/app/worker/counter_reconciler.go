@@ -0,0 +1,53 @@
+package worker
+
+import (
+	"context"
+	"time"
+
+	"github.com/maulana1k/forum-app/internal/domain/service"
+	"github.com/maulana1k/forum-app/internal/pkg/utils"
+	"github.com/maulana1k/forum-app/internal/provider/monitoring"
+)
+
+// StartCounterReconciler periodically repairs post engagement counters
+// that drifted from the interaction tables, e.g. after a failed deploy or
+// rows changed by hand. It stops when ctx is cancelled.
+func StartCounterReconciler(ctx context.Context, posts service.PostService, interval time.Duration) {
+	if interval <= 0 {
+		utils.Logger.Info("counter reconciler disabled")
+		return
+	}
+
+	go func() {
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+				reconcileCounters(ctx, posts)
+			}
+		}
+	}()
+}
+
+func reconcileCounters(ctx context.Context, posts service.PostService) {
+	logger := utils.Logger.WithField("component", "counter-reconciler")
+	start := time.Now()
+
+	fixed, err := posts.ReconcileCounters(ctx)
+	monitoring.CountersRepaired.Add(float64(fixed))
+	if err != nil {
+		logger.WithError(err).Error("counter reconciliation failed")
+		return
+	}
+
+	entry := logger.WithField("fixed", fixed).WithField("duration", time.Since(start).Round(time.Millisecond).String())
+	if fixed > 0 {
+		entry.Warn("repaired drifted post counters")
+	} else {
+		entry.Debug("post counters in sync")
+	}
+}
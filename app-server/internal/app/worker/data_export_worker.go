@@ -0,0 +1,28 @@
+package worker
+
+import (
+	"context"
+	"encoding/json"
+
+	"github.com/maulana1k/forum-app/internal/domain/service"
+	"github.com/maulana1k/forum-app/internal/provider/broker"
+	"github.com/rabbitmq/amqp091-go"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// StartDataExportWorker builds the data exports users asked for.
+func StartDataExportWorker(r *broker.RabbitMQ, accounts service.AccountService) error {
+	consumer := broker.NewConsumer(r, service.DataExportQueue)
+
+	return consumer.Consume(func(ctx context.Context, msg amqp091.Delivery) error {
+		var req service.DataExportRequest
+		if err := json.Unmarshal(msg.Body, &req); err != nil {
+			return err
+		}
+
+		trace.SpanFromContext(ctx).SetAttributes(attribute.String("export.id", req.ExportID))
+
+		return accounts.BuildExport(ctx, req.ExportID)
+	})
+}
@@ -0,0 +1,46 @@
+package worker
+
+import (
+	"context"
+	"time"
+
+	"github.com/maulana1k/forum-app/internal/domain/service"
+	"github.com/maulana1k/forum-app/internal/pkg/utils"
+)
+
+// StartEmbeddingIndexer periodically embeds new and edited posts for
+// related-post search. The first run also backfills existing posts.
+// Replicas may embed the same post twice, which is harmless. It stops
+// when ctx is cancelled.
+func StartEmbeddingIndexer(ctx context.Context, related service.RelatedPostService, interval time.Duration) {
+	if interval <= 0 {
+		utils.Logger.Info("embedding indexer disabled")
+		return
+	}
+
+	go func() {
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+				indexPosts(ctx, related)
+			}
+		}
+	}()
+}
+
+func indexPosts(ctx context.Context, related service.RelatedPostService) {
+	logger := utils.Logger.WithField("component", "embedding-indexer")
+
+	indexed, err := related.IndexPosts(ctx)
+	if err != nil {
+		logger.WithError(err).Error("embedding posts failed")
+	}
+	if indexed > 0 {
+		logger.WithField("indexed", indexed).Info("embedded posts")
+	}
+}
@@ -0,0 +1,32 @@
+package worker
+
+import (
+	"context"
+	"encoding/json"
+
+	"github.com/maulana1k/forum-app/internal/domain/service"
+	"github.com/maulana1k/forum-app/internal/provider/broker"
+	"github.com/rabbitmq/amqp091-go"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// StartLinkPreviewWorker unfurls the links queued by newly published
+// posts.
+func StartLinkPreviewWorker(r *broker.RabbitMQ, previews service.LinkPreviewService) error {
+	consumer := broker.NewConsumer(r, service.LinkPreviewQueue)
+
+	return consumer.Consume(func(ctx context.Context, msg amqp091.Delivery) error {
+		var req service.LinkPreviewRequest
+		if err := json.Unmarshal(msg.Body, &req); err != nil {
+			return err
+		}
+
+		trace.SpanFromContext(ctx).SetAttributes(
+			attribute.String("post.id", req.PostID),
+			attribute.String("url.full", req.URL),
+		)
+
+		return previews.Unfurl(ctx, req.PostID, req.URL)
+	})
+}
@@ -0,0 +1,46 @@
+package worker
+
+import (
+	"context"
+	"time"
+
+	"github.com/maulana1k/forum-app/internal/domain/service"
+	"github.com/maulana1k/forum-app/internal/pkg/utils"
+)
+
+// StartPollCloser periodically closes polls whose close time has passed
+// and announces their results on the broker. Replicas can all run it:
+// each poll is claimed by exactly one of them. It stops when ctx is
+// cancelled.
+func StartPollCloser(ctx context.Context, posts service.PostService, interval time.Duration) {
+	if interval <= 0 {
+		utils.Logger.Info("poll closer disabled")
+		return
+	}
+
+	go func() {
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+				closePolls(ctx, posts)
+			}
+		}
+	}()
+}
+
+func closePolls(ctx context.Context, posts service.PostService) {
+	logger := utils.Logger.WithField("component", "poll-closer")
+
+	closed, err := posts.ClosePolls(ctx)
+	if err != nil {
+		logger.WithError(err).Error("closing polls failed")
+	}
+	if closed > 0 {
+		logger.WithField("closed", closed).Info("closed polls")
+	}
+}
@@ -0,0 +1,45 @@
+package worker
+
+import (
+	"context"
+	"time"
+
+	"github.com/maulana1k/forum-app/internal/domain/service"
+	"github.com/maulana1k/forum-app/internal/pkg/utils"
+)
+
+// StartPostScheduler periodically publishes scheduled posts that are due.
+// Every replica may run it; each post is claimed by exactly one of them.
+// It stops when ctx is cancelled.
+func StartPostScheduler(ctx context.Context, posts service.PostService, interval time.Duration) {
+	if interval <= 0 {
+		utils.Logger.Info("post scheduler disabled")
+		return
+	}
+
+	go func() {
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+				publishDuePosts(ctx, posts)
+			}
+		}
+	}()
+}
+
+func publishDuePosts(ctx context.Context, posts service.PostService) {
+	logger := utils.Logger.WithField("component", "post-scheduler")
+
+	published, err := posts.PublishDuePosts(ctx)
+	if err != nil {
+		logger.WithError(err).Error("publishing scheduled posts failed")
+	}
+	if published > 0 {
+		logger.WithField("published", published).Info("published scheduled posts")
+	}
+}
@@ -0,0 +1,53 @@
+package worker
+
+import (
+	"context"
+	"encoding/json"
+
+	"github.com/maulana1k/forum-app/internal/app/dto"
+	"github.com/maulana1k/forum-app/internal/domain/service"
+	"github.com/maulana1k/forum-app/internal/provider/broker"
+	"github.com/rabbitmq/amqp091-go"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// SentimentQueue receives posts flagged by the FastAPI sentiment consumer.
+const SentimentQueue = "post-sentiment"
+
+// sentimentModel names the sentiment consumer's verdicts among the
+// moderation results.
+const sentimentModel = "sentiment"
+
+type sentimentResult struct {
+	PostID  string  `json:"post_id"`
+	Content string  `json:"content"`
+	Score   float64 `json:"score"`
+}
+
+// StartSentimentWorker records the flagged post results published by the
+// sentiment service as moderation results. Services on gRPC report theirs
+// through ReportModerationResult instead.
+func StartSentimentWorker(r *broker.RabbitMQ, moderation service.ModerationService) error {
+	consumer := broker.NewConsumer(r, SentimentQueue)
+
+	return consumer.Consume(func(ctx context.Context, msg amqp091.Delivery) error {
+		var result sentimentResult
+		if err := json.Unmarshal(msg.Body, &result); err != nil {
+			return err
+		}
+
+		trace.SpanFromContext(ctx).SetAttributes(
+			attribute.String("post.id", result.PostID),
+			attribute.Float64("sentiment.score", result.Score),
+		)
+
+		// The queue only carries flagged posts.
+		return moderation.ReportResult(ctx, &dto.ModerationResultRequest{
+			PostID:  result.PostID,
+			Model:   sentimentModel,
+			Score:   result.Score,
+			Flagged: true,
+		})
+	})
+}
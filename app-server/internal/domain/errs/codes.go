@@ -0,0 +1,124 @@
+package errs
+
+// Shared input errors.
+var (
+	ErrInvalidBody  = Validation("invalid_body", "cannot parse request body")
+	ErrInvalidID    = Validation("invalid_id", "invalid ID")
+	ErrInvalidQuery = Validation("invalid_query", "invalid query parameters")
+	ErrTooManyIDs   = Validation("too_many_ids", "too many IDs requested at once")
+)
+
+// Auth errors.
+var (
+	ErrMissingCredentials = Validation("missing_credentials", "username, email, and password cannot be empty")
+	ErrInvalidCredentials = Unauthorized("invalid_credentials", "invalid credentials")
+	ErrMissingToken       = Unauthorized("missing_token", "missing or malformed JWT")
+	ErrInvalidToken       = Unauthorized("invalid_token", "invalid or expired JWT")
+	ErrAccountDeactivated = Unauthorized("account_deactivated", "account is deactivated; sign in to reactivate it")
+	ErrEmailTaken         = Conflict("email_taken", "email already in use")
+	ErrUsernameTaken      = Conflict("username_taken", "username already in use")
+)
+
+// User errors.
+var (
+	ErrUserNotFound      = NotFound("user_not_found", "user not found")
+	ErrUserProfileExists = Conflict("user_profile_exists", "user profile already exists")
+	ErrUsernameCooldown  = Forbidden("username_change_too_soon", "username was changed too recently")
+	ErrIncorrectPassword = Forbidden("incorrect_password", "password is incorrect")
+	ErrEmailUnchanged    = Validation("email_unchanged", "new email is the current email")
+	ErrInvalidEmailToken = Validation("invalid_email_token", "email verification token is invalid or expired")
+)
+
+// Post errors.
+var (
+	ErrPostNotFound       = NotFound("post_not_found", "post not found")
+	ErrQuotedPostNotFound = Validation("quoted_post_not_found", "quoted post not found")
+	ErrPostUpdateDenied   = Forbidden("post_update_forbidden", "unauthorized to update this post")
+	ErrPostDeleteDenied   = Forbidden("post_delete_forbidden", "unauthorized to delete this post")
+	ErrPostEditExpired    = Forbidden("post_edit_window_closed", "post can no longer be edited")
+	ErrPostPublishDenied  = Forbidden("post_publish_forbidden", "unauthorized to publish this post")
+	ErrPostPublished      = Conflict("post_already_published", "post is already published")
+	ErrInvalidSchedule    = Validation("invalid_publish_at", "publish_at must be in the future and within a year")
+	ErrDraftScheduled     = Validation("draft_scheduled", "a draft cannot have publish_at")
+	ErrPostAlreadyLiked   = Conflict("post_already_liked", "post already liked")
+	ErrPostNotLiked       = Conflict("post_not_liked", "post not liked")
+	ErrPostAlreadySaved   = Conflict("post_already_bookmarked", "post already bookmarked")
+	ErrPostNotSaved       = Conflict("post_not_bookmarked", "post not bookmarked")
+)
+
+// Reaction errors.
+var (
+	ErrUnknownReaction  = Validation("unknown_reaction", "reaction is not allowed")
+	ErrReactionNotFound = Conflict("reaction_not_found", "no reaction to remove")
+)
+
+// Poll errors.
+var (
+	ErrInvalidPollClose  = Validation("invalid_poll_close", "poll must close between 5 minutes and 30 days from now")
+	ErrInvalidPollOption = Validation("invalid_poll_option", "option does not belong to this poll")
+	ErrPollSingleChoice  = Validation("poll_single_choice", "this poll accepts a single option")
+	ErrPollNotFound      = NotFound("poll_not_found", "post has no poll")
+	ErrPollClosed        = Conflict("poll_closed", "poll is closed")
+	ErrPollAlreadyVoted  = Conflict("poll_already_voted", "already voted in this poll")
+	ErrPollNotVoted      = Conflict("poll_not_voted", "no vote to withdraw")
+)
+
+// Media errors.
+var (
+	ErrMediaFileMissing   = Validation("media_file_missing", "multipart field \"file\" is required")
+	ErrMediaTooLarge      = Validation("media_too_large", "file exceeds the upload size limit")
+	ErrUnsupportedMedia   = Validation("unsupported_media_type", "only JPEG, PNG, GIF and WebP images are accepted")
+	ErrMediaNotFound      = NotFound("media_not_found", "media not found")
+	ErrMediaForbidden     = Forbidden("media_forbidden", "media belongs to another user")
+	ErrMediaNotReady      = Conflict("media_not_ready", "upload has not been completed")
+	ErrMediaReady         = Conflict("media_already_completed", "upload was already completed")
+	ErrInvalidUploadToken = Unauthorized("invalid_upload_token", "upload URL is invalid or expired")
+	ErrInvalidDownload    = Unauthorized("invalid_download_token", "download URL is invalid or expired")
+)
+
+// Community errors.
+var (
+	ErrCommunityNotFound     = NotFound("community_not_found", "community not found")
+	ErrCommunitySlugTaken    = Conflict("community_slug_taken", "community slug already in use")
+	ErrCommunityUpdateDenied = Forbidden("community_update_forbidden", "only the owner and moderators can manage this community")
+	ErrCommunityPrivate      = Forbidden("community_private", "only members can view this community")
+	ErrCommunityMemberOnly   = Forbidden("community_members_only", "only members can post in this community")
+	ErrCommunityBanned       = Forbidden("community_banned", "you are banned from this community")
+	ErrCommunityOwnerRole    = Validation("community_owner_role", "the owner's membership cannot be changed")
+	ErrAlreadyMember         = Conflict("community_already_member", "already a member or awaiting approval")
+	ErrNotMember             = Conflict("community_not_member", "not a member of this community")
+	ErrMemberNotFound        = NotFound("community_member_not_found", "member not found")
+)
+
+// Relation errors.
+var (
+	ErrSelfRelation = Validation("self_relation", "you cannot block or mute yourself")
+	ErrNotBlocked   = Conflict("user_not_blocked", "user is not blocked")
+	ErrNotMuted     = Conflict("user_not_muted", "user is not muted")
+)
+
+// Muted word errors.
+var (
+	ErrEmptyMutedWord    = Validation("empty_muted_word", "muted word must contain a letter or digit")
+	ErrInvalidMuteExpiry = Validation("invalid_mute_expiry", "expires_at must be in the future")
+	ErrTooManyMutedWords = Validation("too_many_muted_words", "muted word limit reached")
+	ErrMutedWordNotFound = NotFound("muted_word_not_found", "muted word not found")
+)
+
+// Recommendation errors.
+var (
+	ErrRecommenderUnavailable     = Unavailable("recommender_unavailable", "recommendation service unavailable")
+	ErrRecommendationAsUserDenied = Forbidden("recommendation_as_user_forbidden", "only admins can view another user's recommendations")
+	ErrRecommendationNotFound     = NotFound("recommendation_not_found", "post was not recommended to you")
+)
+
+// Settings errors.
+var (
+	ErrSettingsVersionConflict = Conflict("settings_version_conflict", "settings were changed elsewhere; reload them and try again")
+)
+
+// Account errors.
+var (
+	ErrExportInProgress = Conflict("data_export_in_progress", "a data export is already being prepared")
+	ErrExportNotFound   = NotFound("data_export_not_found", "data export not found")
+)
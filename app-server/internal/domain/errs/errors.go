@@ -0,0 +1,103 @@
+package errs
+
+import (
+	"errors"
+	"fmt"
+)
+
+// Kind sentinels classify domain errors. Match them with errors.Is:
+//
+//	errors.Is(err, errs.ErrNotFound)
+var (
+	ErrValidation   = errors.New("validation failed")
+	ErrUnauthorized = errors.New("unauthorized")
+	ErrForbidden    = errors.New("forbidden")
+	ErrNotFound     = errors.New("not found")
+	ErrConflict     = errors.New("conflict")
+	ErrUnavailable  = errors.New("unavailable")
+)
+
+// FieldError describes why a single input field was rejected.
+type FieldError struct {
+	Field   string `json:"field"`
+	Code    string `json:"code"`
+	Message string `json:"message"`
+}
+
+// Error is a typed domain error. Code is a stable, machine readable
+// identifier (e.g. "post_not_found") that clients may switch on; Message is
+// meant for humans and may change.
+type Error struct {
+	Kind    error
+	Code    string
+	Message string
+	Fields  []FieldError
+	Err     error
+}
+
+func (e *Error) Error() string {
+	if e.Err != nil {
+		return fmt.Sprintf("%s: %v", e.Message, e.Err)
+	}
+	return e.Message
+}
+
+// Is reports whether target is this error's kind sentinel, or a typed error
+// with the same code, so both errors.Is(err, errs.ErrNotFound) and
+// errors.Is(err, errs.ErrPostNotFound) hold for a wrapped copy.
+func (e *Error) Is(target error) bool {
+	if target == e.Kind {
+		return true
+	}
+	t, ok := target.(*Error)
+	return ok && t.Code == e.Code
+}
+
+func (e *Error) Unwrap() error {
+	return e.Err
+}
+
+// Wrap returns a copy of e that records cause as the underlying error.
+func (e *Error) Wrap(cause error) *Error {
+	cp := *e
+	cp.Err = cause
+	return &cp
+}
+
+func newError(kind error, code, message string) *Error {
+	return &Error{Kind: kind, Code: code, Message: message}
+}
+
+func NotFound(code, message string) *Error {
+	return newError(ErrNotFound, code, message)
+}
+
+func Forbidden(code, message string) *Error {
+	return newError(ErrForbidden, code, message)
+}
+
+func Conflict(code, message string) *Error {
+	return newError(ErrConflict, code, message)
+}
+
+func Unauthorized(code, message string) *Error {
+	return newError(ErrUnauthorized, code, message)
+}
+
+func Unavailable(code, message string) *Error {
+	return newError(ErrUnavailable, code, message)
+}
+
+// Validation builds an input error, optionally carrying per-field details.
+func Validation(code, message string, fields ...FieldError) *Error {
+	e := newError(ErrValidation, code, message)
+	e.Fields = fields
+	return e
+}
+
+// As extracts the typed *Error from err's chain.
+func As(err error) (*Error, bool) {
+	var e *Error
+	ok := errors.As(err, &e)
+	return e, ok
+}
@@ -0,0 +1,134 @@
+package events
+
+import (
+	"context"
+	"sync"
+
+	"github.com/maulana1k/forum-app/internal/pkg/utils"
+)
+
+// Event is something that happened in the domain. Services publish events
+// after a change is committed; subscribers react to them (cache
+// invalidation, counters, ...).
+type Event interface {
+	Name() string
+}
+
+const (
+	PostCreatedEvent        = "post.created"
+	PostUpdatedEvent        = "post.updated"
+	PostDeletedEvent        = "post.deleted"
+	PostReactedEvent        = "post.reacted"
+	PostUnreactedEvent      = "post.unreacted"
+	PollVotedEvent          = "poll.voted"
+	PollClosedEvent         = "poll.closed"
+	UserProfileUpdatedEvent = "user.profile_updated"
+
+	RecommendationClickedEvent = "recommendation.clicked"
+)
+
+type PostCreated struct {
+	PostID       string
+	AuthorID     string
+	QuotedPostID string
+}
+
+type PostUpdated struct{ PostID string }
+
+type PostDeleted struct {
+	PostID   string
+	AuthorID string
+}
+
+// PostReacted is raised when a user adds or switches a reaction. Previous
+// is the replaced reaction, if any.
+type PostReacted struct {
+	PostID   string
+	UserID   string
+	Reaction string
+	Previous string
+}
+
+type PostUnreacted struct {
+	PostID   string
+	UserID   string
+	Reaction string
+}
+
+// PollVoted is raised when a user votes in or withdraws from a poll.
+type PollVoted struct {
+	PostID    string
+	PollID    string
+	UserID    string
+	Withdrawn bool
+}
+
+type PollClosed struct {
+	PostID string
+	PollID string
+}
+
+type UserProfileUpdated struct{ UserID string }
+
+// RecommendationClicked is raised when a user opens a post they were
+// recommended.
+type RecommendationClicked struct {
+	UserID           string
+	PostID           string
+	RecommendationID string
+}
+
+func (PostCreated) Name() string           { return PostCreatedEvent }
+func (PostUpdated) Name() string           { return PostUpdatedEvent }
+func (PostDeleted) Name() string           { return PostDeletedEvent }
+func (PostReacted) Name() string           { return PostReactedEvent }
+func (PostUnreacted) Name() string         { return PostUnreactedEvent }
+func (PollVoted) Name() string             { return PollVotedEvent }
+func (PollClosed) Name() string            { return PollClosedEvent }
+func (UserProfileUpdated) Name() string    { return UserProfileUpdatedEvent }
+func (RecommendationClicked) Name() string { return RecommendationClickedEvent }
+
+// Handler reacts to an event. Errors are logged, never returned to the
+// publisher: the change that raised the event has already happened.
+type Handler func(ctx context.Context, event Event) error
+
+// Bus is a synchronous in-process event bus.
+type Bus struct {
+	mu       sync.RWMutex
+	handlers map[string][]Handler
+}
+
+func NewBus() *Bus {
+	return &Bus{handlers: make(map[string][]Handler)}
+}
+
+// Subscribe registers h for each of the named events.
+func (b *Bus) Subscribe(h Handler, names ...string) {
+	if b == nil {
+		return
+	}
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	for _, name := range names {
+		b.handlers[name] = append(b.handlers[name], h)
+	}
+}
+
+// Publish runs every handler subscribed to the event, in order. A nil bus
+// drops events so services can be built without one.
+func (b *Bus) Publish(ctx context.Context, event Event) {
+	if b == nil {
+		return
+	}
+
+	b.mu.RLock()
+	handlers := b.handlers[event.Name()]
+	b.mu.RUnlock()
+
+	for _, h := range handlers {
+		if err := h(ctx, event); err != nil {
+			utils.LoggerFromContext(ctx).WithError(err).WithField("event", event.Name()).Error("event handler failed")
+		}
+	}
+}
@@ -0,0 +1,56 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// GhostUserID is the placeholder author that posts of deleted accounts are
+// moved to under DeletionAnonymize. The row is created on first use and
+// cannot sign in.
+var GhostUserID = uuid.MustParse("ffffffff-ffff-ffff-ffff-ffffffffffff")
+
+// GhostUsername is the username shown for GhostUserID. It does not match
+// the username pattern, so no one can register it.
+const GhostUsername = "[deleted]"
+
+// DeletionPolicy is what happens to the content of a deleted account.
+type DeletionPolicy string
+
+const (
+	// DeletionAnonymize keeps posts and replies under the ghost user so
+	// threads stay readable.
+	DeletionAnonymize DeletionPolicy = "anonymize"
+	// DeletionCascade deletes everything the user wrote.
+	DeletionCascade DeletionPolicy = "cascade"
+)
+
+type ExportStatus string
+
+const (
+	ExportPending ExportStatus = "pending"
+	ExportReady   ExportStatus = "ready"
+	ExportFailed  ExportStatus = "failed"
+)
+
+// DataExport is a ZIP archive of a user's data, built in the background.
+// The archive is stored under Key until ExpiresAt.
+type DataExport struct {
+	ID          uuid.UUID    `gorm:"type:uuid;default:gen_random_uuid();primaryKey"`
+	UserID      uuid.UUID    `gorm:"type:uuid;not null;index"`
+	Status      ExportStatus `gorm:"type:varchar(16);not null;default:'pending'"`
+	Key         string       `gorm:"type:text"`
+	Size        int64
+	Error       string `gorm:"type:text"`
+	CompletedAt *time.Time
+	ExpiresAt   *time.Time `gorm:"index"`
+	CreatedAt   time.Time
+
+	User User `gorm:"foreignKey:UserID;constraint:OnDelete:CASCADE"`
+}
+
+// IsReady reports whether the archive can be downloaded at now.
+func (e *DataExport) IsReady(now time.Time) bool {
+	return e.Status == ExportReady && e.ExpiresAt != nil && now.Before(*e.ExpiresAt)
+}
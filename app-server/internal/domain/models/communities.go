@@ -0,0 +1,90 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+type CommunityVisibility string
+
+const (
+	// CommunityPublic is readable by everyone and open to join.
+	CommunityPublic CommunityVisibility = "public"
+	// CommunityRestricted is readable by everyone, but joining needs a
+	// moderator's approval and only members may post.
+	CommunityRestricted CommunityVisibility = "restricted"
+	// CommunityPrivate is only readable by members and joining needs a
+	// moderator's approval.
+	CommunityPrivate CommunityVisibility = "private"
+)
+
+type CommunityRole string
+
+const (
+	CommunityRoleOwner     CommunityRole = "owner"
+	CommunityRoleModerator CommunityRole = "moderator"
+	CommunityRoleMember    CommunityRole = "member"
+)
+
+type MembershipStatus string
+
+const (
+	MembershipActive  MembershipStatus = "active"
+	MembershipPending MembershipStatus = "pending"
+	MembershipBanned  MembershipStatus = "banned"
+)
+
+// Community is a sub-forum. Posts with a CommunityID belong to it and are
+// moderated by its owner and moderators.
+type Community struct {
+	ID           uuid.UUID           `gorm:"type:uuid;default:gen_random_uuid();primaryKey;index:idx_communities_created_at_id,priority:2"`
+	Slug         string              `gorm:"type:varchar(32);not null;uniqueIndex"`
+	Name         string              `gorm:"type:varchar(100);not null"`
+	Description  string              `gorm:"type:text"`
+	Rules        string              `gorm:"type:text"`
+	Visibility   CommunityVisibility `gorm:"type:varchar(16);not null;default:'public'"`
+	OwnerID      uuid.UUID           `gorm:"type:uuid;not null;index"`
+	MembersCount int                 `gorm:"not null;default:0"`
+	CreatedAt    time.Time           `gorm:"index:idx_communities_created_at_id,priority:1"`
+	UpdatedAt    time.Time
+	DeletedAt    gorm.DeletedAt `gorm:"index"`
+
+	Owner User `gorm:"foreignKey:OwnerID;constraint:OnDelete:CASCADE"`
+}
+
+// IsListed reports whether non-members can see the community's posts.
+func (c *Community) IsListed() bool {
+	return c.Visibility != CommunityPrivate
+}
+
+// CommunityMember is a user's standing in a community. Bans are kept as a
+// member row so a banned user cannot simply join again.
+type CommunityMember struct {
+	ID          uint             `gorm:"primaryKey"`
+	CommunityID uuid.UUID        `gorm:"type:uuid;not null;uniqueIndex:idx_community_members_unique;index:idx_community_members_list,priority:1"`
+	UserID      uuid.UUID        `gorm:"type:uuid;not null;uniqueIndex:idx_community_members_unique;index"`
+	Role        CommunityRole    `gorm:"type:varchar(16);not null;default:'member'"`
+	Status      MembershipStatus `gorm:"type:varchar(16);not null;default:'active';index:idx_community_members_list,priority:2"`
+	CreatedAt   time.Time
+	UpdatedAt   time.Time
+
+	Community Community `gorm:"foreignKey:CommunityID;constraint:OnDelete:CASCADE"`
+	User      User      `gorm:"foreignKey:UserID;constraint:OnDelete:CASCADE"`
+}
+
+// IsActive reports whether the member has been admitted and not banned.
+func (m *CommunityMember) IsActive() bool {
+	return m != nil && m.Status == MembershipActive
+}
+
+// IsBanned reports whether the member was banned.
+func (m *CommunityMember) IsBanned() bool {
+	return m != nil && m.Status == MembershipBanned
+}
+
+// CanModerate reports whether the member moderates the community.
+func (m *CommunityMember) CanModerate() bool {
+	return m.IsActive() && (m.Role == CommunityRoleOwner || m.Role == CommunityRoleModerator)
+}
@@ -0,0 +1,53 @@
+package models
+
+import (
+	"database/sql/driver"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Vector is a pgvector value. Its text form, "[1,2,3]", is also JSON.
+type Vector []float32
+
+func (v Vector) Value() (driver.Value, error) {
+	raw, err := json.Marshal([]float32(v))
+	if err != nil {
+		return nil, err
+	}
+	// A string, not bytes, so it is sent as text rather than bytea.
+	return string(raw), nil
+}
+
+func (v *Vector) Scan(src any) error {
+	var raw []byte
+	switch s := src.(type) {
+	case []byte:
+		raw = s
+	case string:
+		raw = []byte(s)
+	case nil:
+		*v = nil
+		return nil
+	default:
+		return fmt.Errorf("models: cannot scan %T into Vector", src)
+	}
+	return json.Unmarshal(raw, (*[]float32)(v))
+}
+
+// PostEmbedding is the vector of a published post's text, used to find
+// related posts.
+type PostEmbedding struct {
+	PostID uuid.UUID `gorm:"type:uuid;primaryKey"`
+	// Embedding has embedding.Dimensions components.
+	Embedding Vector `gorm:"type:vector(384);not null"`
+	Model     string `gorm:"type:varchar(128);not null"`
+	// ContentAt is the EditedAt, or else CreatedAt, of the post version
+	// that was embedded; a later edit makes the embedding stale.
+	ContentAt time.Time `gorm:"not null"`
+	UpdatedAt time.Time
+
+	Post Post `gorm:"foreignKey:PostID;constraint:OnDelete:CASCADE"`
+}
@@ -0,0 +1,20 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// LinkPreview is the card metadata fetched for a URL. One row is kept per
+// URL and shared by every post linking to it; FetchedAt decides when it is
+// stale.
+type LinkPreview struct {
+	ID          uuid.UUID `gorm:"type:uuid;default:gen_random_uuid();primaryKey"`
+	URL         string    `gorm:"type:text;not null;uniqueIndex"`
+	Title       string    `gorm:"type:text"`
+	Description string    `gorm:"type:text"`
+	ImageURL    string    `gorm:"type:text"`
+	SiteName    string    `gorm:"type:text"`
+	FetchedAt   time.Time `gorm:"not null"`
+}
@@ -0,0 +1,40 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type MediaStatus string
+
+const (
+	// MediaPending is a presigned upload the client has not completed yet.
+	MediaPending MediaStatus = "pending"
+	MediaReady   MediaStatus = "ready"
+)
+
+// Media is an uploaded image. Posts and avatars reference it by ID; the
+// object itself lives in the blob store under Key.
+type Media struct {
+	ID           uuid.UUID   `gorm:"type:uuid;default:gen_random_uuid();primaryKey"`
+	OwnerID      uuid.UUID   `gorm:"type:uuid;not null;index"`
+	Status       MediaStatus `gorm:"type:varchar(16);not null;default:'pending'"`
+	ContentType  string      `gorm:"type:varchar(64)"`
+	Size         int64
+	Width        int
+	Height       int
+	Key          string `gorm:"type:text;not null;uniqueIndex"`
+	ThumbnailKey string `gorm:"type:text"`
+	URL          string `gorm:"type:text"`
+	ThumbnailURL string `gorm:"type:text"`
+	CreatedAt    time.Time
+	UpdatedAt    time.Time
+
+	Owner User `gorm:"foreignKey:OwnerID;constraint:OnDelete:CASCADE"`
+}
+
+// IsReady reports whether the media was processed and can be attached.
+func (m *Media) IsReady() bool {
+	return m.Status == MediaReady
+}
@@ -0,0 +1,24 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// ModerationResult is a classifier's latest verdict on a post, as
+// reported by the ML services. Each model keeps one row per post.
+type ModerationResult struct {
+	ID     uint      `gorm:"primaryKey"`
+	PostID uuid.UUID `gorm:"type:uuid;not null;uniqueIndex:idx_moderation_results_post_model"`
+	// Model names the classifier, e.g. "sentiment".
+	Model   string  `gorm:"type:varchar(64);not null;uniqueIndex:idx_moderation_results_post_model"`
+	Label   string  `gorm:"type:varchar(64)"`
+	Score   float64 `gorm:"not null"`
+	Flagged bool    `gorm:"not null;default:false;index"`
+
+	CreatedAt time.Time
+	UpdatedAt time.Time
+
+	Post Post `gorm:"foreignKey:PostID;constraint:OnDelete:CASCADE"`
+}
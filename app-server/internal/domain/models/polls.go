@@ -0,0 +1,47 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Poll is an optional vote attached to a post. ClosedAt is stamped by the
+// poll closer once ClosesAt has passed and the close event went out.
+type Poll struct {
+	ID             uuid.UUID `gorm:"type:uuid;default:gen_random_uuid();primaryKey"`
+	PostID         uuid.UUID `gorm:"type:uuid;not null;uniqueIndex"`
+	MultipleChoice bool      `gorm:"not null;default:false"`
+	ClosesAt       time.Time `gorm:"not null;index:idx_polls_pending_close,where:closed_at IS NULL"`
+	ClosedAt       *time.Time
+	VotersCount    int `gorm:"not null;default:0"`
+	CreatedAt      time.Time
+
+	Options []PollOption `gorm:"foreignKey:PollID;constraint:OnDelete:CASCADE"`
+}
+
+// IsClosed reports whether voting on the poll has ended at now.
+func (p *Poll) IsClosed(now time.Time) bool {
+	return !now.Before(p.ClosesAt)
+}
+
+type PollOption struct {
+	ID         uint      `gorm:"primaryKey"`
+	PollID     uuid.UUID `gorm:"type:uuid;not null;index"`
+	Position   int       `gorm:"not null"`
+	Text       string    `gorm:"type:varchar(100);not null"`
+	VotesCount int       `gorm:"not null;default:0"`
+}
+
+// PollVote is one option chosen by a user. Single choice polls hold at
+// most one row per user, multiple choice polls one per chosen option.
+type PollVote struct {
+	ID        uint      `gorm:"primaryKey"`
+	PollID    uuid.UUID `gorm:"type:uuid;not null;uniqueIndex:idx_poll_votes_unique;index:idx_poll_votes_user,priority:1"`
+	UserID    uuid.UUID `gorm:"type:uuid;not null;uniqueIndex:idx_poll_votes_unique;index:idx_poll_votes_user,priority:2"`
+	OptionID  uint      `gorm:"not null;uniqueIndex:idx_poll_votes_unique"`
+	CreatedAt time.Time
+
+	Poll   Poll       `gorm:"foreignKey:PollID;constraint:OnDelete:CASCADE"`
+	Option PollOption `gorm:"foreignKey:OptionID;constraint:OnDelete:CASCADE"`
+}
@@ -8,33 +8,81 @@ import (
 	"gorm.io/gorm"
 )
 
+// PostStatus is where a post is in its lifecycle. Only published posts are
+// visible to other users.
+type PostStatus string
+
+const (
+	PostPublished PostStatus = "published"
+	PostDraft     PostStatus = "draft"
+	PostScheduled PostStatus = "scheduled"
+)
+
+// PostVisibility is who may see a published post.
+type PostVisibility string
+
+const (
+	PostPublic PostVisibility = "public"
+	// PostOnlyMe keeps a post to its author, like a draft that stays
+	// published.
+	PostOnlyMe PostVisibility = "only_me"
+)
+
 type Post struct {
-	ID           uuid.UUID `gorm:"type:uuid;default:gen_random_uuid();primaryKey"`
-	Content      string    `gorm:"type:text;not null"`
-	AuthorID     uuid.UUID `gorm:"not null;index"`
-	QuotedPostID *uuid.UUID
-	Tags         string `gorm:"type:text"`
-	ImageURL     string `gorm:"type:text"`
-	CreatedAt    time.Time
-	UpdatedAt    time.Time
-	DeletedAt    gorm.DeletedAt `gorm:"index"`
-	LikesCount   int
-	RepliesCount int
-	RepostsCount int
-	Author       User  `gorm:"foreignKey:AuthorID;constraint:OnDelete:CASCADE"`
-	QuotedPost   *Post `gorm:"foreignKey:QuotedPostID;constraint:OnDelete:SET NULL"`
-
-	Replies []Replies `gorm:"foreignKey:PostID;constraint:OnDelete:CASCADE"`
+	ID            uuid.UUID `gorm:"type:uuid;default:gen_random_uuid();primaryKey;index:idx_posts_created_at_id,priority:2;index:idx_posts_author_created_at_id,priority:3"`
+	Content       string    `gorm:"type:text;not null"`
+	ContentHTML   string    `gorm:"type:text"`
+	AuthorID      uuid.UUID `gorm:"not null;index;index:idx_posts_author_created_at_id,priority:1"`
+	QuotedPostID  *uuid.UUID
+	CommunityID   *uuid.UUID `gorm:"type:uuid;index"`
+	Tags          string     `gorm:"type:text"`
+	ImageURL      string     `gorm:"type:text"`
+	MediaID       *uuid.UUID `gorm:"type:uuid"`
+	LinkPreviewID *uuid.UUID `gorm:"type:uuid"`
+	CreatedAt     time.Time  `gorm:"index:idx_posts_created_at_id,priority:1;index:idx_posts_author_created_at_id,priority:2"`
+	UpdatedAt     time.Time
+	DeletedAt     gorm.DeletedAt `gorm:"index"`
+	LikesCount    int            `gorm:"not null;default:0"`
+	RepliesCount  int            `gorm:"not null;default:0"`
+	RepostsCount  int            `gorm:"not null;default:0"`
+	EditedAt      *time.Time
+	RevisionCount int            `gorm:"not null;default:0"`
+	Status        PostStatus     `gorm:"type:varchar(16);not null;default:'published';index"`
+	Visibility    PostVisibility `gorm:"type:varchar(16);not null;default:'public'"`
+	PublishAt     *time.Time     `gorm:"index:idx_posts_publish_due,where:status = 'scheduled'"`
+	Author        User           `gorm:"foreignKey:AuthorID;constraint:OnDelete:CASCADE"`
+	QuotedPost    *Post          `gorm:"foreignKey:QuotedPostID;constraint:OnDelete:SET NULL"`
+	Community     *Community     `gorm:"foreignKey:CommunityID;constraint:OnDelete:CASCADE"`
+	Media         *Media         `gorm:"foreignKey:MediaID;constraint:OnDelete:SET NULL"`
+	LinkPreview   *LinkPreview   `gorm:"foreignKey:LinkPreviewID;constraint:OnDelete:SET NULL"`
+
+	Replies        []Replies           `gorm:"foreignKey:PostID;constraint:OnDelete:CASCADE"`
+	ReactionCounts []PostReactionCount `gorm:"foreignKey:PostID;constraint:OnDelete:CASCADE"`
+	Poll           *Poll               `gorm:"foreignKey:PostID;constraint:OnDelete:CASCADE"`
+}
+
+// IsPublished reports whether the post is visible to everyone.
+func (p *Post) IsPublished() bool {
+	return p.Status == "" || p.Status == PostPublished
+}
+
+// IsVisibleTo reports whether userID may see the post: their own posts
+// always, other users' only once published and not kept to the author.
+func (p *Post) IsVisibleTo(userID uuid.UUID) bool {
+	if p.AuthorID == userID {
+		return true
+	}
+	return p.IsPublished() && p.Visibility != PostOnlyMe
 }
 
 type Replies struct {
-	ID        uint `gorm:"primaryKey"`
-	PostID    uint `gorm:"not null;index"`
+	ID        uint      `gorm:"primaryKey;index:idx_replies_post_created_at_id,priority:3"`
+	PostID    uuid.UUID `gorm:"type:uuid;not null;index;index:idx_replies_post_created_at_id,priority:1"`
 	ParentID  *uint
 	Author    string        `gorm:"not null"`
 	Content   string        `gorm:"type:text;not null"`
 	Likes     pq.Int64Array `gorm:"type:integer[]"`
-	CreatedAt time.Time
+	CreatedAt time.Time     `gorm:"index:idx_replies_post_created_at_id,priority:2"`
 	UpdatedAt time.Time
 	DeletedAt gorm.DeletedAt `gorm:"index"`
 
@@ -44,6 +92,8 @@ type Replies struct {
 
 type PostInteractionType string
 
+// LIKE and DISLIKE rows predate reactions (see PostReaction); they are
+// migrated to reactions at startup and no longer written.
 const (
 	LIKE     PostInteractionType = "LIKE"
 	DISLIKE  PostInteractionType = "DISLIKE"
@@ -52,9 +102,9 @@ const (
 
 type PostInteractions struct {
 	ID              uint      `gorm:"primaryKey"`
-	PostID          uuid.UUID `gorm:"not null;index"`
-	UserID          uuid.UUID `gorm:"not null;index"`
-	InteractionType string    `gorm:"not null;type:text"`
+	PostID          uuid.UUID `gorm:"not null;index;uniqueIndex:idx_post_interactions_unique,where:deleted_at IS NULL"`
+	UserID          uuid.UUID `gorm:"not null;index;uniqueIndex:idx_post_interactions_unique,where:deleted_at IS NULL"`
+	InteractionType string    `gorm:"not null;type:text;uniqueIndex:idx_post_interactions_unique,where:deleted_at IS NULL"`
 	CreatedAt       time.Time
 	UpdatedAt       time.Time
 	DeletedAt       gorm.DeletedAt `gorm:"index"`
@@ -0,0 +1,36 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// ReactionLike is the reaction behind the legacy like/unlike endpoints and
+// Post.LikesCount.
+const ReactionLike = "like"
+
+// DefaultReactions is the palette used when none is configured.
+var DefaultReactions = []string{ReactionLike, "love", "laugh", "wow", "sad", "angry"}
+
+// PostReaction is a user's single reaction to a post. Switching reaction
+// updates the row in place.
+type PostReaction struct {
+	ID        uint      `gorm:"primaryKey;index:idx_post_reactions_list,priority:4"`
+	PostID    uuid.UUID `gorm:"type:uuid;not null;uniqueIndex:idx_post_reactions_post_user;index:idx_post_reactions_list,priority:1"`
+	UserID    uuid.UUID `gorm:"type:uuid;not null;uniqueIndex:idx_post_reactions_post_user;index"`
+	Reaction  string    `gorm:"type:varchar(32);not null;index:idx_post_reactions_list,priority:2"`
+	CreatedAt time.Time `gorm:"index:idx_post_reactions_list,priority:3"`
+	UpdatedAt time.Time
+
+	Post Post `gorm:"foreignKey:PostID;constraint:OnDelete:CASCADE"`
+	User User `gorm:"foreignKey:UserID;constraint:OnDelete:CASCADE"`
+}
+
+// PostReactionCount is the denormalised number of reactions of one kind
+// on a post, kept in step with PostReaction.
+type PostReactionCount struct {
+	PostID   uuid.UUID `gorm:"type:uuid;primaryKey"`
+	Reaction string    `gorm:"type:varchar(32);primaryKey"`
+	Count    int       `gorm:"not null;default:0"`
+}
@@ -0,0 +1,27 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// RecommendationImpression records that a post was shown to a user as a
+// recommendation and whether they opened it, so the recommender can learn
+// from what was served. ServeID groups the posts of one served page.
+type RecommendationImpression struct {
+	ID      uint      `gorm:"primaryKey"`
+	ServeID uuid.UUID `gorm:"type:uuid;not null;uniqueIndex:idx_recommendation_impressions_unique"`
+	PostID  uuid.UUID `gorm:"type:uuid;not null;uniqueIndex:idx_recommendation_impressions_unique;index"`
+	UserID  uuid.UUID `gorm:"type:uuid;not null;index:idx_recommendation_impressions_user,priority:1"`
+	// Position is the post's zero-based rank within the page.
+	Position int     `gorm:"not null"`
+	Score    float64 `gorm:"not null"`
+	// Source is "recommender" or "fallback".
+	Source    string `gorm:"type:varchar(16);not null"`
+	ClickedAt *time.Time
+	CreatedAt time.Time `gorm:"index:idx_recommendation_impressions_user,priority:2"`
+
+	User User `gorm:"foreignKey:UserID;constraint:OnDelete:CASCADE"`
+	Post Post `gorm:"foreignKey:PostID;constraint:OnDelete:CASCADE"`
+}
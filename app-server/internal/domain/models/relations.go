@@ -0,0 +1,47 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type RelationKind string
+
+const (
+	// RelationBlock cuts off both users from each other's posts and
+	// interactions.
+	RelationBlock RelationKind = "block"
+	// RelationMute hides the target from the user's feeds; the target is
+	// not told and can still interact.
+	RelationMute RelationKind = "mute"
+)
+
+// UserRelation records that UserID blocked or muted TargetID.
+type UserRelation struct {
+	ID        uint         `gorm:"primaryKey"`
+	UserID    uuid.UUID    `gorm:"type:uuid;not null;uniqueIndex:idx_user_relations_unique;index:idx_user_relations_list,priority:1"`
+	TargetID  uuid.UUID    `gorm:"type:uuid;not null;uniqueIndex:idx_user_relations_unique;index"`
+	Kind      RelationKind `gorm:"type:varchar(16);not null;uniqueIndex:idx_user_relations_unique;index:idx_user_relations_list,priority:2"`
+	CreatedAt time.Time
+
+	User   User `gorm:"foreignKey:UserID;constraint:OnDelete:CASCADE"`
+	Target User `gorm:"foreignKey:TargetID;constraint:OnDelete:CASCADE"`
+}
+
+// MutedWord hides posts and replies containing a word, phrase or hashtag
+// from the user's feeds. Phrase is stored normalised by textfilter.
+type MutedWord struct {
+	ID        uint       `gorm:"primaryKey"`
+	UserID    uuid.UUID  `gorm:"type:uuid;not null;uniqueIndex:idx_muted_words_unique"`
+	Phrase    string     `gorm:"type:varchar(100);not null;uniqueIndex:idx_muted_words_unique"`
+	ExpiresAt *time.Time `gorm:"index"`
+	CreatedAt time.Time
+
+	User User `gorm:"foreignKey:UserID;constraint:OnDelete:CASCADE"`
+}
+
+// IsActive reports whether the mute still applies at now.
+func (w *MutedWord) IsActive(now time.Time) bool {
+	return w.ExpiresAt == nil || w.ExpiresAt.After(now)
+}
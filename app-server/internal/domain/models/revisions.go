@@ -0,0 +1,24 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// PostRevision is an immutable snapshot of a post version. Revision 1 is
+// the original text, written when the post is first edited; each edit
+// then adds the version it produced. Rows are never updated.
+type PostRevision struct {
+	ID          uint      `gorm:"primaryKey"`
+	PostID      uuid.UUID `gorm:"type:uuid;not null;uniqueIndex:idx_post_revisions_post_revision"`
+	Revision    int       `gorm:"not null;uniqueIndex:idx_post_revisions_post_revision"`
+	EditorID    uuid.UUID `gorm:"type:uuid;not null"`
+	Content     string    `gorm:"type:text;not null"`
+	ContentHTML string    `gorm:"type:text"`
+	Tags        string    `gorm:"type:text"`
+	ImageURL    string    `gorm:"type:text"`
+	CreatedAt   time.Time
+
+	Post Post `gorm:"foreignKey:PostID;constraint:OnDelete:CASCADE"`
+}
@@ -1,35 +1,160 @@
 package models
 
 import (
+	"database/sql/driver"
+	"encoding/json"
+	"fmt"
 	"time"
 
 	"github.com/google/uuid"
 	"gorm.io/gorm"
 )
 
+// User roles. Moderators and admins may act on other users' content.
+const (
+	RoleUser      = "user"
+	RoleModerator = "moderator"
+	RoleAdmin     = "admin"
+)
+
 type User struct {
-	ID          uuid.UUID `gorm:"type:uuid;default:gen_random_uuid();primaryKey"`
-	Username    string    `gorm:"uniqueIndex;not null"`
-	Email       string    `gorm:"uniqueIndex;not null"`
-	Password    string    `gorm:"not null"`
-	Role        string    `gorm:"default:'user'"`
-	AvatarURL   string    `gorm:"type:text"`
-	DisplayName string    `gorm:"type:text"`
-	Bio         string    `gorm:"type:text"`
-	Location    string    `gorm:"type:varchar(100)"`
-	CreatedAt   time.Time
-	UpdatedAt   time.Time
-	DeletedAt   gorm.DeletedAt `gorm:"index"`
+	ID            uuid.UUID  `gorm:"type:uuid;default:gen_random_uuid();primaryKey"`
+	Username      string     `gorm:"uniqueIndex;not null"`
+	Email         string     `gorm:"uniqueIndex;not null"`
+	Password      string     `gorm:"not null"`
+	Role          string     `gorm:"default:'user'"`
+	AvatarURL     string     `gorm:"type:text"`
+	AvatarMediaID *uuid.UUID `gorm:"type:uuid"`
+	DisplayName   string     `gorm:"type:text"`
+	Bio           string     `gorm:"type:text"`
+	Location      string     `gorm:"type:varchar(100)"`
+	// EmailVerifiedAt is set when the user confirms an email change.
+	EmailVerifiedAt   *time.Time
+	UsernameChangedAt *time.Time
+	// DeactivatedAt hides the user and their posts until they sign in
+	// again. DeleteAfter is set when they asked for their account to be
+	// deleted; it is purged once that time has passed.
+	DeactivatedAt *time.Time
+	DeleteAfter   *time.Time `gorm:"index"`
+	CreatedAt     time.Time
+	UpdatedAt     time.Time
+	DeletedAt     gorm.DeletedAt `gorm:"index"`
 }
 
-type UserSettings struct {
+// IsActive reports whether the user has not deactivated their account.
+func (u *User) IsActive() bool {
+	return u.DeactivatedAt == nil
+}
+
+// IsModerator reports whether the user holds moderation rights.
+func (u *User) IsModerator() bool {
+	return u.Role == RoleModerator || u.Role == RoleAdmin
+}
+
+// IsAdmin reports whether the user administers the whole site.
+func (u *User) IsAdmin() bool {
+	return u.Role == RoleAdmin
+}
+
+// UsernameReservation keeps a username a user gave up from being claimed
+// by anyone else until ExpiresAt, so links and mentions of the old handle
+// cannot be hijacked. The previous owner may take it back meanwhile.
+type UsernameReservation struct {
+	Username  string    `gorm:"primaryKey"`
+	UserID    uuid.UUID `gorm:"type:uuid;not null;index"`
+	ExpiresAt time.Time `gorm:"not null"`
+	CreatedAt time.Time
+
+	User User `gorm:"foreignKey:UserID;constraint:OnDelete:CASCADE"`
+}
+
+// EmailChange is a pending change of a user's email address. It takes
+// effect once the user proves they own the new address by sending back
+// the token mailed to it; only a hash of the token is stored.
+type EmailChange struct {
 	ID        uint      `gorm:"primaryKey"`
-	UserID    uuid.UUID `gorm:"not null;uniqueIndex"`
-	Theme     string    `gorm:"type:varchar(50);default:'light'"`
-	Language  string    `gorm:"type:varchar(50);default:'en'"`
+	UserID    uuid.UUID `gorm:"type:uuid;not null;uniqueIndex"`
+	Email     string    `gorm:"not null"`
+	TokenHash string    `gorm:"type:char(64);not null;uniqueIndex"`
+	ExpiresAt time.Time `gorm:"not null"`
 	CreatedAt time.Time
-	UpdatedAt time.Time
-	DeletedAt gorm.DeletedAt `gorm:"index"`
 
 	User User `gorm:"foreignKey:UserID;constraint:OnDelete:CASCADE"`
 }
+
+// UserSettings holds a user's preferences. Users without a row use
+// DefaultUserSettings. Version is bumped on every change so concurrent
+// edits from two devices cannot silently overwrite each other.
+type UserSettings struct {
+	ID                    uint              `gorm:"primaryKey"`
+	UserID                uuid.UUID         `gorm:"not null;uniqueIndex"`
+	Theme                 string            `gorm:"type:varchar(50);default:'light'"`
+	Language              string            `gorm:"type:varchar(50);default:'en'"`
+	Notifications         NotificationPrefs `gorm:"type:jsonb;not null;default:'{}'"`
+	DefaultPostVisibility PostVisibility    `gorm:"type:varchar(16);not null;default:'public'"`
+	HideLikes             bool              `gorm:"not null;default:false"`
+	Version               int               `gorm:"not null;default:1"`
+	CreatedAt             time.Time
+	UpdatedAt             time.Time
+	DeletedAt             gorm.DeletedAt `gorm:"index"`
+
+	User User `gorm:"foreignKey:UserID;constraint:OnDelete:CASCADE"`
+}
+
+// DefaultUserSettings returns the settings of a user who never changed
+// any.
+func DefaultUserSettings(userID uuid.UUID) *UserSettings {
+	all := NotificationChannels{InApp: true, Push: true}
+	return &UserSettings{
+		UserID:   userID,
+		Theme:    "light",
+		Language: "en",
+		Notifications: NotificationPrefs{
+			Replies:     all,
+			Mentions:    all,
+			Reactions:   NotificationChannels{InApp: true},
+			Quotes:      all,
+			Follows:     NotificationChannels{InApp: true},
+			PollResults: NotificationChannels{InApp: true},
+		},
+		DefaultPostVisibility: PostPublic,
+	}
+}
+
+// NotificationChannels says where one kind of notification is delivered.
+type NotificationChannels struct {
+	InApp bool `json:"in_app"`
+	Email bool `json:"email"`
+	Push  bool `json:"push"`
+}
+
+// NotificationPrefs holds the delivery channels for each notification
+// event type. It is stored as a jsonb column.
+type NotificationPrefs struct {
+	Replies     NotificationChannels `json:"replies"`
+	Mentions    NotificationChannels `json:"mentions"`
+	Reactions   NotificationChannels `json:"reactions"`
+	Quotes      NotificationChannels `json:"quotes"`
+	Follows     NotificationChannels `json:"follows"`
+	PollResults NotificationChannels `json:"poll_results"`
+}
+
+func (p NotificationPrefs) Value() (driver.Value, error) {
+	return json.Marshal(p)
+}
+
+func (p *NotificationPrefs) Scan(src any) error {
+	var raw []byte
+	switch v := src.(type) {
+	case []byte:
+		raw = v
+	case string:
+		raw = []byte(v)
+	case nil:
+		*p = NotificationPrefs{}
+		return nil
+	default:
+		return fmt.Errorf("models: cannot scan %T into NotificationPrefs", src)
+	}
+	return json.Unmarshal(raw, p)
+}
@@ -0,0 +1,311 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/maulana1k/forum-app/internal/domain/models"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// AccountData is everything stored about one user, for data exports.
+type AccountData struct {
+	User        models.User
+	Settings    *models.UserSettings
+	Posts       []models.Post
+	Replies     []models.Replies
+	Reactions   []models.PostReaction
+	PollVotes   []models.PollVote
+	Bookmarks   []models.PostInteractions
+	Memberships []models.CommunityMember
+	Relations   []models.UserRelation
+	MutedWords  []models.MutedWord
+	Media       []models.Media
+}
+
+type AccountRepository interface {
+	// Deactivate hides the user until they sign in again. A non-nil
+	// deleteAfter also schedules the account for purging.
+	Deactivate(ctx context.Context, userID uuid.UUID, deleteAfter *time.Time) error
+	// ListDueDeletions returns up to limit users whose deletion is due at
+	// now.
+	ListDueDeletions(ctx context.Context, now time.Time, limit int) ([]uuid.UUID, error)
+	// PurgeUser permanently deletes a user whose deletion is due at now,
+	// handling their content per policy. It returns false if the user is
+	// no longer due, e.g. because they signed in again, or is being
+	// purged elsewhere. blobKeys are the stored objects the caller must
+	// delete once the purge committed.
+	PurgeUser(ctx context.Context, userID uuid.UUID, now time.Time, policy models.DeletionPolicy) (purged bool, blobKeys []string, err error)
+	LoadAccountData(ctx context.Context, userID uuid.UUID) (*AccountData, error)
+
+	CreateExport(ctx context.Context, export *models.DataExport) error
+	GetExport(ctx context.Context, id uuid.UUID) (*models.DataExport, error)
+	// GetPendingExport returns gorm.ErrRecordNotFound if the user has no
+	// export being built.
+	GetPendingExport(ctx context.Context, userID uuid.UUID) (*models.DataExport, error)
+	UpdateExport(ctx context.Context, export *models.DataExport) error
+	// ListExpiredExports returns up to limit exports whose archive expired
+	// at now.
+	ListExpiredExports(ctx context.Context, now time.Time, limit int) ([]models.DataExport, error)
+	DeleteExport(ctx context.Context, id uuid.UUID) error
+}
+
+type accountRepository struct {
+	db *gorm.DB
+}
+
+func NewAccountRepository(db *gorm.DB) AccountRepository {
+	return &accountRepository{db: db}
+}
+
+func (r *accountRepository) Deactivate(ctx context.Context, userID uuid.UUID, deleteAfter *time.Time) error {
+	return r.db.WithContext(ctx).Model(&models.User{}).
+		Where("id = ?", userID).
+		Updates(map[string]any{"deactivated_at": time.Now(), "delete_after": deleteAfter}).Error
+}
+
+func (r *accountRepository) ListDueDeletions(ctx context.Context, now time.Time, limit int) ([]uuid.UUID, error) {
+	var ids []uuid.UUID
+	err := r.db.WithContext(ctx).Model(&models.User{}).
+		Where("delete_after <= ?", now).
+		Order("delete_after").
+		Limit(limit).
+		Pluck("id", &ids).Error
+	return ids, err
+}
+
+func (r *accountRepository) PurgeUser(ctx context.Context, userID uuid.UUID, now time.Time, policy models.DeletionPolicy) (bool, []string, error) {
+	var keys []string
+	purged := false
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		var user models.User
+		res := tx.Clauses(clause.Locking{Strength: "UPDATE", Options: "SKIP LOCKED"}).
+			Where("id = ? AND delete_after <= ?", userID, now).
+			Limit(1).
+			Find(&user)
+		if res.Error != nil || res.RowsAffected == 0 {
+			return res.Error
+		}
+
+		var err error
+		if keys, err = purgedBlobKeys(tx, &user, policy); err != nil {
+			return err
+		}
+		if err := handOverContent(tx, &user, policy); err != nil {
+			return err
+		}
+
+		// Memberships go with the user; keep the counts right.
+		if err := tx.Exec(`
+			UPDATE communities SET members_count = GREATEST(members_count - 1, 0)
+			WHERE id IN (SELECT community_id FROM community_members WHERE user_id = ? AND status = ?)`,
+			userID, models.MembershipActive).Error; err != nil {
+			return err
+		}
+
+		// Everything else references the user with ON DELETE CASCADE.
+		if err := tx.Unscoped().Delete(&models.User{}, "id = ?", userID).Error; err != nil {
+			return err
+		}
+
+		purged = true
+		return nil
+	})
+	if err != nil {
+		return false, nil, err
+	}
+	return purged, keys, nil
+}
+
+// purgedBlobKeys lists the stored objects that go away with user: their
+// exports and avatar, and under DeletionCascade all their uploads.
+func purgedBlobKeys(tx *gorm.DB, user *models.User, policy models.DeletionPolicy) ([]string, error) {
+	var keys []string
+	if err := tx.Model(&models.DataExport{}).
+		Where("user_id = ? AND key <> ''", user.ID).
+		Pluck("key", &keys).Error; err != nil {
+		return nil, err
+	}
+
+	query := tx.Where("owner_id = ?", user.ID)
+	if policy != models.DeletionCascade {
+		if user.AvatarMediaID == nil {
+			return keys, nil
+		}
+		query = query.Where("id = ?", *user.AvatarMediaID)
+	}
+	var media []models.Media
+	if err := query.Find(&media).Error; err != nil {
+		return nil, err
+	}
+	for _, m := range media {
+		keys = append(keys, m.Key)
+		if m.ThumbnailKey != "" && m.ThumbnailKey != m.Key {
+			keys = append(keys, m.ThumbnailKey)
+		}
+	}
+	return keys, nil
+}
+
+// handOverContent moves what other users still rely on to the ghost user
+// before user is deleted. Communities always survive their owner; posts,
+// replies and uploads only under DeletionAnonymize.
+func handOverContent(tx *gorm.DB, user *models.User, policy models.DeletionPolicy) error {
+	if err := ensureGhostUser(tx); err != nil {
+		return err
+	}
+	if err := tx.Model(&models.Community{}).Unscoped().
+		Where("owner_id = ?", user.ID).
+		Update("owner_id", models.GhostUserID).Error; err != nil {
+		return err
+	}
+
+	replyAuthors := []string{user.ID.String(), user.Username}
+	if policy == models.DeletionCascade {
+		return tx.Unscoped().Where("author IN ?", replyAuthors).Delete(&models.Replies{}).Error
+	}
+
+	// Unpublished posts were never part of a thread.
+	if err := tx.Unscoped().
+		Where("author_id = ? AND status <> ?", user.ID, models.PostPublished).
+		Delete(&models.Post{}).Error; err != nil {
+		return err
+	}
+	if err := tx.Model(&models.Post{}).Unscoped().
+		Where("author_id = ?", user.ID).
+		Update("author_id", models.GhostUserID).Error; err != nil {
+		return err
+	}
+	if err := tx.Model(&models.PostRevision{}).
+		Where("editor_id = ?", user.ID).
+		Update("editor_id", models.GhostUserID).Error; err != nil {
+		return err
+	}
+	if err := tx.Model(&models.Replies{}).Unscoped().
+		Where("author IN ?", replyAuthors).
+		Update("author", models.GhostUsername).Error; err != nil {
+		return err
+	}
+
+	// Images in kept posts stay; the avatar goes with the user.
+	media := tx.Model(&models.Media{}).Where("owner_id = ?", user.ID)
+	if user.AvatarMediaID != nil {
+		media = media.Where("id <> ?", *user.AvatarMediaID)
+	}
+	return media.Update("owner_id", models.GhostUserID).Error
+}
+
+// ensureGhostUser creates the placeholder author if it does not exist yet.
+// Its empty email and password hash never match, so it cannot sign in.
+func ensureGhostUser(tx *gorm.DB) error {
+	return tx.Clauses(clause.OnConflict{Columns: []clause.Column{{Name: "id"}}, DoNothing: true}).Create(&models.User{
+		ID:          models.GhostUserID,
+		Username:    models.GhostUsername,
+		DisplayName: "Deleted user",
+		Role:        models.RoleUser,
+	}).Error
+}
+
+func (r *accountRepository) LoadAccountData(ctx context.Context, userID uuid.UUID) (*AccountData, error) {
+	db := r.db.WithContext(ctx)
+	data := &AccountData{}
+
+	if err := db.First(&data.User, "id = ?", userID).Error; err != nil {
+		return nil, err
+	}
+
+	var settings models.UserSettings
+	res := db.Where("user_id = ?", userID).Limit(1).Find(&settings)
+	if res.Error != nil {
+		return nil, res.Error
+	}
+	if res.RowsAffected > 0 {
+		data.Settings = &settings
+	}
+
+	steps := []func() error{
+		func() error {
+			return db.Preload("Poll.Options").
+				Where("author_id = ?", userID).
+				Order("created_at, id").
+				Find(&data.Posts).Error
+		},
+		func() error {
+			return db.Where("author IN ?", []string{userID.String(), data.User.Username}).
+				Order("created_at, id").
+				Find(&data.Replies).Error
+		},
+		func() error {
+			return db.Where("user_id = ?", userID).Order("created_at, id").Find(&data.Reactions).Error
+		},
+		func() error {
+			return db.Preload("Option").Where("user_id = ?", userID).Order("created_at, id").Find(&data.PollVotes).Error
+		},
+		func() error {
+			return db.Where("user_id = ? AND interaction_type = ?", userID, models.BOOKMARK).
+				Order("created_at, id").
+				Find(&data.Bookmarks).Error
+		},
+		func() error {
+			return db.Preload("Community").Where("user_id = ?", userID).Order("created_at, id").Find(&data.Memberships).Error
+		},
+		func() error {
+			return db.Preload("Target").Where("user_id = ?", userID).Order("created_at, id").Find(&data.Relations).Error
+		},
+		func() error {
+			return db.Where("user_id = ?", userID).Order("created_at, id").Find(&data.MutedWords).Error
+		},
+		func() error {
+			return db.Where("owner_id = ? AND status = ?", userID, models.MediaReady).Order("created_at").Find(&data.Media).Error
+		},
+	}
+	for _, step := range steps {
+		if err := step(); err != nil {
+			return nil, err
+		}
+	}
+	return data, nil
+}
+
+func (r *accountRepository) CreateExport(ctx context.Context, export *models.DataExport) error {
+	return r.db.WithContext(ctx).Create(export).Error
+}
+
+func (r *accountRepository) GetExport(ctx context.Context, id uuid.UUID) (*models.DataExport, error) {
+	var export models.DataExport
+	if err := r.db.WithContext(ctx).First(&export, "id = ?", id).Error; err != nil {
+		return nil, err
+	}
+	return &export, nil
+}
+
+func (r *accountRepository) GetPendingExport(ctx context.Context, userID uuid.UUID) (*models.DataExport, error) {
+	var export models.DataExport
+	err := r.db.WithContext(ctx).
+		Where("user_id = ? AND status = ?", userID, models.ExportPending).
+		First(&export).Error
+	if err != nil {
+		return nil, err
+	}
+	return &export, nil
+}
+
+func (r *accountRepository) UpdateExport(ctx context.Context, export *models.DataExport) error {
+	return r.db.WithContext(ctx).Save(export).Error
+}
+
+func (r *accountRepository) ListExpiredExports(ctx context.Context, now time.Time, limit int) ([]models.DataExport, error) {
+	var exports []models.DataExport
+	err := r.db.WithContext(ctx).
+		Where("expires_at <= ?", now).
+		Order("expires_at").
+		Limit(limit).
+		Find(&exports).Error
+	return exports, err
+}
+
+func (r *accountRepository) DeleteExport(ctx context.Context, id uuid.UUID) error {
+	return r.db.WithContext(ctx).Delete(&models.DataExport{}, "id = ?", id).Error
+}
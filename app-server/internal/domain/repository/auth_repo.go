@@ -1,15 +1,23 @@
 package repository
 
 import (
+	"context"
+	"time"
+
 	"github.com/maulana1k/forum-app/internal/domain/models"
 	"gorm.io/gorm"
 )
 
 type AuthRepository interface {
-	CreateUser(user *models.User) error
-	GetUserByEmail(email string) (*models.User, error)
-	IsEmailExists(email string) (bool, error)
-	IsUsernameExists(username string) (bool, error)
+	CreateUser(ctx context.Context, user *models.User) error
+	GetUserByEmail(ctx context.Context, email string) (*models.User, error)
+	IsEmailExists(ctx context.Context, email string) (bool, error)
+	// IsUsernameExists also reports usernames still reserved for users
+	// who changed away from them.
+	IsUsernameExists(ctx context.Context, username string) (bool, error)
+	// ReactivateUser undoes a deactivation, cancelling any pending
+	// deletion.
+	ReactivateUser(ctx context.Context, user *models.User) error
 }
 
 type authRepository struct {
@@ -22,33 +30,54 @@ func NewAuthRepository(db *gorm.DB) AuthRepository {
 	}
 }
 
-func (r *authRepository) CreateUser(user *models.User) error {
-	return r.db.Create(user).Error
+func (r *authRepository) CreateUser(ctx context.Context, user *models.User) error {
+	return r.db.WithContext(ctx).Create(user).Error
 }
 
-func (r *authRepository) GetUserByEmail(email string) (*models.User, error) {
+func (r *authRepository) GetUserByEmail(ctx context.Context, email string) (*models.User, error) {
 	var user models.User
-	err := r.db.Where("email = ?", email).First(&user).Error
+	err := r.db.WithContext(ctx).Where("email = ?", email).First(&user).Error
 	if err != nil {
 		return nil, err
 	}
 	return &user, nil
 }
 
-func (r *authRepository) IsEmailExists(email string) (bool, error) {
+func (r *authRepository) IsEmailExists(ctx context.Context, email string) (bool, error) {
 	var count int64
-	err := r.db.Model(&models.User{}).Where("email = ?", email).Count(&count).Error
+	err := r.db.WithContext(ctx).Model(&models.User{}).Where("email = ?", email).Count(&count).Error
 	if err != nil {
 		return false, err
 	}
 	return count > 0, nil
 }
 
-func (r *authRepository) IsUsernameExists(username string) (bool, error) {
+func (r *authRepository) IsUsernameExists(ctx context.Context, username string) (bool, error) {
 	var count int64
-	err := r.db.Model(&models.User{}).Where("username = ?", username).Count(&count).Error
+	err := r.db.WithContext(ctx).Model(&models.User{}).Where("username = ?", username).Count(&count).Error
+	if err != nil {
+		return false, err
+	}
+	if count > 0 {
+		return true, nil
+	}
+
+	err = r.db.WithContext(ctx).Model(&models.UsernameReservation{}).
+		Where("username = ? AND expires_at > ?", username, time.Now()).
+		Count(&count).Error
 	if err != nil {
 		return false, err
 	}
 	return count > 0, nil
 }
+
+func (r *authRepository) ReactivateUser(ctx context.Context, user *models.User) error {
+	err := r.db.WithContext(ctx).Model(user).
+		Updates(map[string]any{"deactivated_at": nil, "delete_after": nil}).Error
+	if err != nil {
+		return err
+	}
+	user.DeactivatedAt = nil
+	user.DeleteAfter = nil
+	return nil
+}
@@ -0,0 +1,215 @@
+package repository
+
+import (
+	"context"
+	"errors"
+
+	"github.com/google/uuid"
+	"github.com/maulana1k/forum-app/internal/domain/errs"
+	"github.com/maulana1k/forum-app/internal/domain/models"
+	"github.com/maulana1k/forum-app/internal/pkg/pagination"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type CommunityRepository interface {
+	// CreateCommunity inserts community and makes its owner the first
+	// member.
+	CreateCommunity(ctx context.Context, community *models.Community) error
+	GetCommunityByID(ctx context.Context, id uuid.UUID) (*models.Community, error)
+	GetCommunityBySlug(ctx context.Context, slug string) (*models.Community, error)
+	// ListCommunities returns the communities that are not private, plus
+	// the private ones viewerID is an active member of.
+	ListCommunities(ctx context.Context, viewerID uuid.UUID, page pagination.Params) ([]models.Community, error)
+	CountCommunities(ctx context.Context, viewerID uuid.UUID) (int64, error)
+	UpdateCommunity(ctx context.Context, id uuid.UUID, fields map[string]any) error
+	GetMember(ctx context.Context, communityID, userID uuid.UUID) (*models.CommunityMember, error)
+	GetMemberWithUser(ctx context.Context, communityID, userID uuid.UUID) (*models.CommunityMember, error)
+	// AddMember inserts a membership with the given status. It fails with
+	// errs.ErrAlreadyMember if the user already has a membership row.
+	AddMember(ctx context.Context, member *models.CommunityMember) error
+	// RemoveMember deletes a pending or active membership. Bans are kept.
+	RemoveMember(ctx context.Context, communityID, userID uuid.UUID) error
+	// UpdateMember changes a member's role and status, keeping the
+	// community's member count in step.
+	UpdateMember(ctx context.Context, member *models.CommunityMember) error
+	ListMembers(ctx context.Context, communityID uuid.UUID, status models.MembershipStatus, page pagination.Params) ([]models.CommunityMember, error)
+}
+
+type communityRepository struct {
+	db *gorm.DB
+}
+
+func NewCommunityRepository(db *gorm.DB) CommunityRepository {
+	return &communityRepository{db: db}
+}
+
+func (r *communityRepository) CreateCommunity(ctx context.Context, community *models.Community) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		community.MembersCount = 1
+		res := tx.Clauses(clause.OnConflict{DoNothing: true}).Create(community)
+		if res.Error != nil {
+			return res.Error
+		}
+		if res.RowsAffected == 0 {
+			return errs.ErrCommunitySlugTaken
+		}
+		return tx.Create(&models.CommunityMember{
+			CommunityID: community.ID,
+			UserID:      community.OwnerID,
+			Role:        models.CommunityRoleOwner,
+			Status:      models.MembershipActive,
+		}).Error
+	})
+}
+
+func (r *communityRepository) GetCommunityByID(ctx context.Context, id uuid.UUID) (*models.Community, error) {
+	var community models.Community
+	if err := r.db.WithContext(ctx).First(&community, id).Error; err != nil {
+		return nil, err
+	}
+	return &community, nil
+}
+
+func (r *communityRepository) GetCommunityBySlug(ctx context.Context, slug string) (*models.Community, error) {
+	var community models.Community
+	if err := r.db.WithContext(ctx).Where("slug = ?", slug).First(&community).Error; err != nil {
+		return nil, err
+	}
+	return &community, nil
+}
+
+// visibleCommunities limits a communities query to what viewerID may see.
+func visibleCommunities(viewerID uuid.UUID) func(*gorm.DB) *gorm.DB {
+	return func(db *gorm.DB) *gorm.DB {
+		return db.Where(
+			"communities.visibility <> ? OR EXISTS (SELECT 1 FROM community_members cm WHERE cm.community_id = communities.id AND cm.user_id = ? AND cm.status = ?)",
+			models.CommunityPrivate, viewerID, models.MembershipActive,
+		)
+	}
+}
+
+func (r *communityRepository) ListCommunities(ctx context.Context, viewerID uuid.UUID, page pagination.Params) ([]models.Community, error) {
+	var communities []models.Community
+	err := r.db.WithContext(ctx).
+		Scopes(visibleCommunities(viewerID), page.Scope("communities", parseUUID)).
+		Find(&communities).Error
+	return communities, err
+}
+
+func (r *communityRepository) CountCommunities(ctx context.Context, viewerID uuid.UUID) (int64, error) {
+	var total int64
+	err := r.db.WithContext(ctx).Model(&models.Community{}).
+		Scopes(visibleCommunities(viewerID)).
+		Count(&total).Error
+	return total, err
+}
+
+func (r *communityRepository) UpdateCommunity(ctx context.Context, id uuid.UUID, fields map[string]any) error {
+	return r.db.WithContext(ctx).Model(&models.Community{}).Where("id = ?", id).Updates(fields).Error
+}
+
+func (r *communityRepository) GetMember(ctx context.Context, communityID, userID uuid.UUID) (*models.CommunityMember, error) {
+	var member models.CommunityMember
+	err := r.db.WithContext(ctx).
+		Where("community_id = ? AND user_id = ?", communityID, userID).
+		First(&member).Error
+	if err != nil {
+		return nil, err
+	}
+	return &member, nil
+}
+
+func (r *communityRepository) GetMemberWithUser(ctx context.Context, communityID, userID uuid.UUID) (*models.CommunityMember, error) {
+	var member models.CommunityMember
+	err := r.db.WithContext(ctx).
+		Preload("User").
+		Where("community_id = ? AND user_id = ?", communityID, userID).
+		First(&member).Error
+	if err != nil {
+		return nil, err
+	}
+	return &member, nil
+}
+
+func (r *communityRepository) AddMember(ctx context.Context, member *models.CommunityMember) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		res := tx.Clauses(clause.OnConflict{DoNothing: true}).Create(member)
+		if res.Error != nil {
+			return res.Error
+		}
+		if res.RowsAffected == 0 {
+			return errs.ErrAlreadyMember
+		}
+		if member.Status != models.MembershipActive {
+			return nil
+		}
+		return incrementMembers(tx, member.CommunityID, 1)
+	})
+}
+
+func (r *communityRepository) RemoveMember(ctx context.Context, communityID, userID uuid.UUID) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		var member models.CommunityMember
+		err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).
+			Where("community_id = ? AND user_id = ?", communityID, userID).
+			First(&member).Error
+		if errors.Is(err, gorm.ErrRecordNotFound) || member.IsBanned() {
+			return errs.ErrNotMember
+		}
+		if err != nil {
+			return err
+		}
+
+		if err := tx.Delete(&member).Error; err != nil {
+			return err
+		}
+		if !member.IsActive() {
+			return nil
+		}
+		return incrementMembers(tx, communityID, -1)
+	})
+}
+
+func (r *communityRepository) UpdateMember(ctx context.Context, member *models.CommunityMember) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		var current models.CommunityMember
+		if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).
+			First(&current, member.ID).Error; err != nil {
+			return err
+		}
+
+		if err := tx.Model(&current).Updates(map[string]any{
+			"role":   member.Role,
+			"status": member.Status,
+		}).Error; err != nil {
+			return err
+		}
+
+		switch {
+		case !current.IsActive() && member.IsActive():
+			return incrementMembers(tx, member.CommunityID, 1)
+		case current.IsActive() && !member.IsActive():
+			return incrementMembers(tx, member.CommunityID, -1)
+		}
+		return nil
+	})
+}
+
+func (r *communityRepository) ListMembers(ctx context.Context, communityID uuid.UUID, status models.MembershipStatus, page pagination.Params) ([]models.CommunityMember, error) {
+	var members []models.CommunityMember
+	err := r.db.WithContext(ctx).
+		Preload("User").
+		Where("community_id = ? AND status = ?", communityID, status).
+		Scopes(page.Scope("community_members", parseReplyID)).
+		Find(&members).Error
+	return members, err
+}
+
+// incrementMembers adds delta to a community's member count, never letting
+// it go below zero.
+func incrementMembers(tx *gorm.DB, communityID uuid.UUID, delta int) error {
+	return tx.Model(&models.Community{}).
+		Where("id = ?", communityID).
+		UpdateColumn("members_count", gorm.Expr("GREATEST(members_count + ?, 0)", delta)).Error
+}
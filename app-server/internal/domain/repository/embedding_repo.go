@@ -0,0 +1,106 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/google/uuid"
+	"github.com/maulana1k/forum-app/internal/domain/models"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type EmbeddingRepository interface {
+	// ListPostsToEmbed returns up to limit published posts that have no
+	// embedding or were edited since theirs, newest first. Only the
+	// fields needed to embed them are loaded.
+	ListPostsToEmbed(ctx context.Context, limit int) ([]models.Post, error)
+	SaveEmbeddings(ctx context.Context, embeddings []models.PostEmbedding) error
+	GetEmbedding(ctx context.Context, postID uuid.UUID) (*models.PostEmbedding, error)
+	// FindSimilarPosts returns the main feed posts nearest to
+	// query.Embedding by cosine distance, closest first.
+	FindSimilarPosts(ctx context.Context, query SimilarPostsQuery) ([]SimilarPost, error)
+}
+
+// SimilarPostsQuery selects the neighbours returned by FindSimilarPosts.
+type SimilarPostsQuery struct {
+	Embedding models.Vector
+	// PostID and the posts of AuthorID are left out.
+	PostID   uuid.UUID
+	AuthorID uuid.UUID
+	// ViewerID's hidden authors are left out.
+	ViewerID uuid.UUID
+	Limit    int
+}
+
+// SimilarPost is a post ID with its cosine similarity to the query.
+type SimilarPost struct {
+	ID    uuid.UUID
+	Score float64
+}
+
+// minEFSearch is the fewest candidates the HNSW index yields per search.
+// The feed filters run on those candidates afterwards, so a search
+// restricted by blocks or one prolific author needs more than it returns.
+const minEFSearch = 100
+
+type embeddingRepository struct {
+	db *gorm.DB
+}
+
+func NewEmbeddingRepository(db *gorm.DB) EmbeddingRepository {
+	return &embeddingRepository{db: db}
+}
+
+func (r *embeddingRepository) ListPostsToEmbed(ctx context.Context, limit int) ([]models.Post, error) {
+	var posts []models.Post
+	err := r.db.WithContext(ctx).
+		Select("posts.id", "posts.content", "posts.tags", "posts.created_at", "posts.edited_at").
+		Joins("LEFT JOIN post_embeddings ON post_embeddings.post_id = posts.id").
+		Where("post_embeddings.post_id IS NULL OR posts.edited_at > post_embeddings.content_at").
+		Scopes(publishedPosts, visiblePosts(uuid.Nil)).
+		Order("posts.created_at DESC, posts.id").
+		Limit(limit).
+		Find(&posts).Error
+	return posts, err
+}
+
+func (r *embeddingRepository) SaveEmbeddings(ctx context.Context, embeddings []models.PostEmbedding) error {
+	if len(embeddings) == 0 {
+		return nil
+	}
+	return r.db.WithContext(ctx).Clauses(clause.OnConflict{
+		Columns:   []clause.Column{{Name: "post_id"}},
+		DoUpdates: clause.AssignmentColumns([]string{"embedding", "model", "content_at", "updated_at"}),
+	}).Create(&embeddings).Error
+}
+
+func (r *embeddingRepository) GetEmbedding(ctx context.Context, postID uuid.UUID) (*models.PostEmbedding, error) {
+	var e models.PostEmbedding
+	if err := r.db.WithContext(ctx).First(&e, "post_id = ?", postID).Error; err != nil {
+		return nil, err
+	}
+	return &e, nil
+}
+
+func (r *embeddingRepository) FindSimilarPosts(ctx context.Context, query SimilarPostsQuery) ([]SimilarPost, error) {
+	var similar []SimilarPost
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		// SET takes no bind parameters; the value is an int.
+		efSearch := max(minEFSearch, 4*query.Limit)
+		if err := tx.Exec(fmt.Sprintf("SET LOCAL hnsw.ef_search = %d", efSearch)).Error; err != nil {
+			return err
+		}
+
+		distance := clause.Expr{SQL: "post_embeddings.embedding <=> ?", Vars: []any{query.Embedding}}
+		return tx.Model(&models.Post{}).
+			Select("posts.id, 1 - (?) AS score", distance).
+			Joins("JOIN post_embeddings ON post_embeddings.post_id = posts.id").
+			Where("posts.id <> ? AND posts.author_id <> ?", query.PostID, query.AuthorID).
+			Scopes(publishedPosts, visiblePosts(query.ViewerID), activeAuthors, listedPosts, withoutHidden(query.ViewerID)).
+			Clauses(clause.OrderBy{Expression: distance}).
+			Limit(query.Limit).
+			Scan(&similar).Error
+	})
+	return similar, err
+}
@@ -0,0 +1,41 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/maulana1k/forum-app/internal/domain/models"
+	"gorm.io/gorm"
+)
+
+type ImpressionRepository interface {
+	// RecordImpressions stores the posts of one served recommendation page.
+	RecordImpressions(ctx context.Context, impressions []models.RecommendationImpression) error
+	// RecordClick marks a served post as opened by userID. Repeated clicks
+	// keep the first time. It returns false if userID was never served the
+	// post under serveID.
+	RecordClick(ctx context.Context, serveID, postID, userID uuid.UUID, at time.Time) (bool, error)
+}
+
+type impressionRepository struct {
+	db *gorm.DB
+}
+
+func NewImpressionRepository(db *gorm.DB) ImpressionRepository {
+	return &impressionRepository{db: db}
+}
+
+func (r *impressionRepository) RecordImpressions(ctx context.Context, impressions []models.RecommendationImpression) error {
+	if len(impressions) == 0 {
+		return nil
+	}
+	return r.db.WithContext(ctx).Create(&impressions).Error
+}
+
+func (r *impressionRepository) RecordClick(ctx context.Context, serveID, postID, userID uuid.UUID, at time.Time) (bool, error) {
+	res := r.db.WithContext(ctx).Model(&models.RecommendationImpression{}).
+		Where("serve_id = ? AND post_id = ? AND user_id = ?", serveID, postID, userID).
+		Update("clicked_at", gorm.Expr("COALESCE(clicked_at, ?)", at))
+	return res.RowsAffected > 0, res.Error
+}
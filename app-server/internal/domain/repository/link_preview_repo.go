@@ -0,0 +1,50 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	"github.com/maulana1k/forum-app/internal/domain/models"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type LinkPreviewRepository interface {
+	GetPreviewByURL(ctx context.Context, url string) (*models.LinkPreview, error)
+	// UpsertPreview stores preview, replacing the row for the same URL.
+	// The ID of the stored row is written back to preview.
+	UpsertPreview(ctx context.Context, preview *models.LinkPreview) error
+	AttachPreview(ctx context.Context, postID, previewID uuid.UUID) error
+}
+
+type linkPreviewRepository struct {
+	db *gorm.DB
+}
+
+func NewLinkPreviewRepository(db *gorm.DB) LinkPreviewRepository {
+	return &linkPreviewRepository{db: db}
+}
+
+func (r *linkPreviewRepository) GetPreviewByURL(ctx context.Context, url string) (*models.LinkPreview, error) {
+	var preview models.LinkPreview
+	if err := r.db.WithContext(ctx).Where("url = ?", url).First(&preview).Error; err != nil {
+		return nil, err
+	}
+	return &preview, nil
+}
+
+func (r *linkPreviewRepository) UpsertPreview(ctx context.Context, preview *models.LinkPreview) error {
+	return r.db.WithContext(ctx).
+		Clauses(clause.OnConflict{
+			Columns:   []clause.Column{{Name: "url"}},
+			DoUpdates: clause.AssignmentColumns([]string{"title", "description", "image_url", "site_name", "fetched_at"}),
+		}).
+		Create(preview).Error
+}
+
+func (r *linkPreviewRepository) AttachPreview(ctx context.Context, postID, previewID uuid.UUID) error {
+	// UpdateColumn leaves updated_at alone; a preview is not an edit.
+	return r.db.WithContext(ctx).Model(&models.Post{}).
+		Where("id = ?", postID).
+		UpdateColumn("link_preview_id", previewID).Error
+}
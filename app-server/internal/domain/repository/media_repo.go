@@ -0,0 +1,71 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	"github.com/maulana1k/forum-app/internal/domain/models"
+	"gorm.io/gorm"
+)
+
+type MediaRepository interface {
+	CreateMedia(ctx context.Context, media *models.Media) error
+	GetMediaByID(ctx context.Context, id uuid.UUID) (*models.Media, error)
+	GetMediaByKey(ctx context.Context, key string) (*models.Media, error)
+	// MarkReady stores the processing results of a pending upload. It
+	// returns false if the media was no longer pending.
+	MarkReady(ctx context.Context, media *models.Media) (bool, error)
+	DeleteMedia(ctx context.Context, id uuid.UUID) error
+}
+
+type mediaRepository struct {
+	db *gorm.DB
+}
+
+func NewMediaRepository(db *gorm.DB) MediaRepository {
+	return &mediaRepository{db: db}
+}
+
+func (r *mediaRepository) CreateMedia(ctx context.Context, media *models.Media) error {
+	return r.db.WithContext(ctx).Create(media).Error
+}
+
+func (r *mediaRepository) GetMediaByID(ctx context.Context, id uuid.UUID) (*models.Media, error) {
+	var media models.Media
+	if err := r.db.WithContext(ctx).First(&media, id).Error; err != nil {
+		return nil, err
+	}
+	return &media, nil
+}
+
+func (r *mediaRepository) GetMediaByKey(ctx context.Context, key string) (*models.Media, error) {
+	var media models.Media
+	if err := r.db.WithContext(ctx).Where("key = ?", key).First(&media).Error; err != nil {
+		return nil, err
+	}
+	return &media, nil
+}
+
+func (r *mediaRepository) MarkReady(ctx context.Context, media *models.Media) (bool, error) {
+	res := r.db.WithContext(ctx).Model(&models.Media{}).
+		Where("id = ? AND status = ?", media.ID, models.MediaPending).
+		Updates(map[string]any{
+			"status":        models.MediaReady,
+			"content_type":  media.ContentType,
+			"size":          media.Size,
+			"width":         media.Width,
+			"height":        media.Height,
+			"thumbnail_key": media.ThumbnailKey,
+			"url":           media.URL,
+			"thumbnail_url": media.ThumbnailURL,
+		})
+	if res.Error != nil {
+		return false, res.Error
+	}
+	media.Status = models.MediaReady
+	return res.RowsAffected > 0, nil
+}
+
+func (r *mediaRepository) DeleteMedia(ctx context.Context, id uuid.UUID) error {
+	return r.db.WithContext(ctx).Delete(&models.Media{}, id).Error
+}
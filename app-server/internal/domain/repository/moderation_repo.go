@@ -0,0 +1,30 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/maulana1k/forum-app/internal/domain/models"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type ModerationRepository interface {
+	// SaveResult stores result, replacing the earlier verdict of the same
+	// model on the same post.
+	SaveResult(ctx context.Context, result *models.ModerationResult) error
+}
+
+type moderationRepository struct {
+	db *gorm.DB
+}
+
+func NewModerationRepository(db *gorm.DB) ModerationRepository {
+	return &moderationRepository{db: db}
+}
+
+func (r *moderationRepository) SaveResult(ctx context.Context, result *models.ModerationResult) error {
+	return r.db.WithContext(ctx).Clauses(clause.OnConflict{
+		Columns:   []clause.Column{{Name: "post_id"}, {Name: "model"}},
+		DoUpdates: clause.AssignmentColumns([]string{"label", "score", "flagged", "updated_at"}),
+	}).Create(result).Error
+}
@@ -0,0 +1,79 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/maulana1k/forum-app/internal/domain/models"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type MutedWordRepository interface {
+	// SaveMutedWord adds a muted word, or updates the expiry of the same
+	// phrase if the user already muted it. The user's expired mutes are
+	// cleared at the same time.
+	SaveMutedWord(ctx context.Context, word *models.MutedWord) error
+	ListMutedWords(ctx context.Context, userID uuid.UUID) ([]models.MutedWord, error)
+	// ListActivePhrases returns the phrases userID has muted that have not
+	// expired at now.
+	ListActivePhrases(ctx context.Context, userID uuid.UUID, now time.Time) ([]string, error)
+	CountMutedWords(ctx context.Context, userID uuid.UUID) (int64, error)
+	// DeleteMutedWord returns false if userID has no muted word with id.
+	DeleteMutedWord(ctx context.Context, userID uuid.UUID, id uint) (bool, error)
+}
+
+type mutedWordRepository struct {
+	db *gorm.DB
+}
+
+func NewMutedWordRepository(db *gorm.DB) MutedWordRepository {
+	return &mutedWordRepository{db: db}
+}
+
+func (r *mutedWordRepository) SaveMutedWord(ctx context.Context, word *models.MutedWord) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Where("user_id = ? AND expires_at <= ?", word.UserID, time.Now()).
+			Delete(&models.MutedWord{}).Error; err != nil {
+			return err
+		}
+		return tx.Clauses(clause.OnConflict{
+			Columns:   []clause.Column{{Name: "user_id"}, {Name: "phrase"}},
+			DoUpdates: clause.AssignmentColumns([]string{"expires_at"}),
+		}).Create(word).Error
+	})
+}
+
+func (r *mutedWordRepository) ListMutedWords(ctx context.Context, userID uuid.UUID) ([]models.MutedWord, error) {
+	var words []models.MutedWord
+	err := r.db.WithContext(ctx).
+		Where("user_id = ?", userID).
+		Order("created_at DESC, id DESC").
+		Find(&words).Error
+	return words, err
+}
+
+func (r *mutedWordRepository) ListActivePhrases(ctx context.Context, userID uuid.UUID, now time.Time) ([]string, error) {
+	var phrases []string
+	err := r.db.WithContext(ctx).Model(&models.MutedWord{}).
+		Where("user_id = ? AND (expires_at IS NULL OR expires_at > ?)", userID, now).
+		Order("id").
+		Pluck("phrase", &phrases).Error
+	return phrases, err
+}
+
+func (r *mutedWordRepository) CountMutedWords(ctx context.Context, userID uuid.UUID) (int64, error) {
+	var total int64
+	err := r.db.WithContext(ctx).Model(&models.MutedWord{}).
+		Where("user_id = ?", userID).
+		Count(&total).Error
+	return total, err
+}
+
+func (r *mutedWordRepository) DeleteMutedWord(ctx context.Context, userID uuid.UUID, id uint) (bool, error) {
+	res := r.db.WithContext(ctx).
+		Where("id = ? AND user_id = ?", id, userID).
+		Delete(&models.MutedWord{})
+	return res.RowsAffected > 0, res.Error
+}
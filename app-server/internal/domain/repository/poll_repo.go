@@ -0,0 +1,211 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/maulana1k/forum-app/internal/domain/errs"
+	"github.com/maulana1k/forum-app/internal/domain/models"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type PollRepository interface {
+	GetPollByPostID(ctx context.Context, postID uuid.UUID) (*models.Poll, error)
+	// Vote records userID's choice of optionIDs on pollID and bumps the
+	// tallies. A user votes once per poll; unvote first to change it.
+	Vote(ctx context.Context, pollID, userID uuid.UUID, optionIDs []uint) error
+	// Unvote withdraws every vote userID cast on pollID.
+	Unvote(ctx context.Context, pollID, userID uuid.UUID) error
+	GetUserVotes(ctx context.Context, userID uuid.UUID, pollIDs []uuid.UUID) (map[uuid.UUID][]uint, error)
+	// ClaimClosedPolls marks up to limit polls whose close time has passed
+	// as closed and returns them with their final tallies. Rows locked by
+	// another replica are skipped, so every poll is claimed exactly once.
+	ClaimClosedPolls(ctx context.Context, now time.Time, limit int) ([]models.Poll, error)
+}
+
+type pollRepository struct {
+	db *gorm.DB
+}
+
+func NewPollRepository(db *gorm.DB) PollRepository {
+	return &pollRepository{db: db}
+}
+
+// orderPollOptions keeps options in the order the author wrote them.
+func orderPollOptions(db *gorm.DB) *gorm.DB {
+	return db.Order("position")
+}
+
+func (r *pollRepository) GetPollByPostID(ctx context.Context, postID uuid.UUID) (*models.Poll, error) {
+	var poll models.Poll
+	err := r.db.WithContext(ctx).
+		Preload("Options", orderPollOptions).
+		Where("post_id = ?", postID).
+		First(&poll).Error
+	if err != nil {
+		return nil, err
+	}
+	return &poll, nil
+}
+
+func (r *pollRepository) Vote(ctx context.Context, pollID, userID uuid.UUID, optionIDs []uint) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		// Locking the poll serialises votes on it, so two concurrent
+		// requests from one user cannot both pass the already-voted check.
+		poll, err := lockPoll(tx, pollID)
+		if err != nil {
+			return err
+		}
+
+		var voted int64
+		if err := tx.Model(&models.PollVote{}).
+			Where("poll_id = ? AND user_id = ?", pollID, userID).
+			Count(&voted).Error; err != nil {
+			return err
+		}
+		if voted > 0 {
+			return errs.ErrPollAlreadyVoted
+		}
+
+		var valid int64
+		if err := tx.Model(&models.PollOption{}).
+			Where("poll_id = ? AND id IN ?", poll.ID, optionIDs).
+			Count(&valid).Error; err != nil {
+			return err
+		}
+		if int(valid) != len(optionIDs) {
+			return errs.ErrInvalidPollOption
+		}
+
+		votes := make([]models.PollVote, len(optionIDs))
+		for i, id := range optionIDs {
+			votes[i] = models.PollVote{PollID: pollID, UserID: userID, OptionID: id}
+		}
+		if err := tx.Create(&votes).Error; err != nil {
+			return err
+		}
+
+		if err := tx.Model(&models.PollOption{}).
+			Where("id IN ?", optionIDs).
+			UpdateColumn("votes_count", gorm.Expr("votes_count + 1")).Error; err != nil {
+			return err
+		}
+		return tx.Model(&models.Poll{}).
+			Where("id = ?", pollID).
+			UpdateColumn("voters_count", gorm.Expr("voters_count + 1")).Error
+	})
+}
+
+func (r *pollRepository) Unvote(ctx context.Context, pollID, userID uuid.UUID) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if _, err := lockPoll(tx, pollID); err != nil {
+			return err
+		}
+
+		var removed []models.PollVote
+		if err := tx.Clauses(clause.Returning{Columns: []clause.Column{{Name: "option_id"}}}).
+			Where("poll_id = ? AND user_id = ?", pollID, userID).
+			Delete(&removed).Error; err != nil {
+			return err
+		}
+		if len(removed) == 0 {
+			return errs.ErrPollNotVoted
+		}
+
+		optionIDs := make([]uint, len(removed))
+		for i, v := range removed {
+			optionIDs[i] = v.OptionID
+		}
+		if err := tx.Model(&models.PollOption{}).
+			Where("id IN ?", optionIDs).
+			UpdateColumn("votes_count", gorm.Expr("GREATEST(votes_count - 1, 0)")).Error; err != nil {
+			return err
+		}
+		return tx.Model(&models.Poll{}).
+			Where("id = ?", pollID).
+			UpdateColumn("voters_count", gorm.Expr("GREATEST(voters_count - 1, 0)")).Error
+	})
+}
+
+// lockPoll loads pollID FOR UPDATE and refuses polls that are closed.
+func lockPoll(tx *gorm.DB, pollID uuid.UUID) (*models.Poll, error) {
+	var poll models.Poll
+	err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).
+		Where("id = ?", pollID).
+		First(&poll).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, errs.ErrPollNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+	if poll.IsClosed(time.Now()) {
+		return nil, errs.ErrPollClosed
+	}
+	return &poll, nil
+}
+
+func (r *pollRepository) GetUserVotes(ctx context.Context, userID uuid.UUID, pollIDs []uuid.UUID) (map[uuid.UUID][]uint, error) {
+	votes := make(map[uuid.UUID][]uint)
+	if len(pollIDs) == 0 {
+		return votes, nil
+	}
+
+	var rows []models.PollVote
+	err := r.db.WithContext(ctx).
+		Select("poll_id", "option_id").
+		Where("user_id = ? AND poll_id IN ?", userID, pollIDs).
+		Order("option_id").
+		Find(&rows).Error
+	if err != nil {
+		return nil, err
+	}
+
+	for _, v := range rows {
+		votes[v.PollID] = append(votes[v.PollID], v.OptionID)
+	}
+	return votes, nil
+}
+
+func (r *pollRepository) ClaimClosedPolls(ctx context.Context, now time.Time, limit int) ([]models.Poll, error) {
+	var polls []models.Poll
+
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		err := tx.Raw(`
+			UPDATE polls SET closed_at = ?
+			WHERE id IN (
+				SELECT id FROM polls
+				WHERE closed_at IS NULL AND closes_at <= ?
+				ORDER BY closes_at
+				LIMIT ?
+				FOR UPDATE SKIP LOCKED
+			)
+			RETURNING *`, now, now, limit).
+			Scan(&polls).Error
+		if err != nil || len(polls) == 0 {
+			return err
+		}
+
+		ids := make([]uuid.UUID, len(polls))
+		for i, p := range polls {
+			ids[i] = p.ID
+		}
+
+		var options []models.PollOption
+		if err := orderPollOptions(tx).Where("poll_id IN ?", ids).Find(&options).Error; err != nil {
+			return err
+		}
+		for i := range polls {
+			for _, o := range options {
+				if o.PollID == polls[i].ID {
+					polls[i].Options = append(polls[i].Options, o)
+				}
+			}
+		}
+		return nil
+	})
+	return polls, err
+}
@@ -1,28 +1,66 @@
 package repository
 
 import (
+	"context"
+	"strconv"
+	"time"
+
 	"github.com/google/uuid"
+	"github.com/maulana1k/forum-app/internal/domain/errs"
 	"github.com/maulana1k/forum-app/internal/domain/models"
+	"github.com/maulana1k/forum-app/internal/pkg/pagination"
 	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
 )
 
 type PostRepository interface {
-	CreatePost(post *models.Post) error
-	GetPostByID(id string) (*models.Post, error)
-	GetAllPosts(offset, limit int) ([]models.Post, int64, error)
-	UpdatePost(id string, post *models.Post) error
-	DeletePost(id string) error
-	GetPostsByUserID(userID string, offset, limit int) ([]models.Post, int64, error)
-	LikePost(postID, userID string) error
-	UnlikePost(postID, userID string) error
-	IsPostLikedByUser(postID, userID string) (bool, error)
-	BookmarkPost(postID, userID string) error
-	UnbookmarkPost(postID, userID string) error
-	IsPostBookmarkedByUser(postID, userID string) (bool, error)
+	CreatePost(ctx context.Context, post *models.Post) error
+	GetPostByID(ctx context.Context, id string) (*models.Post, error)
+	// GetAllPosts returns the main feed as seen by viewerID, leaving out
+	// users they blocked, muted or were blocked by.
+	GetAllPosts(ctx context.Context, page pagination.Params, viewerID uuid.UUID) ([]models.Post, error)
+	CountPosts(ctx context.Context, viewerID uuid.UUID) (int64, error)
+	// GetPostsByIDs loads the listed posts viewerID may see in the main
+	// feed, in no particular order.
+	GetPostsByIDs(ctx context.Context, ids []uuid.UUID, viewerID uuid.UUID) ([]models.Post, error)
+	// ListPostsSince returns up to limit main feed posts that come after
+	// (since, after) in (created_at, id) order, oldest first.
+	ListPostsSince(ctx context.Context, since time.Time, after uuid.UUID, limit int) ([]models.Post, error)
+	// RankPopularPosts ranks recent posts by engagement that decays with
+	// age. It stands in for the recommender when that is down.
+	RankPopularPosts(ctx context.Context, query PopularPostsQuery) ([]ScoredPost, error)
+	// UpdatePost applies the non-empty fields of post and records the
+	// result as a revision by editorID. It is a no-op if nothing changed.
+	UpdatePost(ctx context.Context, id string, post *models.Post, editorID uuid.UUID) error
+	ListRevisions(ctx context.Context, postID uuid.UUID) ([]models.PostRevision, error)
+	GetDraftsByUserID(ctx context.Context, userID uuid.UUID, page pagination.Params) ([]models.Post, error)
+	// PublishPost publishes a draft or scheduled post now. It returns
+	// false if the post was already published.
+	PublishPost(ctx context.Context, postID uuid.UUID) (bool, error)
+	// ClaimDuePosts publishes up to limit scheduled posts whose publish
+	// time has passed and returns them. Rows locked by another replica are
+	// skipped, so every post is published exactly once.
+	ClaimDuePosts(ctx context.Context, now time.Time, limit int) ([]models.Post, error)
+	DeletePost(ctx context.Context, id string) error
+	// GetPostsByUserID returns a user's posts, or none if viewerID and the
+	// user blocked each other.
+	GetPostsByUserID(ctx context.Context, userID string, page pagination.Params, viewerID uuid.UUID) ([]models.Post, error)
+	CountPostsByUserID(ctx context.Context, userID string, viewerID uuid.UUID) (int64, error)
+	// GetPostsByCommunityID returns a community feed, filtered like
+	// GetAllPosts.
+	GetPostsByCommunityID(ctx context.Context, communityID uuid.UUID, page pagination.Params, viewerID uuid.UUID) ([]models.Post, error)
+	CountPostsByCommunityID(ctx context.Context, communityID uuid.UUID, viewerID uuid.UUID) (int64, error)
+	GetRepliesByPostID(ctx context.Context, postID string, page pagination.Params, viewerID uuid.UUID) ([]models.Replies, error)
+	CountRepliesByPostID(ctx context.Context, postID string, viewerID uuid.UUID) (int64, error)
+	BookmarkPost(ctx context.Context, postID, userID string) error
+	UnbookmarkPost(ctx context.Context, postID, userID string) error
+	IsPostBookmarkedByUser(ctx context.Context, postID, userID string) (bool, error)
+	ListPostIDs(ctx context.Context, after uuid.UUID, limit int) ([]uuid.UUID, error)
+	ReconcileCounters(ctx context.Context, postIDs []uuid.UUID) ([]uuid.UUID, error)
 	// RepostByUser(postID, userID uint) error
 	// UnrepostByUser(postID, userID uint) error
 	// IsPostRepostedByUser(postID, userID uint) (bool, error)
-	GetPostWithDetails(id string) (*models.Post, error)
+	GetPostWithDetails(ctx context.Context, id string) (*models.Post, error)
 }
 
 type postRepository struct {
@@ -35,223 +73,393 @@ func NewPostRepository(db *gorm.DB) PostRepository {
 	}
 }
 
-func (r *postRepository) CreatePost(post *models.Post) error {
-	return r.db.Create(post).Error
+// CreatePost inserts post and, for a published quote, bumps the quoted
+// post's repost count in the same transaction.
+func (r *postRepository) CreatePost(ctx context.Context, post *models.Post) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Create(post).Error; err != nil {
+			return err
+		}
+		if post.QuotedPostID == nil || !post.IsPublished() {
+			return nil
+		}
+		return incrementCounter(tx, *post.QuotedPostID, "reposts_count", 1)
+	})
+}
+
+// publishedPosts limits a posts query to what other users may see.
+func publishedPosts(db *gorm.DB) *gorm.DB {
+	return db.Where("posts.status = ?", models.PostPublished)
+}
+
+// visiblePosts leaves out posts their author keeps to themselves, unless
+// viewerID is that author. uuid.Nil leaves out all of them.
+func visiblePosts(viewerID uuid.UUID) func(*gorm.DB) *gorm.DB {
+	return func(db *gorm.DB) *gorm.DB {
+		return db.Where("posts.visibility <> ? OR posts.author_id = ?", models.PostOnlyMe, viewerID)
+	}
+}
+
+// listedPosts leaves out posts in private communities, which only show up
+// in the community's own feed.
+func listedPosts(db *gorm.DB) *gorm.DB {
+	return db.Where("posts.community_id IS NULL OR posts.community_id IN (?)",
+		db.Session(&gorm.Session{NewDB: true}).Model(&models.Community{}).
+			Select("id").
+			Where("visibility <> ?", models.CommunityPrivate))
 }
 
-func (r *postRepository) GetPostByID(id string) (*models.Post, error) {
+// activeAuthors leaves out posts of users who deactivated their account.
+func activeAuthors(db *gorm.DB) *gorm.DB {
+	return db.Where("posts.author_id NOT IN (?)",
+		db.Session(&gorm.Session{NewDB: true}).Model(&models.User{}).
+			Select("id").
+			Where("deactivated_at IS NOT NULL"))
+}
+
+func (r *postRepository) GetPostByID(ctx context.Context, id string) (*models.Post, error) {
 	postID, err := uuid.Parse(id)
 	if err != nil {
 		return nil, err
 	}
 
 	var post models.Post
-	err = r.db.Preload("Author").
+	err = r.db.WithContext(ctx).Preload("Author").
 		Preload("Replies").
+		Preload("ReactionCounts").
+		Preload("Media").
+		Preload("LinkPreview").
+		Preload("Community").
+		Preload("Poll.Options", orderPollOptions).
 		First(&post, postID).Error
 	if err != nil {
 		return nil, err
 	}
-	post.LikesCount = r.getLikesCounts(postID)[postID]
-	post.RepliesCount = r.getRepliesCounts(postID)[postID]
-	post.RepostsCount = r.getRepostsCounts(postID)[postID]
-
 	return &post, nil
 }
 
-func (r *postRepository) GetAllPosts(offset, limit int) ([]models.Post, int64, error) {
+func (r *postRepository) GetAllPosts(ctx context.Context, page pagination.Params, viewerID uuid.UUID) ([]models.Post, error) {
 	var posts []models.Post
-	var total int64
-
-	// Total posts count
-	// if err := r.db.Model(&models.Post{}).Count(&total).Error; err != nil {
-	// 	return nil, 0, err
-	// }
 
 	// Fetch posts with relationships
-	if err := r.db.Preload("Author").
-		Preload("Replies").
+	if err := r.db.WithContext(ctx).Preload("Author").
+		Preload("Replies", withoutBlockedReplies(viewerID)).
 		Preload("QuotedPost").
-		Offset(offset).
-		Limit(limit).
-		Order("created_at DESC").
+		Preload("ReactionCounts").
+		Preload("Media").
+		Preload("LinkPreview").
+		Preload("Community").
+		Preload("Poll.Options", orderPollOptions).
+		Scopes(publishedPosts, visiblePosts(viewerID), activeAuthors, listedPosts, withoutHidden(viewerID), page.Scope("posts", parseUUID)).
 		Find(&posts).Error; err != nil {
-		return nil, 0, err
-	}
-
-	// Extract post IDs
-	total = int64(len(posts))
-	postIDs := make([]uuid.UUID, len(posts))
-	for i, p := range posts {
-		postIDs[i] = p.ID
+		return nil, err
 	}
 
-	// Batch fetch counts
-	likesCounts := r.getLikesCounts(postIDs...)
-	repliesCounts := r.getRepliesCounts(postIDs...)
-	repostsCounts := r.getRepostsCounts(postIDs...)
-
-	// Assign counts to posts
-	for i, p := range posts {
-		posts[i].LikesCount = likesCounts[p.ID]
-		posts[i].RepliesCount = repliesCounts[p.ID]
-		posts[i].RepostsCount = repostsCounts[p.ID]
-	}
+	return posts, nil
+}
 
-	return posts, total, nil
+func (r *postRepository) CountPosts(ctx context.Context, viewerID uuid.UUID) (int64, error) {
+	var total int64
+	err := r.db.WithContext(ctx).Model(&models.Post{}).Scopes(publishedPosts, visiblePosts(viewerID), activeAuthors, listedPosts, withoutHidden(viewerID)).Count(&total).Error
+	return total, err
 }
 
-// Count likes for a post
-func (r *postRepository) getLikesCounts(postIDs ...uuid.UUID) map[uuid.UUID]int {
-	if len(postIDs) == 0 {
-		return map[uuid.UUID]int{}
+func (r *postRepository) GetPostsByIDs(ctx context.Context, ids []uuid.UUID, viewerID uuid.UUID) ([]models.Post, error) {
+	if len(ids) == 0 {
+		return nil, nil
 	}
 
-	var results []struct {
-		PostID uuid.UUID
-		Count  int64
+	var posts []models.Post
+	if err := r.db.WithContext(ctx).Preload("Author").
+		Preload("Replies", withoutBlockedReplies(viewerID)).
+		Preload("QuotedPost").
+		Preload("ReactionCounts").
+		Preload("Media").
+		Preload("LinkPreview").
+		Preload("Community").
+		Preload("Poll.Options", orderPollOptions).
+		Where("posts.id IN ?", ids).
+		Scopes(publishedPosts, visiblePosts(viewerID), activeAuthors, listedPosts, withoutHidden(viewerID)).
+		Find(&posts).Error; err != nil {
+		return nil, err
 	}
+	return posts, nil
+}
 
-	r.db.Model(&models.PostInteractions{}).
-		Select("post_id, COUNT(*) as count").
-		Where("post_id IN ?", postIDs).
-		Group("post_id").
-		Scan(&results)
+func (r *postRepository) ListPostsSince(ctx context.Context, since time.Time, after uuid.UUID, limit int) ([]models.Post, error) {
+	var posts []models.Post
+	err := r.db.WithContext(ctx).Preload("Author").
+		Preload("QuotedPost").
+		Preload("Community").
+		Where("(posts.created_at, posts.id) > (?, ?)", since, after).
+		Scopes(publishedPosts, visiblePosts(uuid.Nil), activeAuthors, listedPosts).
+		Order("posts.created_at, posts.id").
+		Limit(limit).
+		Find(&posts).Error
+	return posts, err
+}
 
-	counts := make(map[uuid.UUID]int, len(results))
-	for _, r := range results {
-		counts[r.PostID] = int(r.Count)
-	}
-	return counts
+// PopularPostsQuery selects a page of RankPopularPosts.
+type PopularPostsQuery struct {
+	ViewerID uuid.UUID
+	// Since leaves out posts created before it.
+	Since time.Time
+	// Topic ranks posts tagged with it first.
+	Topic          string
+	ExcludePostIDs []uuid.UUID
+	Limit          int
+	Offset         int
 }
 
-// Batch count replies
-func (r *postRepository) getRepliesCounts(postIDs ...uuid.UUID) map[uuid.UUID]int {
-	if len(postIDs) == 0 {
-		return map[uuid.UUID]int{}
-	}
+// ScoredPost is a post ID with its rank score; higher ranks first.
+type ScoredPost struct {
+	ID         uuid.UUID
+	Score      float64
+	TopicMatch bool
+}
 
-	var results []struct {
-		PostID uuid.UUID
-		Count  int64
+// popularityScore weighs replies and reposts above likes and lets a post's
+// score fall as it ages, like the hot ranking of link aggregators.
+const popularityScore = `(posts.likes_count + 2 * posts.replies_count + 3 * posts.reposts_count + 1) /
+	power(extract(epoch from now() - posts.created_at) / 3600 + 2, 1.5)`
+
+func (r *postRepository) RankPopularPosts(ctx context.Context, query PopularPostsQuery) ([]ScoredPost, error) {
+	db := r.db.WithContext(ctx).Model(&models.Post{}).
+		Select("posts.id, "+popularityScore+" AS score, (? <> '' AND posts.tags ILIKE '%' || ? || '%') AS topic_match", query.Topic, query.Topic).
+		Where("posts.created_at >= ?", query.Since).
+		Scopes(publishedPosts, visiblePosts(query.ViewerID), activeAuthors, listedPosts, withoutHidden(query.ViewerID))
+	if len(query.ExcludePostIDs) > 0 {
+		db = db.Where("posts.id NOT IN ?", query.ExcludePostIDs)
 	}
-	r.db.Model(&models.Replies{}).
-		Select("post_id, COUNT(*) as count").
-		Where("post_id IN ?", postIDs).
-		Group("post_id").
-		Scan(&results)
-
-	counts := make(map[uuid.UUID]int)
-	for _, r := range results {
-		counts[r.PostID] = int(r.Count)
-	}
-	return counts
+
+	var scored []ScoredPost
+	err := db.Order("topic_match DESC, score DESC, posts.id").
+		Limit(query.Limit).
+		Offset(query.Offset).
+		Scan(&scored).Error
+	return scored, err
 }
 
-// Batch count reposts
-func (r *postRepository) getRepostsCounts(postIDs ...uuid.UUID) map[uuid.UUID]int {
-	if len(postIDs) == 0 {
-		return map[uuid.UUID]int{}
-	}
-	var results []struct {
-		PostID uuid.UUID
-		Count  int64
-	}
-	r.db.Model(&models.Post{}).
-		Select("quoted_post_id as post_id, COUNT(*) as count").
-		Where("quoted_post_id IN ?", postIDs).
-		Group("quoted_post_id").
-		Scan(&results)
-
-	counts := make(map[uuid.UUID]int)
-	for _, r := range results {
-		counts[r.PostID] = int(r.Count)
-	}
-	return counts
+func parseUUID(id string) (any, error) {
+	return uuid.Parse(id)
 }
 
-func (r *postRepository) UpdatePost(id string, post *models.Post) error {
-	return r.db.Model(&models.Post{}).Where("id = ?", id).Updates(post).Error
+func (r *postRepository) UpdatePost(ctx context.Context, id string, post *models.Post, editorID uuid.UUID) error {
+	postID, err := uuid.Parse(id)
+	if err != nil {
+		return err
+	}
+
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		// The lock keeps revision numbers gapless under concurrent edits.
+		var current models.Post
+		if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).
+			Select("id", "author_id", "content", "content_html", "tags", "image_url", "media_id", "created_at", "revision_count", "status").
+			First(&current, postID).Error; err != nil {
+			return err
+		}
+
+		next := current
+		if post.Content != "" {
+			next.Content = post.Content
+			next.ContentHTML = post.ContentHTML
+		}
+		if post.Tags != "" {
+			next.Tags = post.Tags
+		}
+		if post.MediaID != nil {
+			next.MediaID = post.MediaID
+			next.ImageURL = post.ImageURL
+		}
+		if next.Content == current.Content && next.Tags == current.Tags && next.ImageURL == current.ImageURL {
+			return nil
+		}
+
+		now := time.Now()
+		if !current.IsPublished() {
+			// Unpublished work in progress has no history to keep.
+			return tx.Model(&models.Post{}).Where("id = ?", postID).Updates(map[string]any{
+				"content":      next.Content,
+				"content_html": next.ContentHTML,
+				"tags":         next.Tags,
+				"image_url":    next.ImageURL,
+				"media_id":     next.MediaID,
+				"updated_at":   now,
+			}).Error
+		}
+
+		var revisions []models.PostRevision
+		if current.RevisionCount == 0 {
+			revisions = append(revisions, models.PostRevision{
+				PostID:      postID,
+				Revision:    1,
+				EditorID:    current.AuthorID,
+				Content:     current.Content,
+				ContentHTML: current.ContentHTML,
+				Tags:        current.Tags,
+				ImageURL:    current.ImageURL,
+				CreatedAt:   current.CreatedAt,
+			})
+		}
+		revisions = append(revisions, models.PostRevision{
+			PostID:      postID,
+			Revision:    current.RevisionCount + 2,
+			EditorID:    editorID,
+			Content:     next.Content,
+			ContentHTML: next.ContentHTML,
+			Tags:        next.Tags,
+			ImageURL:    next.ImageURL,
+			CreatedAt:   now,
+		})
+		if err := tx.Create(&revisions).Error; err != nil {
+			return err
+		}
+
+		return tx.Model(&models.Post{}).Where("id = ?", postID).Updates(map[string]any{
+			"content":        next.Content,
+			"content_html":   next.ContentHTML,
+			"tags":           next.Tags,
+			"image_url":      next.ImageURL,
+			"media_id":       next.MediaID,
+			"edited_at":      now,
+			"updated_at":     now,
+			"revision_count": gorm.Expr("revision_count + 1"),
+		}).Error
+	})
 }
 
-func (r *postRepository) DeletePost(id string) error {
-	return r.db.Delete(&models.Post{}, id).Error
+// ListRevisions returns every recorded version of a post, oldest first.
+func (r *postRepository) ListRevisions(ctx context.Context, postID uuid.UUID) ([]models.PostRevision, error) {
+	var revisions []models.PostRevision
+	err := r.db.WithContext(ctx).
+		Where("post_id = ?", postID).
+		Order("revision").
+		Find(&revisions).Error
+	return revisions, err
 }
 
-func (r *postRepository) GetPostsByUserID(userID string, offset, limit int) ([]models.Post, int64, error) {
-	uid, err := uuid.Parse(userID)
+func (r *postRepository) DeletePost(ctx context.Context, id string) error {
+	postID, err := uuid.Parse(id)
 	if err != nil {
-		return nil, 0, err
+		return err
 	}
 
-	var posts []models.Post
-	var total int64
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		var post models.Post
+		if err := tx.Select("id", "quoted_post_id", "status").First(&post, postID).Error; err != nil {
+			return err
+		}
+		if err := tx.Delete(&post).Error; err != nil {
+			return err
+		}
+		if post.QuotedPostID == nil || !post.IsPublished() {
+			return nil
+		}
+		return incrementCounter(tx, *post.QuotedPostID, "reposts_count", -1)
+	})
+}
 
-	if err := r.db.Model(&models.Post{}).
-		Where("author_id = ?", uid).
-		Count(&total).Error; err != nil {
-		return nil, 0, err
+func (r *postRepository) GetPostsByUserID(ctx context.Context, userID string, page pagination.Params, viewerID uuid.UUID) ([]models.Post, error) {
+	uid, err := uuid.Parse(userID)
+	if err != nil {
+		return nil, err
 	}
 
-	if err := r.db.
+	var posts []models.Post
+	if err := r.db.WithContext(ctx).
 		Where("author_id = ?", uid).
 		Preload("Author", func(db *gorm.DB) *gorm.DB {
 			return db.Select("id", "username", "display_name", "avatar_url", "bio")
 		}).
 		Preload("Replies", func(db *gorm.DB) *gorm.DB {
-			return db.Order("created_at ASC")
+			return db.Scopes(withoutBlockedReplies(viewerID)).Order("created_at ASC")
 		}).
 		Preload("QuotedPost").
-		Offset(offset).
-		Limit(limit).
-		Order("created_at DESC").
+		Preload("ReactionCounts").
+		Preload("Media").
+		Preload("LinkPreview").
+		Preload("Community").
+		Preload("Poll.Options", orderPollOptions).
+		Scopes(publishedPosts, visiblePosts(viewerID), activeAuthors, listedPosts, withoutBlocked(viewerID), page.Scope("posts", parseUUID)).
 		Find(&posts).Error; err != nil {
-		return nil, 0, err
+		return nil, err
 	}
 
-	return posts, total, nil
+	return posts, nil
 }
 
-func (r *postRepository) LikePost(postIDstr, userIDstr string) error {
-	postID, err := uuid.Parse(postIDstr)
+func (r *postRepository) CountPostsByUserID(ctx context.Context, userID string, viewerID uuid.UUID) (int64, error) {
+	uid, err := uuid.Parse(userID)
 	if err != nil {
-		return err
+		return 0, err
 	}
 
-	userID, err := uuid.Parse(userIDstr)
-	if err != nil {
-		return err
-	}
-	like := models.PostInteractions{
-		PostID:          postID,
-		UserID:          userID,
-		InteractionType: string(models.LIKE),
-	}
-	return r.db.Create(&like).Error
+	var total int64
+	err = r.db.WithContext(ctx).Model(&models.Post{}).
+		Where("author_id = ?", uid).
+		Scopes(publishedPosts, visiblePosts(viewerID), activeAuthors, listedPosts, withoutBlocked(viewerID)).
+		Count(&total).Error
+	return total, err
 }
 
-func (r *postRepository) UnlikePost(postIDstr, userIDstr string) error {
-	postID, err := uuid.Parse(postIDstr)
+func (r *postRepository) GetPostsByCommunityID(ctx context.Context, communityID uuid.UUID, page pagination.Params, viewerID uuid.UUID) ([]models.Post, error) {
+	var posts []models.Post
+	err := r.db.WithContext(ctx).
+		Where("community_id = ?", communityID).
+		Preload("Author").
+		Preload("QuotedPost").
+		Preload("ReactionCounts").
+		Preload("Media").
+		Preload("LinkPreview").
+		Preload("Community").
+		Preload("Poll.Options", orderPollOptions).
+		Scopes(publishedPosts, visiblePosts(viewerID), activeAuthors, withoutHidden(viewerID), page.Scope("posts", parseUUID)).
+		Find(&posts).Error
+	return posts, err
+}
+
+func (r *postRepository) CountPostsByCommunityID(ctx context.Context, communityID uuid.UUID, viewerID uuid.UUID) (int64, error) {
+	var total int64
+	err := r.db.WithContext(ctx).Model(&models.Post{}).
+		Where("community_id = ?", communityID).
+		Scopes(publishedPosts, visiblePosts(viewerID), activeAuthors, withoutHidden(viewerID)).
+		Count(&total).Error
+	return total, err
+}
+
+func (r *postRepository) GetRepliesByPostID(ctx context.Context, postID string, page pagination.Params, viewerID uuid.UUID) ([]models.Replies, error) {
+	pid, err := uuid.Parse(postID)
 	if err != nil {
-		return err
+		return nil, err
 	}
-	userID, err := uuid.Parse(userIDstr)
+
+	var replies []models.Replies
+	err = r.db.WithContext(ctx).
+		Where("post_id = ?", pid).
+		Scopes(withoutBlockedReplies(viewerID), page.Scope("replies", parseReplyID)).
+		Find(&replies).Error
+	return replies, err
+}
+
+func (r *postRepository) CountRepliesByPostID(ctx context.Context, postID string, viewerID uuid.UUID) (int64, error) {
+	pid, err := uuid.Parse(postID)
 	if err != nil {
-		return err
+		return 0, err
 	}
-	return r.db.Where("post_id = ? AND user_id = ? AND interaction_type = 'LIKE'", postID, userID).
-		Delete(&models.PostInteractions{}).Error
+
+	var total int64
+	err = r.db.WithContext(ctx).Model(&models.Replies{}).
+		Where("post_id = ?", pid).
+		Scopes(withoutBlockedReplies(viewerID)).
+		Count(&total).Error
+	return total, err
 }
 
-func (r *postRepository) IsPostLikedByUser(postID, userID string) (bool, error) {
-	var count int64
-	err := r.db.Model(&models.PostInteractions{}).
-		Where("post_id = ? AND user_id = ? AND interaction_type = 'LIKE'", postID, userID).
-		Count(&count).Error
-	return count > 0, err
+func parseReplyID(id string) (any, error) {
+	return strconv.ParseUint(id, 10, 64)
 }
 
-func (r *postRepository) BookmarkPost(postIDstr, userIDstr string) error {
+func (r *postRepository) BookmarkPost(ctx context.Context, postIDstr, userIDstr string) error {
 	postID, err := uuid.Parse(postIDstr)
 	if err != nil {
 		return err
@@ -266,10 +474,18 @@ func (r *postRepository) BookmarkPost(postIDstr, userIDstr string) error {
 		UserID:          userID,
 		InteractionType: string(models.BOOKMARK),
 	}
-	return r.db.Create(&bookmark).Error
+
+	res := r.db.WithContext(ctx).Clauses(clause.OnConflict{DoNothing: true}).Create(&bookmark)
+	if res.Error != nil {
+		return res.Error
+	}
+	if res.RowsAffected == 0 {
+		return errs.ErrPostAlreadySaved
+	}
+	return nil
 }
 
-func (r *postRepository) UnbookmarkPost(postIDstr, userIDstr string) error {
+func (r *postRepository) UnbookmarkPost(ctx context.Context, postIDstr, userIDstr string) error {
 	postID, err := uuid.Parse(postIDstr)
 	if err != nil {
 		return err
@@ -278,14 +494,14 @@ func (r *postRepository) UnbookmarkPost(postIDstr, userIDstr string) error {
 	if err != nil {
 		return err
 	}
-	return r.db.Where("post_id = ? AND user_id = ? AND interaction_type = 'BOOKMARK'", postID, userID).
+	return r.db.WithContext(ctx).Where("post_id = ? AND user_id = ? AND interaction_type = ?", postID, userID, models.BOOKMARK).
 		Delete(&models.PostInteractions{}).Error
 }
 
-func (r *postRepository) IsPostBookmarkedByUser(postID, userID string) (bool, error) {
+func (r *postRepository) IsPostBookmarkedByUser(ctx context.Context, postID, userID string) (bool, error) {
 	var count int64
-	err := r.db.Model(&models.PostInteractions{}).
-		Where("post_id = ? AND user_id = ? AND interaction_type = 'BOOKMARK'", postID, userID).
+	err := r.db.WithContext(ctx).Model(&models.PostInteractions{}).
+		Where("post_id = ? AND user_id = ? AND interaction_type = ?", postID, userID, models.BOOKMARK).
 		Count(&count).Error
 	return count > 0, err
 }
@@ -310,23 +526,143 @@ func (r *postRepository) IsPostBookmarkedByUser(postID, userID string) (bool, er
 // 	return count > 0, err
 // }
 
-func (r *postRepository) GetPostWithDetails(id string) (*models.Post, error) {
+func (r *postRepository) GetPostWithDetails(ctx context.Context, id string) (*models.Post, error) {
 	uid, err := uuid.Parse(id)
 	if err != nil {
 		return nil, err
 	}
 	var post models.Post
-	err = r.db.Preload("Author").
+	err = r.db.WithContext(ctx).Preload("Author").
 		Preload("Replies.Post").
 		Preload("QuotedPost.Author").
+		Preload("ReactionCounts").
+		Preload("Media").
+		Preload("LinkPreview").
+		Preload("Community").
+		Preload("Poll.Options", orderPollOptions).
 		First(&post, uid).Error
 	if err != nil {
 		return nil, err
 	}
 
-	post.LikesCount = r.getLikesCounts(uid)[uid]
-	post.RepliesCount = r.getRepliesCounts(uid)[uid]
-	post.RepostsCount = r.getRepostsCounts(uid)[uid]
-
 	return &post, nil
 }
+
+func (r *postRepository) GetDraftsByUserID(ctx context.Context, userID uuid.UUID, page pagination.Params) ([]models.Post, error) {
+	var posts []models.Post
+	err := r.db.WithContext(ctx).
+		Where("author_id = ? AND posts.status IN ?", userID, []models.PostStatus{models.PostDraft, models.PostScheduled}).
+		Preload("Author").
+		Preload("QuotedPost").
+		Preload("Media").
+		Preload("LinkPreview").
+		Preload("Community").
+		Preload("Poll.Options", orderPollOptions).
+		Scopes(page.Scope("posts", parseUUID)).
+		Find(&posts).Error
+	return posts, err
+}
+
+func (r *postRepository) PublishPost(ctx context.Context, postID uuid.UUID) (bool, error) {
+	var published []models.Post
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		var err error
+		published, err = publishPosts(tx, []uuid.UUID{postID}, time.Now())
+		return err
+	})
+	return len(published) > 0, err
+}
+
+func (r *postRepository) ClaimDuePosts(ctx context.Context, now time.Time, limit int) ([]models.Post, error) {
+	var published []models.Post
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		var due []uuid.UUID
+		if err := tx.Model(&models.Post{}).
+			Clauses(clause.Locking{Strength: "UPDATE", Options: "SKIP LOCKED"}).
+			Where("status = ? AND publish_at <= ?", models.PostScheduled, now).
+			Order("publish_at").
+			Limit(limit).
+			Pluck("id", &due).Error; err != nil || len(due) == 0 {
+			return err
+		}
+
+		var err error
+		published, err = publishPosts(tx, due, now)
+		return err
+	})
+	return published, err
+}
+
+// publishPosts flips unpublished posts to published as of now, moving them
+// to the top of the feed, and counts published quotes.
+func publishPosts(tx *gorm.DB, ids []uuid.UUID, now time.Time) ([]models.Post, error) {
+	var posts []models.Post
+	err := tx.Raw(`
+		UPDATE posts SET status = ?, created_at = ?, updated_at = ?, publish_at = NULL
+		WHERE id IN ? AND status <> ? AND deleted_at IS NULL
+		RETURNING *`, models.PostPublished, now, now, ids, models.PostPublished).
+		Scan(&posts).Error
+	if err != nil {
+		return nil, err
+	}
+
+	for _, p := range posts {
+		if p.QuotedPostID == nil {
+			continue
+		}
+		if err := incrementCounter(tx, *p.QuotedPostID, "reposts_count", 1); err != nil {
+			return nil, err
+		}
+	}
+	return posts, nil
+}
+
+// incrementCounter adds delta to one of the denormalised counters of a
+// post, never letting it go below zero.
+func incrementCounter(tx *gorm.DB, postID uuid.UUID, column string, delta int) error {
+	return tx.Model(&models.Post{}).
+		Where("id = ?", postID).
+		UpdateColumn(column, gorm.Expr("GREATEST("+column+" + ?, 0)", delta)).Error
+}
+
+// ListPostIDs returns up to limit post IDs after the given one, in ID
+// order, for batch jobs that walk the whole table.
+func (r *postRepository) ListPostIDs(ctx context.Context, after uuid.UUID, limit int) ([]uuid.UUID, error) {
+	var ids []uuid.UUID
+	err := r.db.WithContext(ctx).Model(&models.Post{}).
+		Where("id > ?", after).
+		Order("id").
+		Limit(limit).
+		Pluck("id", &ids).Error
+	return ids, err
+}
+
+// ReconcileCounters recomputes the counters of the given posts from the
+// source tables and rewrites the ones that drifted. It returns the IDs of
+// the posts it repaired.
+func (r *postRepository) ReconcileCounters(ctx context.Context, postIDs []uuid.UUID) ([]uuid.UUID, error) {
+	if len(postIDs) == 0 {
+		return nil, nil
+	}
+
+	var fixed []uuid.UUID
+	err := r.db.WithContext(ctx).Raw(`
+		UPDATE posts p
+		SET likes_count = c.likes, replies_count = c.replies, reposts_count = c.reposts
+		FROM (
+			SELECT p2.id,
+				(SELECT COUNT(*) FROM post_reactions pr
+					WHERE pr.post_id = p2.id AND pr.reaction = ?) AS likes,
+				(SELECT COUNT(*) FROM replies rp
+					WHERE rp.post_id = p2.id AND rp.deleted_at IS NULL) AS replies,
+				(SELECT COUNT(*) FROM posts q
+					WHERE q.quoted_post_id = p2.id AND q.status = ? AND q.deleted_at IS NULL) AS reposts
+			FROM posts p2
+			WHERE p2.id IN ?
+		) c
+		WHERE p.id = c.id
+			AND (p.likes_count, p.replies_count, p.reposts_count) IS DISTINCT FROM (c.likes, c.replies, c.reposts)
+		RETURNING p.id`, models.ReactionLike, models.PostPublished, postIDs).
+		Scan(&fixed).Error
+	return fixed, err
+}
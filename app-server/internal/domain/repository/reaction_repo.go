@@ -0,0 +1,229 @@
+package repository
+
+import (
+	"context"
+	"errors"
+
+	"github.com/google/uuid"
+	"github.com/maulana1k/forum-app/internal/domain/models"
+	"github.com/maulana1k/forum-app/internal/pkg/pagination"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type ReactionRepository interface {
+	// SetReaction records userID's reaction to postID, replacing any
+	// previous one, and returns the previous reaction ("" if none).
+	SetReaction(ctx context.Context, postID, userID uuid.UUID, reaction string) (string, error)
+	// RemoveReaction deletes userID's reaction to postID and returns it
+	// ("" if there was none).
+	RemoveReaction(ctx context.Context, postID, userID uuid.UUID) (string, error)
+	GetUserReaction(ctx context.Context, postID, userID uuid.UUID) (string, error)
+	GetUserReactions(ctx context.Context, userID uuid.UUID, postIDs []uuid.UUID) (map[uuid.UUID]string, error)
+	// ListReactionUsers leaves out users viewerID blocked or was blocked by,
+	// and users other than viewerID who hide their likes.
+	ListReactionUsers(ctx context.Context, postID uuid.UUID, reaction string, page pagination.Params, viewerID uuid.UUID) ([]models.PostReaction, error)
+	ReconcileCounts(ctx context.Context, postIDs []uuid.UUID) ([]uuid.UUID, error)
+}
+
+type reactionRepository struct {
+	db *gorm.DB
+}
+
+func NewReactionRepository(db *gorm.DB) ReactionRepository {
+	return &reactionRepository{db: db}
+}
+
+func (r *reactionRepository) SetReaction(ctx context.Context, postID, userID uuid.UUID, reaction string) (string, error) {
+	previous, err := r.setReaction(ctx, postID, userID, reaction)
+	if errors.Is(err, errReactionRace) {
+		// Retry once: the row now exists and will be locked and switched.
+		// Should it have been removed again in between, the second race is
+		// returned rather than retried.
+		previous, err = r.setReaction(ctx, postID, userID, reaction)
+	}
+	return previous, err
+}
+
+var errReactionRace = errors.New("concurrent reaction insert")
+
+// setReaction is a single attempt of SetReaction. It returns
+// errReactionRace if a concurrent request inserted the user's reaction
+// first.
+func (r *reactionRepository) setReaction(ctx context.Context, postID, userID uuid.UUID, reaction string) (string, error) {
+	var previous string
+
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		var existing models.PostReaction
+		err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).
+			Where("post_id = ? AND user_id = ?", postID, userID).
+			First(&existing).Error
+		switch {
+		case errors.Is(err, gorm.ErrRecordNotFound):
+			created := models.PostReaction{PostID: postID, UserID: userID, Reaction: reaction}
+			res := tx.Clauses(clause.OnConflict{DoNothing: true}).Create(&created)
+			if res.Error != nil {
+				return res.Error
+			}
+			// A concurrent request from the same user won the insert.
+			if res.RowsAffected == 0 {
+				return errReactionRace
+			}
+		case err != nil:
+			return err
+		case existing.Reaction == reaction:
+			previous = reaction
+			return nil
+		default:
+			previous = existing.Reaction
+			if err := tx.Model(&existing).Update("reaction", reaction).Error; err != nil {
+				return err
+			}
+			if err := adjustReactionCount(tx, postID, previous, -1); err != nil {
+				return err
+			}
+		}
+
+		return adjustReactionCount(tx, postID, reaction, 1)
+	})
+	return previous, err
+}
+
+func (r *reactionRepository) RemoveReaction(ctx context.Context, postID, userID uuid.UUID) (string, error) {
+	var removed string
+
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		var existing []models.PostReaction
+		if err := tx.Clauses(clause.Returning{}).
+			Where("post_id = ? AND user_id = ?", postID, userID).
+			Delete(&existing).Error; err != nil {
+			return err
+		}
+		if len(existing) == 0 {
+			return nil
+		}
+
+		removed = existing[0].Reaction
+		return adjustReactionCount(tx, postID, removed, -1)
+	})
+	return removed, err
+}
+
+func (r *reactionRepository) GetUserReaction(ctx context.Context, postID, userID uuid.UUID) (string, error) {
+	var reactions []string
+	err := r.db.WithContext(ctx).Model(&models.PostReaction{}).
+		Where("post_id = ? AND user_id = ?", postID, userID).
+		Limit(1).
+		Pluck("reaction", &reactions).Error
+	if err != nil || len(reactions) == 0 {
+		return "", err
+	}
+	return reactions[0], nil
+}
+
+func (r *reactionRepository) GetUserReactions(ctx context.Context, userID uuid.UUID, postIDs []uuid.UUID) (map[uuid.UUID]string, error) {
+	reactions := make(map[uuid.UUID]string, len(postIDs))
+	if len(postIDs) == 0 {
+		return reactions, nil
+	}
+
+	var rows []models.PostReaction
+	if err := r.db.WithContext(ctx).
+		Select("post_id", "reaction").
+		Where("user_id = ? AND post_id IN ?", userID, postIDs).
+		Find(&rows).Error; err != nil {
+		return nil, err
+	}
+
+	for _, row := range rows {
+		reactions[row.PostID] = row.Reaction
+	}
+	return reactions, nil
+}
+
+func (r *reactionRepository) ListReactionUsers(ctx context.Context, postID uuid.UUID, reaction string, page pagination.Params, viewerID uuid.UUID) ([]models.PostReaction, error) {
+	var reactions []models.PostReaction
+	db := r.db.WithContext(ctx).
+		Where("post_id = ? AND reaction = ?", postID, reaction).
+		Where("post_reactions.user_id NOT IN (?)",
+			r.db.Session(&gorm.Session{NewDB: true}).Model(&models.UserSettings{}).
+				Select("user_id").
+				Where("hide_likes AND user_id <> ?", viewerID))
+	if viewerID != uuid.Nil {
+		db = db.Where("post_reactions.user_id NOT IN ("+blockedAuthorsSQL+")",
+			viewerID, models.RelationBlock, viewerID, models.RelationBlock)
+	}
+	err := db.
+		Preload("User", func(db *gorm.DB) *gorm.DB {
+			return db.Select("id", "username", "display_name", "avatar_url", "bio")
+		}).
+		Scopes(page.Scope("post_reactions", parseReplyID)).
+		Find(&reactions).Error
+	return reactions, err
+}
+
+// ReconcileCounts rebuilds post_reaction_counts for the posts whose stored
+// counts differ from post_reactions and returns their IDs.
+func (r *reactionRepository) ReconcileCounts(ctx context.Context, postIDs []uuid.UUID) ([]uuid.UUID, error) {
+	if len(postIDs) == 0 {
+		return nil, nil
+	}
+
+	var drifted []uuid.UUID
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Raw(`
+			SELECT DISTINCT COALESCE(a.post_id, c.post_id)
+			FROM (
+				SELECT post_id, reaction, COUNT(*) AS count
+				FROM post_reactions
+				WHERE post_id IN ?
+				GROUP BY post_id, reaction
+			) a
+			FULL OUTER JOIN (
+				SELECT post_id, reaction, count
+				FROM post_reaction_counts
+				WHERE post_id IN ? AND count <> 0
+			) c ON a.post_id = c.post_id AND a.reaction = c.reaction
+			WHERE a.count IS DISTINCT FROM c.count`, postIDs, postIDs).
+			Scan(&drifted).Error; err != nil {
+			return err
+		}
+		if len(drifted) == 0 {
+			return nil
+		}
+
+		if err := tx.Where("post_id IN ?", drifted).Delete(&models.PostReactionCount{}).Error; err != nil {
+			return err
+		}
+		return tx.Exec(`
+			INSERT INTO post_reaction_counts (post_id, reaction, count)
+			SELECT post_id, reaction, COUNT(*)
+			FROM post_reactions
+			WHERE post_id IN ?
+			GROUP BY post_id, reaction`, drifted).Error
+	})
+	return drifted, err
+}
+
+// adjustReactionCount adds delta to a post's count for one reaction and
+// keeps Post.LikesCount in step for likes.
+func adjustReactionCount(tx *gorm.DB, postID uuid.UUID, reaction string, delta int) error {
+	err := tx.Clauses(clause.OnConflict{
+		Columns: []clause.Column{{Name: "post_id"}, {Name: "reaction"}},
+		DoUpdates: clause.Assignments(map[string]any{
+			"count": gorm.Expr("GREATEST(post_reaction_counts.count + ?, 0)", delta),
+		}),
+	}).Create(&models.PostReactionCount{
+		PostID:   postID,
+		Reaction: reaction,
+		Count:    max(delta, 0),
+	}).Error
+	if err != nil {
+		return err
+	}
+
+	if reaction != models.ReactionLike {
+		return nil
+	}
+	return incrementCounter(tx, postID, "likes_count", delta)
+}
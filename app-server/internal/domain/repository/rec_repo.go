@@ -2,33 +2,121 @@ package repository
 
 import (
 	"context"
+	"time"
 
 	pb "github.com/maulana1k/forum-app/gen/recommender"
+	"github.com/maulana1k/forum-app/internal/pkg/resilience"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
 )
 
+// RecommendationQuery asks the recommender for one page of posts.
+type RecommendationQuery struct {
+	UserID    string
+	Topic     string
+	Limit     int
+	PageToken string
+	// ExcludePostIDs are posts the user has already seen.
+	ExcludePostIDs []string
+	// ExcludeAuthorIDs are users whose posts must not be recommended.
+	ExcludeAuthorIDs []string
+}
+
 type RecommendationRepository interface {
-	GetRecommendedPosts(userID, topic string, limit int) ([]*pb.PostItem, error)
+	// GetRecommendedPosts asks the recommender for a page of posts. It
+	// returns resilience.ErrOpen without calling it while the recommender
+	// keeps failing.
+	GetRecommendedPosts(ctx context.Context, query RecommendationQuery) (*pb.RecommendationResponse, error)
+}
+
+// RecommenderOptions bounds how long a request waits on the recommender.
+type RecommenderOptions struct {
+	// Timeout is the deadline of one attempt.
+	Timeout time.Duration
+	// Budget is the deadline of the whole call, retries included.
+	Budget time.Duration
+	// Attempts is the total number of tries of a call.
+	Attempts int
+	// BreakerThreshold failures in a row stop calls for BreakerCooldown.
+	BreakerThreshold int
+	BreakerCooldown  time.Duration
 }
 
 type recommendationRepository struct {
-	client pb.RecommenderServiceClient
+	client  pb.RecommenderServiceClient
+	opts    RecommenderOptions
+	breaker *resilience.Breaker
 }
 
-func NewRecommendationRepository(client pb.RecommenderServiceClient) RecommendationRepository {
-	return &recommendationRepository{client: client}
+func NewRecommendationRepository(client pb.RecommenderServiceClient, opts RecommenderOptions) RecommendationRepository {
+	if opts.Timeout <= 0 {
+		opts.Timeout = 500 * time.Millisecond
+	}
+	if opts.Budget <= 0 {
+		opts.Budget = 2 * time.Second
+	}
+	if opts.Attempts <= 0 {
+		opts.Attempts = 3
+	}
+	return &recommendationRepository{
+		client: client,
+		opts:   opts,
+		breaker: resilience.NewBreaker(resilience.BreakerOptions{
+			Threshold: opts.BreakerThreshold,
+			Cooldown:  opts.BreakerCooldown,
+		}),
+	}
 }
 
-func (r *recommendationRepository) GetRecommendedPosts(userID, topic string, limit int) ([]*pb.PostItem, error) {
+func (r *recommendationRepository) GetRecommendedPosts(ctx context.Context, query RecommendationQuery) (*pb.RecommendationResponse, error) {
 	req := &pb.RecommendationRequest{
-		UserId: userID,
-		Topic:  topic,
-		Limit:  int32(limit),
+		UserId:           query.UserID,
+		Topic:            query.Topic,
+		Limit:            int32(query.Limit),
+		PageToken:        query.PageToken,
+		ExcludePostIds:   query.ExcludePostIDs,
+		ExcludeAuthorIds: query.ExcludeAuthorIDs,
 	}
 
-	resp, err := r.client.GetRecommendedPosts(context.Background(), req)
+	// The deadlines hang off the request context, so a client that goes
+	// away also cancels the call.
+	ctx, cancel := context.WithTimeout(ctx, r.opts.Budget)
+	defer cancel()
+
+	var resp *pb.RecommendationResponse
+	retry := resilience.RetryOptions{Attempts: r.opts.Attempts, BaseDelay: 50 * time.Millisecond, MaxDelay: 400 * time.Millisecond}
+	err := resilience.Retry(ctx, retry, func(ctx context.Context) error {
+		return r.breaker.Do(func() error {
+			attemptCtx, cancel := context.WithTimeout(ctx, r.opts.Timeout)
+			defer cancel()
+
+			var err error
+			resp, err = r.client.GetRecommendedPosts(attemptCtx, req)
+			return err
+		}, recommenderDown)
+	}, retryableRecommenderError)
 	if err != nil {
 		return nil, err
 	}
+	return resp, nil
+}
 
-	return resp.Posts, nil
+// recommenderDown reports whether err says the recommender is unhealthy,
+// as opposed to a bad request or a caller that gave up.
+func recommenderDown(err error) bool {
+	switch status.Code(err) {
+	case codes.Unavailable, codes.DeadlineExceeded, codes.ResourceExhausted, codes.Internal, codes.Unknown:
+		return true
+	}
+	return false
+}
+
+// retryableRecommenderError reports whether another attempt may succeed.
+// GetRecommendedPosts only reads, so repeating it is safe.
+func retryableRecommenderError(err error) bool {
+	switch status.Code(err) {
+	case codes.Unavailable, codes.DeadlineExceeded:
+		return true
+	}
+	return false
 }
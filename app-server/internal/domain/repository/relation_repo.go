@@ -0,0 +1,153 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	"github.com/maulana1k/forum-app/internal/domain/models"
+	"github.com/maulana1k/forum-app/internal/pkg/pagination"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type RelationRepository interface {
+	// AddRelation records that userID blocked or muted targetID. It
+	// returns false if the relation already existed.
+	AddRelation(ctx context.Context, userID, targetID uuid.UUID, kind models.RelationKind) (bool, error)
+	// RemoveRelation returns false if there was no such relation.
+	RemoveRelation(ctx context.Context, userID, targetID uuid.UUID, kind models.RelationKind) (bool, error)
+	ListRelations(ctx context.Context, userID uuid.UUID, kind models.RelationKind, page pagination.Params) ([]models.UserRelation, error)
+	// IsBlocked reports whether either user blocked the other.
+	IsBlocked(ctx context.Context, a, b uuid.UUID) (bool, error)
+	// HiddenAuthorIDs returns the users whose posts are kept out of
+	// userID's feeds: everyone they blocked, muted or were blocked by.
+	HiddenAuthorIDs(ctx context.Context, userID uuid.UUID) ([]uuid.UUID, error)
+	// BlockedReplyAuthors returns the reply author names of everyone
+	// userID blocked or was blocked by.
+	BlockedReplyAuthors(ctx context.Context, userID uuid.UUID) ([]string, error)
+}
+
+type relationRepository struct {
+	db *gorm.DB
+}
+
+func NewRelationRepository(db *gorm.DB) RelationRepository {
+	return &relationRepository{db: db}
+}
+
+func (r *relationRepository) AddRelation(ctx context.Context, userID, targetID uuid.UUID, kind models.RelationKind) (bool, error) {
+	res := r.db.WithContext(ctx).Clauses(clause.OnConflict{DoNothing: true}).Create(&models.UserRelation{
+		UserID:   userID,
+		TargetID: targetID,
+		Kind:     kind,
+	})
+	return res.RowsAffected > 0, res.Error
+}
+
+func (r *relationRepository) RemoveRelation(ctx context.Context, userID, targetID uuid.UUID, kind models.RelationKind) (bool, error) {
+	res := r.db.WithContext(ctx).
+		Where("user_id = ? AND target_id = ? AND kind = ?", userID, targetID, kind).
+		Delete(&models.UserRelation{})
+	return res.RowsAffected > 0, res.Error
+}
+
+func (r *relationRepository) ListRelations(ctx context.Context, userID uuid.UUID, kind models.RelationKind, page pagination.Params) ([]models.UserRelation, error) {
+	var relations []models.UserRelation
+	err := r.db.WithContext(ctx).
+		Preload("Target").
+		Where("user_id = ? AND kind = ?", userID, kind).
+		Scopes(page.Scope("user_relations", parseReplyID)).
+		Find(&relations).Error
+	return relations, err
+}
+
+func (r *relationRepository) IsBlocked(ctx context.Context, a, b uuid.UUID) (bool, error) {
+	if a == uuid.Nil || b == uuid.Nil || a == b {
+		return false, nil
+	}
+
+	var count int64
+	err := r.db.WithContext(ctx).Model(&models.UserRelation{}).
+		Where("kind = ? AND ((user_id = ? AND target_id = ?) OR (user_id = ? AND target_id = ?))",
+			models.RelationBlock, a, b, b, a).
+		Count(&count).Error
+	return count > 0, err
+}
+
+func (r *relationRepository) HiddenAuthorIDs(ctx context.Context, userID uuid.UUID) ([]uuid.UUID, error) {
+	if userID == uuid.Nil {
+		return nil, nil
+	}
+
+	var ids []uuid.UUID
+	err := r.db.WithContext(ctx).Raw(hiddenAuthorsSQL, userID, userID, models.RelationBlock).
+		Scan(&ids).Error
+	return ids, err
+}
+
+func (r *relationRepository) BlockedReplyAuthors(ctx context.Context, userID uuid.UUID) ([]string, error) {
+	if userID == uuid.Nil {
+		return nil, nil
+	}
+
+	var authors []string
+	err := r.db.WithContext(ctx).Raw(blockedReplyAuthorsSQL, userID, models.RelationBlock, userID, models.RelationBlock).
+		Scan(&authors).Error
+	return authors, err
+}
+
+// hiddenAuthorsSQL selects everyone the user blocked or muted, and everyone
+// who blocked them.
+const hiddenAuthorsSQL = `
+	SELECT target_id FROM user_relations WHERE user_id = ?
+	UNION
+	SELECT user_id FROM user_relations WHERE target_id = ? AND kind = ?`
+
+// blockedAuthorsSQL is hiddenAuthorsSQL without mutes: muted users stay
+// visible on their profile and when linked to directly.
+const blockedAuthorsSQL = `
+	SELECT target_id FROM user_relations WHERE user_id = ? AND kind = ?
+	UNION
+	SELECT user_id FROM user_relations WHERE target_id = ? AND kind = ?`
+
+// blockedReplyAuthorsSQL selects how replies name the users in
+// blockedAuthorsSQL. A reply stores its author as a user ID or, in older
+// rows, a username, so both are returned.
+const blockedReplyAuthorsSQL = `
+	SELECT unnest(ARRAY[id::text, username]) FROM users
+	WHERE id IN (` + blockedAuthorsSQL + `)`
+
+// withoutBlocked leaves out posts by users viewerID blocked or was blocked
+// by. Anonymous viewers see everything.
+func withoutBlocked(viewerID uuid.UUID) func(*gorm.DB) *gorm.DB {
+	return func(db *gorm.DB) *gorm.DB {
+		if viewerID == uuid.Nil {
+			return db
+		}
+		return db.Where("posts.author_id NOT IN ("+blockedAuthorsSQL+")",
+			viewerID, models.RelationBlock, viewerID, models.RelationBlock)
+	}
+}
+
+// withoutBlockedReplies is withoutBlocked for replies.
+func withoutBlockedReplies(viewerID uuid.UUID) func(*gorm.DB) *gorm.DB {
+	return func(db *gorm.DB) *gorm.DB {
+		if viewerID == uuid.Nil {
+			return db
+		}
+		return db.Where("replies.author NOT IN ("+blockedReplyAuthorsSQL+")",
+			viewerID, models.RelationBlock, viewerID, models.RelationBlock)
+	}
+}
+
+// withoutHidden is withoutBlocked that also leaves out muted users. Feeds
+// use it.
+func withoutHidden(viewerID uuid.UUID) func(*gorm.DB) *gorm.DB {
+	return func(db *gorm.DB) *gorm.DB {
+		if viewerID == uuid.Nil {
+			return db
+		}
+		return db.Where("posts.author_id NOT IN ("+hiddenAuthorsSQL+")",
+			viewerID, viewerID, models.RelationBlock)
+	}
+}
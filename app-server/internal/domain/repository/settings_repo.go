@@ -0,0 +1,60 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	"github.com/maulana1k/forum-app/internal/domain/models"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type SettingsRepository interface {
+	// GetSettings returns gorm.ErrRecordNotFound for users who never
+	// saved their settings.
+	GetSettings(ctx context.Context, userID uuid.UUID) (*models.UserSettings, error)
+	// CreateSettings returns false if the user already has settings.
+	CreateSettings(ctx context.Context, settings *models.UserSettings) (bool, error)
+	// UpdateSettings saves settings and bumps their version, but only if
+	// the stored version is still version. It returns false otherwise.
+	UpdateSettings(ctx context.Context, settings *models.UserSettings, version int) (bool, error)
+}
+
+type settingsRepository struct {
+	db *gorm.DB
+}
+
+func NewSettingsRepository(db *gorm.DB) SettingsRepository {
+	return &settingsRepository{db: db}
+}
+
+func (r *settingsRepository) GetSettings(ctx context.Context, userID uuid.UUID) (*models.UserSettings, error) {
+	var settings models.UserSettings
+	err := r.db.WithContext(ctx).Where("user_id = ?", userID).First(&settings).Error
+	if err != nil {
+		return nil, err
+	}
+	return &settings, nil
+}
+
+func (r *settingsRepository) CreateSettings(ctx context.Context, settings *models.UserSettings) (bool, error) {
+	res := r.db.WithContext(ctx).Clauses(clause.OnConflict{
+		Columns:   []clause.Column{{Name: "user_id"}},
+		DoNothing: true,
+	}).Create(settings)
+	return res.RowsAffected > 0, res.Error
+}
+
+func (r *settingsRepository) UpdateSettings(ctx context.Context, settings *models.UserSettings, version int) (bool, error) {
+	res := r.db.WithContext(ctx).Model(&models.UserSettings{}).
+		Where("user_id = ? AND version = ?", settings.UserID, version).
+		Updates(map[string]any{
+			"theme":                   settings.Theme,
+			"language":                settings.Language,
+			"notifications":           settings.Notifications,
+			"default_post_visibility": settings.DefaultPostVisibility,
+			"hide_likes":              settings.HideLikes,
+			"version":                 gorm.Expr("version + 1"),
+		})
+	return res.RowsAffected > 0, res.Error
+}
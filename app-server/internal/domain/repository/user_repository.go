@@ -1,16 +1,46 @@
 package repository
 
 import (
+	"context"
+	"time"
+
 	"github.com/google/uuid"
 	"github.com/maulana1k/forum-app/internal/domain/models"
 	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
 )
 
 type UserRepository interface {
-	GetAll() ([]models.User, error)
-	CreateUserProfile(profile *models.User) error
-	GetUserProfileByUserID(userID uuid.UUID) (*models.User, error)
-	UpdateUserProfile(profile *models.User) error
+	// GetAll returns the users who have not deactivated their account.
+	GetAll(ctx context.Context) ([]models.User, error)
+	CreateUserProfile(ctx context.Context, profile *models.User) error
+	GetUserProfileByUserID(ctx context.Context, userID uuid.UUID) (*models.User, error)
+	// UpdateUserProfile sets fields on the user and, if rename is not nil,
+	// renames them, in one transaction. It returns false if the new
+	// username was taken in the meantime.
+	UpdateUserProfile(ctx context.Context, userID uuid.UUID, fields map[string]any, rename *UsernameChange) (bool, error)
+	GetUserByUsername(ctx context.Context, username string) (*models.User, error)
+	// IsUsernameAvailable reports whether userID may take username: nobody
+	// else uses it and it is not reserved for another user.
+	IsUsernameAvailable(ctx context.Context, username string, userID uuid.UUID) (bool, error)
+	IsEmailInUse(ctx context.Context, email string) (bool, error)
+	// SaveEmailChange replaces any change the user already had pending.
+	SaveEmailChange(ctx context.Context, change *models.EmailChange) error
+	GetEmailChange(ctx context.Context, userID uuid.UUID) (*models.EmailChange, error)
+	GetEmailChangeByToken(ctx context.Context, tokenHash string) (*models.EmailChange, error)
+	// ApplyEmailChange moves the user to the new address, marks it
+	// verified and drops the pending change. It returns false if the
+	// address was taken in the meantime.
+	ApplyEmailChange(ctx context.Context, change *models.EmailChange) (bool, error)
+}
+
+// UsernameChange renames a user. Their old username stays reserved for
+// them until ReservedUntil.
+type UsernameChange struct {
+	OldName       string
+	NewName       string
+	ChangedAt     time.Time
+	ReservedUntil time.Time
 }
 
 type userRepository struct {
@@ -23,27 +53,139 @@ func NewUserRepository(db *gorm.DB) UserRepository {
 	}
 }
 
-func (r *userRepository) GetAll() ([]models.User, error) {
+func (r *userRepository) GetAll(ctx context.Context) ([]models.User, error) {
 	var users []models.User
-	if err := r.db.Find(&users).Error; err != nil {
+	if err := r.db.WithContext(ctx).Where("deactivated_at IS NULL").Find(&users).Error; err != nil {
 		return nil, err
 	}
 	return users, nil
 }
 
-func (r *userRepository) CreateUserProfile(profile *models.User) error {
-	return r.db.Create(profile).Error
+func (r *userRepository) CreateUserProfile(ctx context.Context, profile *models.User) error {
+	return r.db.WithContext(ctx).Create(profile).Error
 }
 
-func (r *userRepository) GetUserProfileByUserID(userID uuid.UUID) (*models.User, error) {
+func (r *userRepository) GetUserProfileByUserID(ctx context.Context, userID uuid.UUID) (*models.User, error) {
 	var profile models.User
-	err := r.db.Where("id = ?", userID).First(&profile).Error
+	err := r.db.WithContext(ctx).Where("id = ?", userID).First(&profile).Error
 	if err != nil {
 		return nil, err
 	}
 	return &profile, nil
 }
 
-func (r *userRepository) UpdateUserProfile(profile *models.User) error {
-	return r.db.Save(profile).Error
+func (r *userRepository) UpdateUserProfile(ctx context.Context, userID uuid.UUID, fields map[string]any, rename *UsernameChange) (bool, error) {
+	changed := true
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if rename != nil {
+			var err error
+			if changed, err = changeUsername(tx, userID, rename); err != nil || !changed {
+				return err
+			}
+		}
+		if len(fields) == 0 {
+			return nil
+		}
+		return tx.Model(&models.User{}).Where("id = ?", userID).Updates(fields).Error
+	})
+	return changed, err
+}
+
+func (r *userRepository) GetUserByUsername(ctx context.Context, username string) (*models.User, error) {
+	var user models.User
+	err := r.db.WithContext(ctx).Where("username = ?", username).First(&user).Error
+	if err != nil {
+		return nil, err
+	}
+	return &user, nil
+}
+
+func (r *userRepository) IsUsernameAvailable(ctx context.Context, username string, userID uuid.UUID) (bool, error) {
+	var count int64
+	err := r.db.WithContext(ctx).Model(&models.User{}).
+		Where("username = ? AND id <> ?", username, userID).
+		Count(&count).Error
+	if err != nil || count > 0 {
+		return false, err
+	}
+
+	err = r.db.WithContext(ctx).Model(&models.UsernameReservation{}).
+		Where("username = ? AND user_id <> ? AND expires_at > ?", username, userID, time.Now()).
+		Count(&count).Error
+	return count == 0, err
+}
+
+// changeUsername applies rename within tx. It returns false if the new
+// username is in use by someone else.
+func changeUsername(tx *gorm.DB, userID uuid.UUID, rename *UsernameChange) (bool, error) {
+	res := tx.Model(&models.User{}).
+		Where("id = ? AND NOT EXISTS (SELECT 1 FROM users WHERE username = ? AND id <> ?)", userID, rename.NewName, userID).
+		Updates(map[string]any{"username": rename.NewName, "username_changed_at": rename.ChangedAt})
+	if res.Error != nil || res.RowsAffected == 0 {
+		return false, res.Error
+	}
+
+	// Taking back a handle the user gave up ends its reservation.
+	if err := tx.Where("username = ?", rename.NewName).Delete(&models.UsernameReservation{}).Error; err != nil {
+		return false, err
+	}
+	err := tx.Clauses(clause.OnConflict{
+		Columns:   []clause.Column{{Name: "username"}},
+		DoUpdates: clause.AssignmentColumns([]string{"user_id", "expires_at", "created_at"}),
+	}).Create(&models.UsernameReservation{
+		Username:  rename.OldName,
+		UserID:    userID,
+		ExpiresAt: rename.ReservedUntil,
+	}).Error
+	return err == nil, err
+}
+
+func (r *userRepository) IsEmailInUse(ctx context.Context, email string) (bool, error) {
+	var count int64
+	err := r.db.WithContext(ctx).Model(&models.User{}).Where("email = ?", email).Count(&count).Error
+	return count > 0, err
+}
+
+func (r *userRepository) SaveEmailChange(ctx context.Context, change *models.EmailChange) error {
+	return r.db.WithContext(ctx).Clauses(clause.OnConflict{
+		Columns:   []clause.Column{{Name: "user_id"}},
+		DoUpdates: clause.AssignmentColumns([]string{"email", "token_hash", "expires_at", "created_at"}),
+	}).Create(change).Error
+}
+
+func (r *userRepository) GetEmailChange(ctx context.Context, userID uuid.UUID) (*models.EmailChange, error) {
+	var change models.EmailChange
+	err := r.db.WithContext(ctx).Where("user_id = ?", userID).First(&change).Error
+	if err != nil {
+		return nil, err
+	}
+	return &change, nil
+}
+
+func (r *userRepository) GetEmailChangeByToken(ctx context.Context, tokenHash string) (*models.EmailChange, error) {
+	var change models.EmailChange
+	err := r.db.WithContext(ctx).Where("token_hash = ?", tokenHash).First(&change).Error
+	if err != nil {
+		return nil, err
+	}
+	return &change, nil
+}
+
+func (r *userRepository) ApplyEmailChange(ctx context.Context, change *models.EmailChange) (bool, error) {
+	applied := false
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		res := tx.Model(&models.User{}).
+			Where("id = ? AND NOT EXISTS (SELECT 1 FROM users WHERE email = ? AND id <> ?)", change.UserID, change.Email, change.UserID).
+			Updates(map[string]any{"email": change.Email, "email_verified_at": time.Now()})
+		if res.Error != nil || res.RowsAffected == 0 {
+			return res.Error
+		}
+		if err := tx.Delete(&models.EmailChange{}, change.ID).Error; err != nil {
+			return err
+		}
+
+		applied = true
+		return nil
+	})
+	return applied, err
 }
@@ -0,0 +1,258 @@
+package service
+
+import (
+	"archive/zip"
+	"encoding/json"
+	"io"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/maulana1k/forum-app/internal/domain/models"
+	"github.com/maulana1k/forum-app/internal/domain/repository"
+)
+
+// The export archive has one JSON file per kind of data. Its shapes are
+// part of what users get, so they are kept separate from the API DTOs.
+
+type exportProfile struct {
+	ID            uuid.UUID     `json:"id"`
+	Username      string        `json:"username"`
+	DisplayName   string        `json:"displayName"`
+	Email         string        `json:"email"`
+	EmailVerified bool          `json:"emailVerified"`
+	Bio           string        `json:"bio"`
+	Location      string        `json:"location"`
+	AvatarURL     string        `json:"avatarUrl"`
+	CreatedAt     time.Time     `json:"createdAt"`
+	UpdatedAt     time.Time     `json:"updatedAt"`
+	Uploads       []exportMedia `json:"uploads"`
+}
+
+type exportMedia struct {
+	ID          uuid.UUID `json:"id"`
+	URL         string    `json:"url"`
+	ContentType string    `json:"contentType"`
+	Size        int64     `json:"size"`
+	CreatedAt   time.Time `json:"createdAt"`
+}
+
+type exportPost struct {
+	ID           uuid.UUID   `json:"id"`
+	Status       string      `json:"status"`
+	Content      string      `json:"content"`
+	Tags         string      `json:"tags,omitempty"`
+	ImageURL     string      `json:"imageUrl,omitempty"`
+	CommunityID  *uuid.UUID  `json:"communityId,omitempty"`
+	QuotedPostID *uuid.UUID  `json:"quotedPostId,omitempty"`
+	Poll         *exportPoll `json:"poll,omitempty"`
+	PublishAt    *time.Time  `json:"publishAt,omitempty"`
+	EditedAt     *time.Time  `json:"editedAt,omitempty"`
+	CreatedAt    time.Time   `json:"createdAt"`
+	UpdatedAt    time.Time   `json:"updatedAt"`
+}
+
+type exportPoll struct {
+	Options        []string  `json:"options"`
+	MultipleChoice bool      `json:"multipleChoice"`
+	ClosesAt       time.Time `json:"closesAt"`
+}
+
+type exportReply struct {
+	ID        uint      `json:"id"`
+	PostID    uuid.UUID `json:"postId"`
+	ParentID  *uint     `json:"parentId,omitempty"`
+	Content   string    `json:"content"`
+	CreatedAt time.Time `json:"createdAt"`
+	UpdatedAt time.Time `json:"updatedAt"`
+}
+
+type exportInteractions struct {
+	Reactions   []exportReaction   `json:"reactions"`
+	PollVotes   []exportPollVote   `json:"pollVotes"`
+	Bookmarks   []exportBookmark   `json:"bookmarks"`
+	Communities []exportMembership `json:"communities"`
+	Relations   []exportRelation   `json:"relations"`
+	MutedWords  []exportMutedWord  `json:"mutedWords"`
+}
+
+type exportReaction struct {
+	PostID    uuid.UUID `json:"postId"`
+	Reaction  string    `json:"reaction"`
+	CreatedAt time.Time `json:"createdAt"`
+}
+
+type exportPollVote struct {
+	PollID    uuid.UUID `json:"pollId"`
+	Option    string    `json:"option"`
+	CreatedAt time.Time `json:"createdAt"`
+}
+
+type exportBookmark struct {
+	PostID    uuid.UUID `json:"postId"`
+	CreatedAt time.Time `json:"createdAt"`
+}
+
+type exportMembership struct {
+	CommunityID uuid.UUID `json:"communityId"`
+	Slug        string    `json:"slug"`
+	Role        string    `json:"role"`
+	Status      string    `json:"status"`
+	JoinedAt    time.Time `json:"joinedAt"`
+}
+
+type exportRelation struct {
+	UserID    uuid.UUID `json:"userId"`
+	Username  string    `json:"username"`
+	Kind      string    `json:"kind"`
+	CreatedAt time.Time `json:"createdAt"`
+}
+
+type exportMutedWord struct {
+	Phrase    string     `json:"phrase"`
+	ExpiresAt *time.Time `json:"expiresAt,omitempty"`
+	CreatedAt time.Time  `json:"createdAt"`
+}
+
+// writeExportArchive writes data to w as a ZIP of JSON files.
+func writeExportArchive(w io.Writer, data *repository.AccountData) error {
+	settings := data.Settings
+	if settings == nil {
+		settings = models.DefaultUserSettings(data.User.ID)
+	}
+
+	files := []struct {
+		name string
+		body any
+	}{
+		{"profile.json", exportProfileOf(data)},
+		{"posts.json", exportPostsOf(data.Posts)},
+		{"replies.json", exportRepliesOf(data.Replies)},
+		{"interactions.json", exportInteractionsOf(data)},
+		{"settings.json", mapSettings(settings)},
+	}
+
+	zw := zip.NewWriter(w)
+	for _, f := range files {
+		fw, err := zw.Create(f.name)
+		if err != nil {
+			return err
+		}
+		enc := json.NewEncoder(fw)
+		enc.SetIndent("", "  ")
+		if err := enc.Encode(f.body); err != nil {
+			return err
+		}
+	}
+	return zw.Close()
+}
+
+func exportProfileOf(data *repository.AccountData) exportProfile {
+	u := data.User
+	profile := exportProfile{
+		ID:            u.ID,
+		Username:      u.Username,
+		DisplayName:   u.DisplayName,
+		Email:         u.Email,
+		EmailVerified: u.EmailVerifiedAt != nil,
+		Bio:           u.Bio,
+		Location:      u.Location,
+		AvatarURL:     u.AvatarURL,
+		CreatedAt:     u.CreatedAt,
+		UpdatedAt:     u.UpdatedAt,
+		Uploads:       make([]exportMedia, 0, len(data.Media)),
+	}
+	for _, m := range data.Media {
+		profile.Uploads = append(profile.Uploads, exportMedia{
+			ID:          m.ID,
+			URL:         m.URL,
+			ContentType: m.ContentType,
+			Size:        m.Size,
+			CreatedAt:   m.CreatedAt,
+		})
+	}
+	return profile
+}
+
+func exportPostsOf(posts []models.Post) []exportPost {
+	out := make([]exportPost, 0, len(posts))
+	for _, p := range posts {
+		post := exportPost{
+			ID:           p.ID,
+			Status:       string(p.Status),
+			Content:      p.Content,
+			Tags:         p.Tags,
+			ImageURL:     p.ImageURL,
+			CommunityID:  p.CommunityID,
+			QuotedPostID: p.QuotedPostID,
+			PublishAt:    p.PublishAt,
+			EditedAt:     p.EditedAt,
+			CreatedAt:    p.CreatedAt,
+			UpdatedAt:    p.UpdatedAt,
+		}
+		if p.Poll != nil {
+			poll := &exportPoll{MultipleChoice: p.Poll.MultipleChoice, ClosesAt: p.Poll.ClosesAt}
+			for _, o := range p.Poll.Options {
+				poll.Options = append(poll.Options, o.Text)
+			}
+			post.Poll = poll
+		}
+		out = append(out, post)
+	}
+	return out
+}
+
+func exportRepliesOf(replies []models.Replies) []exportReply {
+	out := make([]exportReply, 0, len(replies))
+	for _, r := range replies {
+		out = append(out, exportReply{
+			ID:        r.ID,
+			PostID:    r.PostID,
+			ParentID:  r.ParentID,
+			Content:   r.Content,
+			CreatedAt: r.CreatedAt,
+			UpdatedAt: r.UpdatedAt,
+		})
+	}
+	return out
+}
+
+func exportInteractionsOf(data *repository.AccountData) exportInteractions {
+	out := exportInteractions{
+		Reactions:   make([]exportReaction, 0, len(data.Reactions)),
+		PollVotes:   make([]exportPollVote, 0, len(data.PollVotes)),
+		Bookmarks:   make([]exportBookmark, 0, len(data.Bookmarks)),
+		Communities: make([]exportMembership, 0, len(data.Memberships)),
+		Relations:   make([]exportRelation, 0, len(data.Relations)),
+		MutedWords:  make([]exportMutedWord, 0, len(data.MutedWords)),
+	}
+	for _, r := range data.Reactions {
+		out.Reactions = append(out.Reactions, exportReaction{PostID: r.PostID, Reaction: r.Reaction, CreatedAt: r.CreatedAt})
+	}
+	for _, v := range data.PollVotes {
+		out.PollVotes = append(out.PollVotes, exportPollVote{PollID: v.PollID, Option: v.Option.Text, CreatedAt: v.CreatedAt})
+	}
+	for _, b := range data.Bookmarks {
+		out.Bookmarks = append(out.Bookmarks, exportBookmark{PostID: b.PostID, CreatedAt: b.CreatedAt})
+	}
+	for _, m := range data.Memberships {
+		out.Communities = append(out.Communities, exportMembership{
+			CommunityID: m.CommunityID,
+			Slug:        m.Community.Slug,
+			Role:        string(m.Role),
+			Status:      string(m.Status),
+			JoinedAt:    m.CreatedAt,
+		})
+	}
+	for _, r := range data.Relations {
+		out.Relations = append(out.Relations, exportRelation{
+			UserID:    r.TargetID,
+			Username:  r.Target.Username,
+			Kind:      string(r.Kind),
+			CreatedAt: r.CreatedAt,
+		})
+	}
+	for _, w := range data.MutedWords {
+		out.MutedWords = append(out.MutedWords, exportMutedWord{Phrase: w.Phrase, ExpiresAt: w.ExpiresAt, CreatedAt: w.CreatedAt})
+	}
+	return out
+}
@@ -0,0 +1,353 @@
+package service
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/maulana1k/forum-app/internal/app/dto"
+	"github.com/maulana1k/forum-app/internal/domain/errs"
+	"github.com/maulana1k/forum-app/internal/domain/events"
+	"github.com/maulana1k/forum-app/internal/domain/models"
+	"github.com/maulana1k/forum-app/internal/domain/repository"
+	"github.com/maulana1k/forum-app/internal/pkg/utils"
+	"github.com/maulana1k/forum-app/internal/provider/broker"
+	"github.com/maulana1k/forum-app/internal/provider/storage"
+	"golang.org/x/crypto/bcrypt"
+	"gorm.io/gorm"
+)
+
+// DataExportQueue carries the IDs of data exports to build.
+const DataExportQueue = "user-data-export"
+
+const purgeBatch = 50
+
+// DataExportRequest is the DataExportQueue message body.
+type DataExportRequest struct {
+	ExportID string `json:"export_id"`
+}
+
+type AccountService interface {
+	// Deactivate hides the user and their posts until they sign in again.
+	Deactivate(ctx context.Context, userID string) error
+	// CheckActive fails with errs.ErrAccountDeactivated if the user
+	// deactivated their account, and with errs.ErrInvalidToken if it no
+	// longer exists.
+	CheckActive(ctx context.Context, userID string) error
+	// ScheduleDeletion deactivates the user and deletes the account for
+	// good once AccountOptions.DeletionGrace has passed, unless they sign
+	// in again before then.
+	ScheduleDeletion(ctx context.Context, userID string, req *dto.DeleteAccountRequest) (*dto.AccountDeletionResponse, error)
+	// RequestExport queues a ZIP archive of the user's data to be built.
+	RequestExport(ctx context.Context, userID string) (*dto.DataExportResponse, error)
+	// GetExport returns one of the user's exports, with a download link
+	// once it is ready.
+	GetExport(ctx context.Context, userID, exportID string) (*dto.DataExportResponse, error)
+	// BuildExport builds and stores a requested archive.
+	BuildExport(ctx context.Context, exportID string) error
+	// PurgeDueAccounts deletes the accounts whose grace period is over and
+	// removes expired export archives. It returns the number of accounts
+	// deleted.
+	PurgeDueAccounts(ctx context.Context) (int, error)
+}
+
+// AccountOptions tunes account closing and data exports.
+type AccountOptions struct {
+	// DeletionGrace is how long a user can still cancel a deletion by
+	// signing in.
+	DeletionGrace time.Duration
+	// DeletionPolicy decides what happens to a deleted user's content.
+	DeletionPolicy models.DeletionPolicy
+	// ExportRetention is how long a built archive is kept.
+	ExportRetention time.Duration
+	// ExportLinkTTL is how long a download link is valid.
+	ExportLinkTTL time.Duration
+}
+
+type accountService struct {
+	accountRepo repository.AccountRepository
+	userRepo    repository.UserRepository
+	store       storage.BlobStore
+	broker      *broker.RabbitMQ
+	events      *events.Bus
+	opts        AccountOptions
+}
+
+func NewAccountService(accountRepo repository.AccountRepository, userRepo repository.UserRepository, store storage.BlobStore, brokerc *broker.RabbitMQ, bus *events.Bus, opts AccountOptions) AccountService {
+	if opts.DeletionGrace <= 0 {
+		opts.DeletionGrace = 30 * 24 * time.Hour
+	}
+	if opts.DeletionPolicy != models.DeletionCascade {
+		opts.DeletionPolicy = models.DeletionAnonymize
+	}
+	if opts.ExportRetention <= 0 {
+		opts.ExportRetention = 7 * 24 * time.Hour
+	}
+	if opts.ExportLinkTTL <= 0 {
+		opts.ExportLinkTTL = 15 * time.Minute
+	}
+	return &accountService{
+		accountRepo: accountRepo,
+		userRepo:    userRepo,
+		store:       store,
+		broker:      brokerc,
+		events:      bus,
+		opts:        opts,
+	}
+}
+
+func (s *accountService) Deactivate(ctx context.Context, userID string) error {
+	uid, err := uuid.Parse(userID)
+	if err != nil {
+		return errs.ErrInvalidToken.Wrap(err)
+	}
+
+	if err := s.accountRepo.Deactivate(ctx, uid, nil); err != nil {
+		return err
+	}
+	s.events.Publish(ctx, events.UserProfileUpdated{UserID: userID})
+	return nil
+}
+
+func (s *accountService) CheckActive(ctx context.Context, userID string) error {
+	uid, err := uuid.Parse(userID)
+	if err != nil {
+		return errs.ErrInvalidToken.Wrap(err)
+	}
+
+	user, err := s.userRepo.GetUserProfileByUserID(ctx, uid)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return errs.ErrInvalidToken.Wrap(err)
+		}
+		return err
+	}
+	if !user.IsActive() {
+		return errs.ErrAccountDeactivated
+	}
+	return nil
+}
+
+func (s *accountService) ScheduleDeletion(ctx context.Context, userID string, req *dto.DeleteAccountRequest) (*dto.AccountDeletionResponse, error) {
+	uid, err := uuid.Parse(userID)
+	if err != nil {
+		return nil, errs.ErrInvalidToken.Wrap(err)
+	}
+
+	user, err := s.userRepo.GetUserProfileByUserID(ctx, uid)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, errs.ErrUserNotFound
+		}
+		return nil, err
+	}
+	if err := bcrypt.CompareHashAndPassword([]byte(user.Password), []byte(req.Password)); err != nil {
+		return nil, errs.ErrIncorrectPassword
+	}
+
+	deleteAfter := time.Now().Add(s.opts.DeletionGrace)
+	if err := s.accountRepo.Deactivate(ctx, uid, &deleteAfter); err != nil {
+		return nil, err
+	}
+	s.events.Publish(ctx, events.UserProfileUpdated{UserID: userID})
+
+	return &dto.AccountDeletionResponse{DeleteAfter: deleteAfter}, nil
+}
+
+func (s *accountService) RequestExport(ctx context.Context, userID string) (*dto.DataExportResponse, error) {
+	uid, err := uuid.Parse(userID)
+	if err != nil {
+		return nil, errs.ErrInvalidToken.Wrap(err)
+	}
+
+	_, err = s.accountRepo.GetPendingExport(ctx, uid)
+	if err == nil {
+		return nil, errs.ErrExportInProgress
+	}
+	if !errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, err
+	}
+
+	// Pending exports expire too, so one lost by the worker does not
+	// block the user forever.
+	expiresAt := time.Now().Add(s.opts.ExportRetention)
+	export := &models.DataExport{UserID: uid, Status: models.ExportPending, ExpiresAt: &expiresAt}
+	if err := s.accountRepo.CreateExport(ctx, export); err != nil {
+		return nil, err
+	}
+
+	body, _ := json.Marshal(DataExportRequest{ExportID: export.ID.String()})
+	producer := broker.NewProducer(s.broker, DataExportQueue)
+	if err := producer.Publish(ctx, body); err != nil {
+		utils.LoggerFromContext(ctx).WithError(err).WithField("export_id", export.ID).Error("failed to publish data export message")
+		s.failExport(ctx, export, err)
+		return nil, err
+	}
+
+	return s.mapExport(ctx, export)
+}
+
+func (s *accountService) GetExport(ctx context.Context, userID, exportID string) (*dto.DataExportResponse, error) {
+	uid, err := uuid.Parse(userID)
+	if err != nil {
+		return nil, errs.ErrInvalidToken.Wrap(err)
+	}
+	id, err := uuid.Parse(exportID)
+	if err != nil {
+		return nil, errs.ErrInvalidID.Wrap(err)
+	}
+
+	export, err := s.accountRepo.GetExport(ctx, id)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, errs.ErrExportNotFound
+		}
+		return nil, err
+	}
+	if export.UserID != uid {
+		return nil, errs.ErrExportNotFound
+	}
+
+	return s.mapExport(ctx, export)
+}
+
+func (s *accountService) BuildExport(ctx context.Context, exportID string) error {
+	id, err := uuid.Parse(exportID)
+	if err != nil {
+		return errs.ErrInvalidID.Wrap(err)
+	}
+
+	export, err := s.accountRepo.GetExport(ctx, id)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			// Expired or the account is gone; nothing to build.
+			return nil
+		}
+		return err
+	}
+	if export.Status != models.ExportPending {
+		return nil
+	}
+
+	if err := s.buildExport(ctx, export); err != nil {
+		s.failExport(ctx, export, err)
+		return err
+	}
+	return nil
+}
+
+func (s *accountService) buildExport(ctx context.Context, export *models.DataExport) error {
+	data, err := s.accountRepo.LoadAccountData(ctx, export.UserID)
+	if err != nil {
+		return err
+	}
+
+	var buf bytes.Buffer
+	if err := writeExportArchive(&buf, data); err != nil {
+		return err
+	}
+
+	key := storage.PrivatePrefix + "exports/" + export.UserID.String() + "/" + export.ID.String() + ".zip"
+	size := int64(buf.Len())
+	if err := s.store.Put(ctx, key, &buf, size, "application/zip"); err != nil {
+		return err
+	}
+
+	now := time.Now()
+	expiresAt := now.Add(s.opts.ExportRetention)
+	export.Status = models.ExportReady
+	export.Key = key
+	export.Size = size
+	export.CompletedAt = &now
+	export.ExpiresAt = &expiresAt
+	return s.accountRepo.UpdateExport(ctx, export)
+}
+
+// failExport records why export could not be built. The error itself is
+// only logged; users see a generic failure.
+func (s *accountService) failExport(ctx context.Context, export *models.DataExport, cause error) {
+	now := time.Now()
+	export.Status = models.ExportFailed
+	export.Error = cause.Error()
+	export.CompletedAt = &now
+	if err := s.accountRepo.UpdateExport(ctx, export); err != nil {
+		utils.LoggerFromContext(ctx).WithError(err).WithField("export_id", export.ID).Error("failed to mark data export failed")
+	}
+}
+
+func (s *accountService) PurgeDueAccounts(ctx context.Context) (int, error) {
+	logger := utils.LoggerFromContext(ctx)
+	now := time.Now()
+
+	if err := s.removeExpiredExports(ctx, now); err != nil {
+		logger.WithError(err).Error("failed to remove expired data exports")
+	}
+
+	ids, err := s.accountRepo.ListDueDeletions(ctx, now, purgeBatch)
+	if err != nil {
+		return 0, err
+	}
+
+	purged := 0
+	for _, id := range ids {
+		ok, keys, err := s.accountRepo.PurgeUser(ctx, id, now, s.opts.DeletionPolicy)
+		if err != nil {
+			return purged, err
+		}
+		if !ok {
+			continue
+		}
+		purged++
+		s.events.Publish(ctx, events.UserProfileUpdated{UserID: id.String()})
+
+		// The rows are gone; a blob left behind here is only wasted space.
+		for _, key := range keys {
+			if err := s.store.Delete(ctx, key); err != nil {
+				logger.WithError(err).WithField("key", key).Warn("failed to delete blob of purged account")
+			}
+		}
+	}
+	return purged, nil
+}
+
+func (s *accountService) removeExpiredExports(ctx context.Context, now time.Time) error {
+	exports, err := s.accountRepo.ListExpiredExports(ctx, now, purgeBatch)
+	if err != nil {
+		return err
+	}
+	for _, export := range exports {
+		if export.Key != "" {
+			if err := s.store.Delete(ctx, export.Key); err != nil {
+				return err
+			}
+		}
+		if err := s.accountRepo.DeleteExport(ctx, export.ID); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (s *accountService) mapExport(ctx context.Context, export *models.DataExport) (*dto.DataExportResponse, error) {
+	resp := &dto.DataExportResponse{
+		ID:          export.ID,
+		Status:      string(export.Status),
+		CreatedAt:   export.CreatedAt,
+		CompletedAt: export.CompletedAt,
+	}
+	if !export.IsReady(time.Now()) {
+		return resp, nil
+	}
+
+	url, err := s.store.PresignGet(ctx, export.Key, "export-"+export.CreatedAt.Format("2006-01-02")+".zip", s.opts.ExportLinkTTL)
+	if err != nil {
+		return nil, err
+	}
+	resp.Size = export.Size
+	resp.DownloadURL = url
+	resp.ExpiresAt = export.ExpiresAt
+	return resp, nil
+}
@@ -1,32 +1,37 @@
 package service
 
 import (
-	"errors"
+	"context"
 	"strings"
 
 	"github.com/google/uuid"
+	"github.com/maulana1k/forum-app/internal/domain/errs"
+	"github.com/maulana1k/forum-app/internal/domain/events"
 	"github.com/maulana1k/forum-app/internal/domain/models"
 	"github.com/maulana1k/forum-app/internal/domain/repository"
 	"github.com/maulana1k/forum-app/internal/pkg/utils"
+	"github.com/maulana1k/forum-app/internal/provider/monitoring"
 	"golang.org/x/crypto/bcrypt"
 )
 
 type AuthService interface {
-	SignUp(username, email, password string) (models.User, string, error)
-	SignIn(email, password string) (string, error)
+	SignUp(ctx context.Context, username, email, password string) (models.User, string, error)
+	SignIn(ctx context.Context, email, password string) (string, error)
 }
 
 type authService struct {
 	authRepo repository.AuthRepository
+	events   *events.Bus
 }
 
-func NewAuthService(authRepo repository.AuthRepository) AuthService {
+func NewAuthService(authRepo repository.AuthRepository, bus *events.Bus) AuthService {
 	return &authService{
 		authRepo: authRepo,
+		events:   bus,
 	}
 }
 
-func (s *authService) SignUp(username, email, password string) (models.User, string, error) {
+func (s *authService) SignUp(ctx context.Context, username, email, password string) (models.User, string, error) {
 	// Trim inputs
 	username = strings.TrimSpace(username)
 	email = strings.TrimSpace(email)
@@ -34,15 +39,15 @@ func (s *authService) SignUp(username, email, password string) (models.User, str
 
 	// Validate required fields
 	if username == "" || email == "" || password == "" {
-		return models.User{}, "", errors.New("username, email, and password cannot be empty")
+		return models.User{}, "", errs.ErrMissingCredentials
 	}
 
 	// Check if email or username already exists
-	if exists, _ := s.authRepo.IsEmailExists(email); exists {
-		return models.User{}, "", errors.New("email already in use")
+	if exists, _ := s.authRepo.IsEmailExists(ctx, email); exists {
+		return models.User{}, "", errs.ErrEmailTaken
 	}
-	if exists, _ := s.authRepo.IsUsernameExists(username); exists {
-		return models.User{}, "", errors.New("username already in use")
+	if exists, _ := s.authRepo.IsUsernameExists(ctx, username); exists {
+		return models.User{}, "", errs.ErrUsernameTaken
 	}
 
 	// Hash password
@@ -64,9 +69,10 @@ func (s *authService) SignUp(username, email, password string) (models.User, str
 		Location:    "",
 	}
 
-	if err := s.authRepo.CreateUser(&user); err != nil {
+	if err := s.authRepo.CreateUser(ctx, &user); err != nil {
 		return models.User{}, "", err
 	}
+	monitoring.UserSignups.Inc()
 
 	token, err := utils.GenerateJWT(user.ID.String())
 	if err != nil {
@@ -76,16 +82,25 @@ func (s *authService) SignUp(username, email, password string) (models.User, str
 	return user, token, nil
 }
 
-func (s *authService) SignIn(email, password string) (string, error) {
+func (s *authService) SignIn(ctx context.Context, email, password string) (string, error) {
 	// Get user by email
-	user, err := s.authRepo.GetUserByEmail(email)
+	user, err := s.authRepo.GetUserByEmail(ctx, email)
 	if err != nil {
-		return "", errors.New("invalid credentials")
+		return "", errs.ErrInvalidCredentials
 	}
 
 	// Compare password
 	if err := bcrypt.CompareHashAndPassword([]byte(user.Password), []byte(password)); err != nil {
-		return "", errors.New("invalid credentials")
+		return "", errs.ErrInvalidCredentials
+	}
+
+	// Signing in brings a deactivated account back, even one scheduled
+	// for deletion.
+	if !user.IsActive() {
+		if err := s.authRepo.ReactivateUser(ctx, user); err != nil {
+			return "", err
+		}
+		s.events.Publish(ctx, events.UserProfileUpdated{UserID: user.ID.String()})
 	}
 
 	// Generate JWT token
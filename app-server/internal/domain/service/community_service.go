@@ -0,0 +1,442 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"strconv"
+
+	"github.com/google/uuid"
+	"github.com/maulana1k/forum-app/internal/app/dto"
+	"github.com/maulana1k/forum-app/internal/domain/errs"
+	"github.com/maulana1k/forum-app/internal/domain/models"
+	"github.com/maulana1k/forum-app/internal/domain/repository"
+	"github.com/maulana1k/forum-app/internal/pkg/pagination"
+	"gorm.io/gorm"
+)
+
+type CommunityService interface {
+	CreateCommunity(ctx context.Context, userID string, req *dto.CreateCommunityRequest) (*dto.CommunityResponse, error)
+	GetCommunity(ctx context.Context, slug, viewerID string) (*dto.CommunityResponse, error)
+	ListCommunities(ctx context.Context, query *dto.PostQueryParams, viewerID string) (*dto.PaginatedCommunitiesResponse, error)
+	UpdateCommunity(ctx context.Context, slug, userID string, req *dto.UpdateCommunityRequest) (*dto.CommunityResponse, error)
+	// JoinCommunity makes the user a member of a public community, or
+	// asks to join a restricted or private one.
+	JoinCommunity(ctx context.Context, slug, userID string) (*dto.MembershipResponse, error)
+	// LeaveCommunity ends a membership or withdraws a join request.
+	LeaveCommunity(ctx context.Context, slug, userID string) error
+	ListCommunityMembers(ctx context.Context, slug, viewerID string, query *dto.CommunityMembersQuery) (*dto.PaginatedCommunityMembersResponse, error)
+	// UpdateCommunityMember approves, bans or changes the role of a member.
+	UpdateCommunityMember(ctx context.Context, slug, actorID, memberID string, req *dto.UpdateMemberRequest) (*dto.CommunityMemberResponse, error)
+}
+
+type communityService struct {
+	communityRepo repository.CommunityRepository
+}
+
+func NewCommunityService(communityRepo repository.CommunityRepository) CommunityService {
+	return &communityService{communityRepo: communityRepo}
+}
+
+func (s *communityService) CreateCommunity(ctx context.Context, userID string, req *dto.CreateCommunityRequest) (*dto.CommunityResponse, error) {
+	uid, err := uuid.Parse(userID)
+	if err != nil {
+		return nil, errs.ErrInvalidToken.Wrap(err)
+	}
+
+	visibility := models.CommunityPublic
+	if req.Visibility != "" {
+		visibility = models.CommunityVisibility(req.Visibility)
+	}
+
+	community := &models.Community{
+		Slug:        req.Slug,
+		Name:        req.Name,
+		Description: req.Description,
+		Rules:       req.Rules,
+		Visibility:  visibility,
+		OwnerID:     uid,
+	}
+	if err := s.communityRepo.CreateCommunity(ctx, community); err != nil {
+		return nil, err
+	}
+
+	return mapCommunity(community, &models.CommunityMember{
+		Role:   models.CommunityRoleOwner,
+		Status: models.MembershipActive,
+	}), nil
+}
+
+func (s *communityService) GetCommunity(ctx context.Context, slug, viewerID string) (*dto.CommunityResponse, error) {
+	uid, _ := uuid.Parse(viewerID)
+	community, member, err := s.lookup(ctx, slug, uid)
+	if err != nil {
+		return nil, err
+	}
+	// Private communities can still be found so people can ask to join;
+	// their posts and members stay hidden.
+	return mapCommunity(community, member), nil
+}
+
+func (s *communityService) ListCommunities(ctx context.Context, query *dto.PostQueryParams, viewerID string) (*dto.PaginatedCommunitiesResponse, error) {
+	page, err := pagination.NewParams(query.Limit, query.Page, query.Cursor)
+	if err != nil {
+		return nil, err
+	}
+
+	uid, _ := uuid.Parse(viewerID)
+	communities, err := s.communityRepo.ListCommunities(ctx, uid, page)
+	if err != nil {
+		return nil, err
+	}
+
+	if page.IsOffset() {
+		total, err := s.communityRepo.CountCommunities(ctx, uid)
+		if err != nil {
+			return nil, err
+		}
+		totalPages := (int(total) + page.Limit - 1) / page.Limit
+		return &dto.PaginatedCommunitiesResponse{
+			Communities: mapCommunities(communities),
+			Total:       int(total),
+			Page:        page.Page,
+			Limit:       page.Limit,
+			TotalPages:  totalPages,
+			HasNextPage: page.Page < totalPages,
+			HasPrevPage: page.Page > 1,
+		}, nil
+	}
+
+	window := pagination.Window(communities, page, func(c models.Community) pagination.Cursor {
+		return pagination.Cursor{CreatedAt: c.CreatedAt, ID: c.ID.String()}
+	})
+	return &dto.PaginatedCommunitiesResponse{
+		Communities: mapCommunities(window.Items),
+		Limit:       page.Limit,
+		HasNextPage: window.HasNext,
+		HasPrevPage: window.HasPrev,
+		NextCursor:  window.NextCursor,
+		PrevCursor:  window.PrevCursor,
+	}, nil
+}
+
+func (s *communityService) UpdateCommunity(ctx context.Context, slug, userID string, req *dto.UpdateCommunityRequest) (*dto.CommunityResponse, error) {
+	uid, err := uuid.Parse(userID)
+	if err != nil {
+		return nil, errs.ErrInvalidToken.Wrap(err)
+	}
+
+	community, member, err := s.lookup(ctx, slug, uid)
+	if err != nil {
+		return nil, err
+	}
+	if !member.CanModerate() {
+		return nil, errs.ErrCommunityUpdateDenied
+	}
+
+	fields := make(map[string]any)
+	if req.Name != nil {
+		fields["name"] = *req.Name
+	}
+	if req.Description != nil {
+		fields["description"] = *req.Description
+	}
+	if req.Rules != nil {
+		fields["rules"] = *req.Rules
+	}
+	if req.Visibility != nil {
+		// Visibility decides who can read the community, so only the
+		// owner may change it.
+		if member.Role != models.CommunityRoleOwner {
+			return nil, errs.ErrCommunityUpdateDenied
+		}
+		fields["visibility"] = *req.Visibility
+	}
+
+	if len(fields) > 0 {
+		if err := s.communityRepo.UpdateCommunity(ctx, community.ID, fields); err != nil {
+			return nil, err
+		}
+	}
+
+	return s.GetCommunity(ctx, slug, userID)
+}
+
+func (s *communityService) JoinCommunity(ctx context.Context, slug, userID string) (*dto.MembershipResponse, error) {
+	uid, err := uuid.Parse(userID)
+	if err != nil {
+		return nil, errs.ErrInvalidToken.Wrap(err)
+	}
+
+	community, existing, err := s.lookup(ctx, slug, uid)
+	if err != nil {
+		return nil, err
+	}
+	if existing.IsBanned() {
+		return nil, errs.ErrCommunityBanned
+	}
+	if existing != nil {
+		return nil, errs.ErrAlreadyMember
+	}
+
+	member := &models.CommunityMember{
+		CommunityID: community.ID,
+		UserID:      uid,
+		Role:        models.CommunityRoleMember,
+		Status:      models.MembershipActive,
+	}
+	if community.Visibility != models.CommunityPublic {
+		member.Status = models.MembershipPending
+	}
+	if err := s.communityRepo.AddMember(ctx, member); err != nil {
+		return nil, err
+	}
+
+	return mapMembership(member), nil
+}
+
+func (s *communityService) LeaveCommunity(ctx context.Context, slug, userID string) error {
+	uid, err := uuid.Parse(userID)
+	if err != nil {
+		return errs.ErrInvalidToken.Wrap(err)
+	}
+
+	community, member, err := s.lookup(ctx, slug, uid)
+	if err != nil {
+		return err
+	}
+	if member != nil && member.Role == models.CommunityRoleOwner {
+		return errs.ErrCommunityOwnerRole
+	}
+
+	return s.communityRepo.RemoveMember(ctx, community.ID, uid)
+}
+
+func (s *communityService) ListCommunityMembers(ctx context.Context, slug, viewerID string, query *dto.CommunityMembersQuery) (*dto.PaginatedCommunityMembersResponse, error) {
+	page, err := pagination.NewParams(query.Limit, 0, query.Cursor)
+	if err != nil {
+		return nil, err
+	}
+
+	uid, _ := uuid.Parse(viewerID)
+	community, viewer, err := s.lookup(ctx, slug, uid)
+	if err != nil {
+		return nil, err
+	}
+	if !canViewCommunity(community, viewer) {
+		return nil, errs.ErrCommunityPrivate
+	}
+
+	status := models.MembershipActive
+	if query.Status != "" {
+		status = models.MembershipStatus(query.Status)
+	}
+	// Join requests and bans are moderation business.
+	if status != models.MembershipActive && !viewer.CanModerate() {
+		return nil, errs.ErrCommunityUpdateDenied
+	}
+
+	members, err := s.communityRepo.ListMembers(ctx, community.ID, status, page)
+	if err != nil {
+		return nil, err
+	}
+
+	window := pagination.Window(members, page, func(m models.CommunityMember) pagination.Cursor {
+		return pagination.Cursor{CreatedAt: m.CreatedAt, ID: strconv.FormatUint(uint64(m.ID), 10)}
+	})
+	responses := make([]dto.CommunityMemberResponse, len(window.Items))
+	for i := range window.Items {
+		responses[i] = *mapCommunityMember(&window.Items[i])
+	}
+	return &dto.PaginatedCommunityMembersResponse{
+		Members:     responses,
+		Limit:       page.Limit,
+		HasNextPage: window.HasNext,
+		HasPrevPage: window.HasPrev,
+		NextCursor:  window.NextCursor,
+		PrevCursor:  window.PrevCursor,
+	}, nil
+}
+
+func (s *communityService) UpdateCommunityMember(ctx context.Context, slug, actorID, memberID string, req *dto.UpdateMemberRequest) (*dto.CommunityMemberResponse, error) {
+	aid, err := uuid.Parse(actorID)
+	if err != nil {
+		return nil, errs.ErrInvalidToken.Wrap(err)
+	}
+	mid, err := uuid.Parse(memberID)
+	if err != nil {
+		return nil, errs.ErrInvalidID.Wrap(err)
+	}
+
+	community, actor, err := s.lookup(ctx, slug, aid)
+	if err != nil {
+		return nil, err
+	}
+	if !actor.CanModerate() {
+		return nil, errs.ErrCommunityUpdateDenied
+	}
+
+	member, err := s.communityRepo.GetMember(ctx, community.ID, mid)
+	if err != nil && !errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, err
+	}
+
+	if member == nil {
+		// Moderators may ban people before they ever join.
+		if req.Status == nil || *req.Status != string(models.MembershipBanned) || req.Role != nil {
+			return nil, errs.ErrMemberNotFound
+		}
+		member = &models.CommunityMember{
+			CommunityID: community.ID,
+			UserID:      mid,
+			Role:        models.CommunityRoleMember,
+			Status:      models.MembershipBanned,
+		}
+		if err := s.communityRepo.AddMember(ctx, member); err != nil {
+			return nil, err
+		}
+		return s.getCommunityMember(ctx, community.ID, mid)
+	}
+
+	if member.Role == models.CommunityRoleOwner {
+		return nil, errs.ErrCommunityOwnerRole
+	}
+	// Moderators manage members; only the owner manages moderators.
+	isOwner := actor.Role == models.CommunityRoleOwner
+	if (req.Role != nil || member.Role == models.CommunityRoleModerator) && !isOwner {
+		return nil, errs.ErrCommunityUpdateDenied
+	}
+
+	if req.Role != nil {
+		member.Role = models.CommunityRole(*req.Role)
+	}
+	if req.Status != nil {
+		member.Status = models.MembershipStatus(*req.Status)
+	}
+	if member.IsBanned() {
+		// A banned moderator loses the role with the membership.
+		member.Role = models.CommunityRoleMember
+	}
+
+	if err := s.communityRepo.UpdateMember(ctx, member); err != nil {
+		return nil, err
+	}
+	return s.getCommunityMember(ctx, community.ID, mid)
+}
+
+// lookup finds a community by slug together with userID's membership,
+// which is nil if the user has none.
+func (s *communityService) lookup(ctx context.Context, slug string, userID uuid.UUID) (*models.Community, *models.CommunityMember, error) {
+	community, err := s.communityRepo.GetCommunityBySlug(ctx, slug)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil, errs.ErrCommunityNotFound
+		}
+		return nil, nil, err
+	}
+
+	member, err := findMember(ctx, s.communityRepo, community.ID, userID)
+	if err != nil {
+		return nil, nil, err
+	}
+	return community, member, nil
+}
+
+func (s *communityService) getCommunityMember(ctx context.Context, communityID, userID uuid.UUID) (*dto.CommunityMemberResponse, error) {
+	member, err := s.communityRepo.GetMemberWithUser(ctx, communityID, userID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, errs.ErrMemberNotFound
+		}
+		return nil, err
+	}
+	return mapCommunityMember(member), nil
+}
+
+// findMember returns userID's membership of a community, or nil if there
+// is none. Anonymous viewers are never members.
+func findMember(ctx context.Context, repo repository.CommunityRepository, communityID, userID uuid.UUID) (*models.CommunityMember, error) {
+	if userID == uuid.Nil {
+		return nil, nil
+	}
+	member, err := repo.GetMember(ctx, communityID, userID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return member, nil
+}
+
+// canViewCommunity reports whether member may read the community's posts.
+// Banned users keep read access to communities anyone can read.
+func canViewCommunity(c *models.Community, member *models.CommunityMember) bool {
+	return c.IsListed() || member.IsActive()
+}
+
+// checkCommunityPost reports whether member may post, react and vote in
+// the community.
+func checkCommunityPost(c *models.Community, member *models.CommunityMember) error {
+	if member.IsBanned() {
+		return errs.ErrCommunityBanned
+	}
+	if c.Visibility != models.CommunityPublic && !member.IsActive() {
+		return errs.ErrCommunityMemberOnly
+	}
+	return nil
+}
+
+func mapCommunities(communities []models.Community) []dto.CommunityResponse {
+	responses := make([]dto.CommunityResponse, len(communities))
+	for i := range communities {
+		responses[i] = *mapCommunity(&communities[i], nil)
+	}
+	return responses
+}
+
+func mapCommunity(c *models.Community, member *models.CommunityMember) *dto.CommunityResponse {
+	return &dto.CommunityResponse{
+		ID:           c.ID.String(),
+		Slug:         c.Slug,
+		Name:         c.Name,
+		Description:  c.Description,
+		Rules:        c.Rules,
+		Visibility:   string(c.Visibility),
+		OwnerID:      c.OwnerID.String(),
+		MembersCount: c.MembersCount,
+		CreatedAt:    c.CreatedAt,
+		UpdatedAt:    c.UpdatedAt,
+		Membership:   mapMembership(member),
+	}
+}
+
+func mapMembership(member *models.CommunityMember) *dto.MembershipResponse {
+	if member == nil {
+		return nil
+	}
+	return &dto.MembershipResponse{
+		Role:   string(member.Role),
+		Status: string(member.Status),
+	}
+}
+
+func mapCommunityMember(m *models.CommunityMember) *dto.CommunityMemberResponse {
+	return &dto.CommunityMemberResponse{
+		User:     mapAuthor(m.User),
+		Role:     string(m.Role),
+		Status:   string(m.Status),
+		JoinedAt: m.CreatedAt,
+	}
+}
+
+func mapCommunitySummary(c *models.Community) *dto.CommunitySummary {
+	if c == nil {
+		return nil
+	}
+	return &dto.CommunitySummary{
+		ID:         c.ID.String(),
+		Slug:       c.Slug,
+		Name:       c.Name,
+		Visibility: string(c.Visibility),
+	}
+}
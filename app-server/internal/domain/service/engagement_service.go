@@ -0,0 +1,65 @@
+package service
+
+import (
+	"context"
+
+	"github.com/maulana1k/forum-app/internal/app/dto"
+	"github.com/maulana1k/forum-app/internal/domain/events"
+	"github.com/maulana1k/forum-app/internal/provider/engagement"
+)
+
+type EngagementService interface {
+	// RecordEvents adds views and dwell times reported by the client to
+	// the engagement stream.
+	RecordEvents(ctx context.Context, userID string, req *dto.EngagementEventsRequest) error
+}
+
+type engagementService struct {
+	recorder *engagement.Recorder
+}
+
+// NewEngagementService also streams the engagement the server sees
+// itself: reactions and recommendation clicks.
+func NewEngagementService(recorder *engagement.Recorder, bus *events.Bus) EngagementService {
+	s := &engagementService{recorder: recorder}
+	bus.Subscribe(s.recordDomainEvent,
+		events.PostReactedEvent,
+		events.RecommendationClickedEvent,
+	)
+	return s
+}
+
+func (s *engagementService) RecordEvents(ctx context.Context, userID string, req *dto.EngagementEventsRequest) error {
+	for _, e := range req.Events {
+		event := engagement.Event{
+			Kind:             engagement.Kind(e.Kind),
+			PostID:           e.PostID,
+			Surface:          e.Surface,
+			RecommendationID: e.RecommendationID,
+		}
+		if event.Kind == engagement.KindDwell {
+			event.DwellMs = e.DwellMs
+		}
+		s.recorder.Record(userID, event)
+	}
+	return nil
+}
+
+func (s *engagementService) recordDomainEvent(ctx context.Context, event events.Event) error {
+	switch e := event.(type) {
+	case events.PostReacted:
+		s.recorder.Record(e.UserID, engagement.Event{
+			Kind:     engagement.KindReaction,
+			PostID:   e.PostID,
+			Reaction: e.Reaction,
+		})
+	case events.RecommendationClicked:
+		s.recorder.Record(e.UserID, engagement.Event{
+			Kind:             engagement.KindRecommendationClick,
+			PostID:           e.PostID,
+			Surface:          "recommendations",
+			RecommendationID: e.RecommendationID,
+		})
+	}
+	return nil
+}
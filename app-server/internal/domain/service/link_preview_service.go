@@ -0,0 +1,149 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/maulana1k/forum-app/internal/app/dto"
+	"github.com/maulana1k/forum-app/internal/domain/events"
+	"github.com/maulana1k/forum-app/internal/domain/models"
+	"github.com/maulana1k/forum-app/internal/domain/repository"
+	"github.com/maulana1k/forum-app/internal/pkg/unfurl"
+	"github.com/maulana1k/forum-app/internal/pkg/utils"
+	"github.com/maulana1k/forum-app/internal/provider/cache"
+	"gorm.io/gorm"
+)
+
+// LinkPreviewQueue carries published posts whose first link still needs a
+// preview.
+const LinkPreviewQueue = "post-link-preview"
+
+// LinkPreviewRequest is the LinkPreviewQueue message body.
+type LinkPreviewRequest struct {
+	PostID string `json:"post_id"`
+	URL    string `json:"url"`
+}
+
+type LinkPreviewService interface {
+	// Unfurl attaches the preview of url to the post, fetching it unless a
+	// fresh one is already stored.
+	Unfurl(ctx context.Context, postID, url string) error
+}
+
+// LinkPreviewOptions tunes link unfurling from configuration.
+type LinkPreviewOptions struct {
+	Fetch unfurl.Options
+	// TTL is how long a fetched preview is reused before the page is
+	// fetched again.
+	TTL time.Duration
+}
+
+type linkPreviewService struct {
+	linkRepo repository.LinkPreviewRepository
+	fetcher  *unfurl.Fetcher
+	events   *events.Bus
+	previews *cache.Loader[*models.LinkPreview]
+	ttl      time.Duration
+}
+
+func NewLinkPreviewService(linkRepo repository.LinkPreviewRepository, bus *events.Bus, store cache.Cache, opts LinkPreviewOptions) LinkPreviewService {
+	if opts.TTL <= 0 {
+		opts.TTL = 24 * time.Hour
+	}
+	return &linkPreviewService{
+		linkRepo: linkRepo,
+		fetcher:  unfurl.NewFetcher(opts.Fetch),
+		events:   bus,
+		previews: cache.NewLoader[*models.LinkPreview](store, "link_preview", opts.TTL),
+		ttl:      opts.TTL,
+	}
+}
+
+func (s *linkPreviewService) Unfurl(ctx context.Context, postID, url string) error {
+	pid, err := uuid.Parse(postID)
+	if err != nil {
+		return err
+	}
+
+	preview, err := s.previews.Get(ctx, url, func(ctx context.Context) (*models.LinkPreview, error) {
+		return s.loadPreview(ctx, url)
+	})
+	switch {
+	case errors.Is(err, unfurl.ErrBlocked), errors.Is(err, unfurl.ErrNotHTML):
+		// Retrying will not change the answer.
+		utils.LoggerFromContext(ctx).WithError(err).WithField("url", url).Debug("link not previewable")
+		return nil
+	case err != nil:
+		return err
+	case preview == nil:
+		return nil
+	}
+
+	if err := s.linkRepo.AttachPreview(ctx, pid, preview.ID); err != nil {
+		return err
+	}
+	s.events.Publish(ctx, events.PostUpdated{PostID: postID})
+	return nil
+}
+
+// loadPreview returns the stored preview of url while it is fresh and
+// fetches the page otherwise. Pages without any card metadata yield nil.
+func (s *linkPreviewService) loadPreview(ctx context.Context, url string) (*models.LinkPreview, error) {
+	stored, err := s.linkRepo.GetPreviewByURL(ctx, url)
+	if err == nil && time.Since(stored.FetchedAt) < s.ttl {
+		return stored, nil
+	}
+	if err != nil && !errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, err
+	}
+
+	fetched, err := s.fetcher.Fetch(ctx, url)
+	if err != nil {
+		return nil, err
+	}
+	if fetched.Empty() {
+		return nil, nil
+	}
+
+	preview := &models.LinkPreview{
+		URL:         url,
+		Title:       fetched.Title,
+		Description: fetched.Description,
+		ImageURL:    fetched.ImageURL,
+		SiteName:    fetched.SiteName,
+		FetchedAt:   time.Now(),
+	}
+	if err := s.linkRepo.UpsertPreview(ctx, preview); err != nil {
+		return nil, err
+	}
+	return preview, nil
+}
+
+// requestLinkPreview queues the first link in post for unfurling.
+func (s *postService) requestLinkPreview(ctx context.Context, post *models.Post) {
+	urls := unfurl.ExtractURLs(post.Content)
+	if len(urls) == 0 {
+		return
+	}
+
+	body, _ := json.Marshal(LinkPreviewRequest{PostID: post.ID.String(), URL: urls[0]})
+	if err := s.linkPreviews.Publish(ctx, body); err != nil {
+		utils.LoggerFromContext(ctx).WithError(err).WithField("post_id", post.ID).Error("failed to publish link preview message")
+	}
+}
+
+func mapLinkPreview(p *models.LinkPreview) *dto.LinkPreview {
+	if p == nil {
+		return nil
+	}
+	return &dto.LinkPreview{
+		URL:         p.URL,
+		Title:       p.Title,
+		Description: p.Description,
+		ImageURL:    p.ImageURL,
+		SiteName:    p.SiteName,
+	}
+}
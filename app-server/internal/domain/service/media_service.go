@@ -0,0 +1,339 @@
+package service
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/maulana1k/forum-app/internal/app/dto"
+	"github.com/maulana1k/forum-app/internal/domain/errs"
+	"github.com/maulana1k/forum-app/internal/domain/models"
+	"github.com/maulana1k/forum-app/internal/domain/repository"
+	"github.com/maulana1k/forum-app/internal/pkg/imaging"
+	"github.com/maulana1k/forum-app/internal/provider/storage"
+	"gorm.io/gorm"
+)
+
+type MediaService interface {
+	// Upload stores an image sent directly in the request.
+	Upload(ctx context.Context, ownerID string, r io.Reader) (*dto.MediaResponse, error)
+	// CreateUpload reserves a media ID and returns a presigned URL the
+	// client uploads the file to.
+	CreateUpload(ctx context.Context, ownerID string, req *dto.CreateUploadRequest) (*dto.UploadResponse, error)
+	// ReceiveUpload accepts the body of a presigned upload for stores that
+	// route them through the API.
+	ReceiveUpload(ctx context.Context, token string, r io.Reader) error
+	// OpenDownload opens the object behind a signed download link for
+	// stores that route them through the API, with its file name.
+	OpenDownload(ctx context.Context, token string) (io.ReadCloser, string, error)
+	// CompleteUpload validates and processes a presigned upload.
+	CompleteUpload(ctx context.Context, ownerID, mediaID string) (*dto.MediaResponse, error)
+	GetMedia(ctx context.Context, mediaID string) (*dto.MediaResponse, error)
+}
+
+// MediaOptions tunes upload limits.
+type MediaOptions struct {
+	MaxBytes      int64
+	ThumbnailSize int
+	UploadTTL     time.Duration
+}
+
+type mediaService struct {
+	mediaRepo repository.MediaRepository
+	store     storage.BlobStore
+	opts      MediaOptions
+}
+
+func NewMediaService(mediaRepo repository.MediaRepository, store storage.BlobStore, opts MediaOptions) MediaService {
+	if opts.MaxBytes <= 0 {
+		opts.MaxBytes = 10 << 20
+	}
+	if opts.ThumbnailSize <= 0 {
+		opts.ThumbnailSize = 320
+	}
+	if opts.UploadTTL <= 0 {
+		opts.UploadTTL = 15 * time.Minute
+	}
+	return &mediaService{mediaRepo: mediaRepo, store: store, opts: opts}
+}
+
+func mediaKey(id uuid.UUID) string {
+	return "media/" + id.String()
+}
+
+func (s *mediaService) Upload(ctx context.Context, ownerID string, r io.Reader) (*dto.MediaResponse, error) {
+	uid, err := uuid.Parse(ownerID)
+	if err != nil {
+		return nil, errs.ErrInvalidToken.Wrap(err)
+	}
+
+	data, err := s.readLimited(r)
+	if err != nil {
+		return nil, err
+	}
+
+	id := uuid.New()
+	media := &models.Media{ID: id, OwnerID: uid, Key: mediaKey(id)}
+	if err := s.process(ctx, media, data); err != nil {
+		return nil, err
+	}
+	if err := s.store.Put(ctx, media.Key, bytes.NewReader(data), int64(len(data)), media.ContentType); err != nil {
+		return nil, err
+	}
+
+	media.Status = models.MediaReady
+	if err := s.mediaRepo.CreateMedia(ctx, media); err != nil {
+		return nil, err
+	}
+	return mapMedia(media), nil
+}
+
+func (s *mediaService) CreateUpload(ctx context.Context, ownerID string, req *dto.CreateUploadRequest) (*dto.UploadResponse, error) {
+	uid, err := uuid.Parse(ownerID)
+	if err != nil {
+		return nil, errs.ErrInvalidToken.Wrap(err)
+	}
+	// Checked again on the bytes at completion; this just fails fast.
+	if !imaging.Allowed(req.ContentType) {
+		return nil, errs.ErrUnsupportedMedia
+	}
+	if req.Size > s.opts.MaxBytes {
+		return nil, errs.ErrMediaTooLarge
+	}
+
+	id := uuid.New()
+	media := &models.Media{ID: id, OwnerID: uid, Status: models.MediaPending, Key: mediaKey(id)}
+	if err := s.mediaRepo.CreateMedia(ctx, media); err != nil {
+		return nil, err
+	}
+
+	url, err := s.store.PresignPut(ctx, media.Key, req.ContentType, s.opts.UploadTTL)
+	if err != nil {
+		return nil, err
+	}
+
+	return &dto.UploadResponse{
+		Media:     *mapMedia(media),
+		UploadURL: url,
+		Method:    http.MethodPut,
+		ExpiresAt: time.Now().Add(s.opts.UploadTTL),
+	}, nil
+}
+
+func (s *mediaService) OpenDownload(ctx context.Context, token string) (io.ReadCloser, string, error) {
+	signed, ok := s.store.(storage.SignedDownloads)
+	if !ok {
+		return nil, "", errs.ErrInvalidDownload
+	}
+	key, filename, err := signed.VerifyDownload(token)
+	if err != nil {
+		return nil, "", errs.ErrInvalidDownload.Wrap(err)
+	}
+
+	body, err := s.store.Get(ctx, key)
+	if err != nil {
+		if errors.Is(err, storage.ErrNotFound) {
+			return nil, "", errs.ErrInvalidDownload.Wrap(err)
+		}
+		return nil, "", err
+	}
+	return body, filename, nil
+}
+
+func (s *mediaService) ReceiveUpload(ctx context.Context, token string, r io.Reader) error {
+	signed, ok := s.store.(storage.SignedUploads)
+	if !ok {
+		return errs.ErrInvalidUploadToken
+	}
+	key, err := signed.VerifyUpload(token)
+	if err != nil {
+		return errs.ErrInvalidUploadToken.Wrap(err)
+	}
+	// The token outlives the upload it was issued for; once the media is
+	// completed the key must not be overwritten.
+	media, err := s.mediaRepo.GetMediaByKey(ctx, key)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return errs.ErrInvalidUploadToken
+		}
+		return err
+	}
+	if media.Status != models.MediaPending {
+		return errs.ErrInvalidUploadToken
+	}
+
+	data, err := s.readLimited(r)
+	if err != nil {
+		return err
+	}
+	// Objects are served as they are stored, so refuse anything that is not
+	// an allowed image before it reaches the store.
+	info, err := inspect(data)
+	if err != nil {
+		return err
+	}
+	return s.store.Put(ctx, key, bytes.NewReader(data), int64(len(data)), info.ContentType)
+}
+
+func (s *mediaService) CompleteUpload(ctx context.Context, ownerID, mediaID string) (*dto.MediaResponse, error) {
+	uid, err := uuid.Parse(ownerID)
+	if err != nil {
+		return nil, errs.ErrInvalidToken.Wrap(err)
+	}
+	media, err := s.getMedia(ctx, mediaID)
+	if err != nil {
+		return nil, err
+	}
+	if media.OwnerID != uid {
+		return nil, errs.ErrMediaForbidden
+	}
+	if media.IsReady() {
+		return nil, errs.ErrMediaReady
+	}
+
+	obj, err := s.store.Get(ctx, media.Key)
+	if errors.Is(err, storage.ErrNotFound) {
+		return nil, errs.ErrMediaNotReady
+	}
+	if err != nil {
+		return nil, err
+	}
+	data, err := s.readLimited(obj)
+	obj.Close()
+	if err == nil {
+		err = s.process(ctx, media, data)
+	}
+	if err != nil {
+		// Don't keep bytes we refused; the client may retry the upload.
+		_ = s.store.Delete(ctx, media.Key)
+		return nil, err
+	}
+
+	updated, err := s.mediaRepo.MarkReady(ctx, media)
+	if err != nil {
+		return nil, err
+	}
+	if !updated {
+		return nil, errs.ErrMediaReady
+	}
+	return mapMedia(media), nil
+}
+
+func (s *mediaService) GetMedia(ctx context.Context, mediaID string) (*dto.MediaResponse, error) {
+	media, err := s.getMedia(ctx, mediaID)
+	if err != nil {
+		return nil, err
+	}
+	return mapMedia(media), nil
+}
+
+func (s *mediaService) getMedia(ctx context.Context, mediaID string) (*models.Media, error) {
+	id, err := uuid.Parse(mediaID)
+	if err != nil {
+		return nil, errs.ErrInvalidID.Wrap(err)
+	}
+	media, err := s.mediaRepo.GetMediaByID(ctx, id)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, errs.ErrMediaNotFound
+		}
+		return nil, err
+	}
+	return media, nil
+}
+
+// readLimited reads r fully, failing once it exceeds the size limit.
+func (s *mediaService) readLimited(r io.Reader) ([]byte, error) {
+	data, err := io.ReadAll(io.LimitReader(r, s.opts.MaxBytes+1))
+	if err != nil {
+		return nil, err
+	}
+	if int64(len(data)) > s.opts.MaxBytes {
+		return nil, errs.ErrMediaTooLarge
+	}
+	return data, nil
+}
+
+// process sniffs and measures the image in data and stores its thumbnail,
+// filling in media. The original is stored by the caller.
+func (s *mediaService) process(ctx context.Context, media *models.Media, data []byte) error {
+	info, err := inspect(data)
+	if err != nil {
+		return err
+	}
+
+	thumb, err := imaging.Thumbnail(data, s.opts.ThumbnailSize)
+	if err != nil {
+		return errs.ErrUnsupportedMedia.Wrap(err)
+	}
+	thumbKey := media.Key + "_thumb.jpg"
+	if err := s.store.Put(ctx, thumbKey, bytes.NewReader(thumb), int64(len(thumb)), "image/jpeg"); err != nil {
+		return err
+	}
+
+	media.ContentType = info.ContentType
+	media.Size = int64(len(data))
+	media.Width = info.Width
+	media.Height = info.Height
+	media.ThumbnailKey = thumbKey
+	media.URL = s.store.URL(media.Key)
+	media.ThumbnailURL = s.store.URL(thumbKey)
+	return nil
+}
+
+// inspect sniffs the image in data, mapping rejections to domain errors.
+func inspect(data []byte) (imaging.Info, error) {
+	info, err := imaging.Inspect(data)
+	switch {
+	case errors.Is(err, imaging.ErrTooLarge):
+		return info, errs.ErrMediaTooLarge.Wrap(err)
+	case err != nil:
+		return info, errs.ErrUnsupportedMedia.Wrap(err)
+	}
+	return info, nil
+}
+
+// resolveMedia loads a processed upload that ownerID may attach to a post
+// or profile.
+func resolveMedia(ctx context.Context, repo repository.MediaRepository, ownerID uuid.UUID, mediaID string) (*models.Media, error) {
+	id, err := uuid.Parse(strings.TrimSpace(mediaID))
+	if err != nil {
+		return nil, errs.ErrInvalidID.Wrap(err)
+	}
+	media, err := repo.GetMediaByID(ctx, id)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, errs.ErrMediaNotFound
+		}
+		return nil, err
+	}
+	if media.OwnerID != ownerID {
+		return nil, errs.ErrMediaForbidden
+	}
+	if !media.IsReady() {
+		return nil, errs.ErrMediaNotReady
+	}
+	return media, nil
+}
+
+func mapMedia(m *models.Media) *dto.MediaResponse {
+	if m == nil {
+		return nil
+	}
+	return &dto.MediaResponse{
+		ID:           m.ID.String(),
+		Status:       string(m.Status),
+		ContentType:  m.ContentType,
+		Size:         m.Size,
+		Width:        m.Width,
+		Height:       m.Height,
+		URL:          m.URL,
+		ThumbnailURL: m.ThumbnailURL,
+		CreatedAt:    m.CreatedAt,
+	}
+}
@@ -0,0 +1,68 @@
+package service
+
+import (
+	"context"
+	"errors"
+
+	"github.com/google/uuid"
+	"github.com/maulana1k/forum-app/internal/app/dto"
+	"github.com/maulana1k/forum-app/internal/domain/errs"
+	"github.com/maulana1k/forum-app/internal/domain/models"
+	"github.com/maulana1k/forum-app/internal/domain/repository"
+	"github.com/maulana1k/forum-app/internal/pkg/utils"
+	"github.com/maulana1k/forum-app/internal/provider/monitoring"
+	"github.com/sirupsen/logrus"
+	"gorm.io/gorm"
+)
+
+type ModerationService interface {
+	// ReportResult records a classifier's verdict on a post. A later
+	// report from the same model replaces the earlier one.
+	ReportResult(ctx context.Context, req *dto.ModerationResultRequest) error
+}
+
+type moderationService struct {
+	moderationRepo repository.ModerationRepository
+	postRepo       repository.PostRepository
+}
+
+func NewModerationService(moderationRepo repository.ModerationRepository, postRepo repository.PostRepository) ModerationService {
+	return &moderationService{
+		moderationRepo: moderationRepo,
+		postRepo:       postRepo,
+	}
+}
+
+func (s *moderationService) ReportResult(ctx context.Context, req *dto.ModerationResultRequest) error {
+	postID, err := uuid.Parse(req.PostID)
+	if err != nil {
+		return errs.ErrInvalidID.Wrap(err)
+	}
+	if _, err := s.postRepo.GetPostByID(ctx, req.PostID); err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return errs.ErrPostNotFound
+		}
+		return err
+	}
+
+	if err := s.moderationRepo.SaveResult(ctx, &models.ModerationResult{
+		PostID:  postID,
+		Model:   req.Model,
+		Label:   req.Label,
+		Score:   req.Score,
+		Flagged: req.Flagged,
+	}); err != nil {
+		return err
+	}
+
+	if req.Flagged {
+		monitoring.PostsFlagged.Inc()
+		utils.LoggerFromContext(ctx).WithFields(logrus.Fields{
+			"post_id": req.PostID,
+			"model":   req.Model,
+			"label":   req.Label,
+			"score":   req.Score,
+		}).Info("post flagged by moderation model")
+	}
+	return nil
+}
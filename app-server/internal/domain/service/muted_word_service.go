@@ -0,0 +1,174 @@
+package service
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/maulana1k/forum-app/internal/app/dto"
+	"github.com/maulana1k/forum-app/internal/domain/errs"
+	"github.com/maulana1k/forum-app/internal/domain/models"
+	"github.com/maulana1k/forum-app/internal/domain/repository"
+	"github.com/maulana1k/forum-app/internal/pkg/textfilter"
+)
+
+// maxMutedWords bounds how many terms one user can mute, which also bounds
+// the matching work on every feed page they load.
+const maxMutedWords = 200
+
+// FilterReasonMutedWord is the reason given for content hidden by a muted
+// word.
+const FilterReasonMutedWord = "muted_word"
+
+type MutedWordService interface {
+	AddMutedWord(ctx context.Context, userID string, req *dto.CreateMutedWordRequest) (*dto.MutedWordResponse, error)
+	// ListMutedWords returns the user's mutes that have not expired,
+	// newest first.
+	ListMutedWords(ctx context.Context, userID string) ([]dto.MutedWordResponse, error)
+	RemoveMutedWord(ctx context.Context, userID string, id uint) error
+}
+
+type mutedWordService struct {
+	repo repository.MutedWordRepository
+}
+
+func NewMutedWordService(repo repository.MutedWordRepository) MutedWordService {
+	return &mutedWordService{repo: repo}
+}
+
+func (s *mutedWordService) AddMutedWord(ctx context.Context, userID string, req *dto.CreateMutedWordRequest) (*dto.MutedWordResponse, error) {
+	uid, err := uuid.Parse(userID)
+	if err != nil {
+		return nil, errs.ErrInvalidToken.Wrap(err)
+	}
+
+	phrase := textfilter.Normalize(req.Phrase)
+	if phrase == "" {
+		return nil, errs.ErrEmptyMutedWord
+	}
+	if req.ExpiresAt != nil && !req.ExpiresAt.After(time.Now()) {
+		return nil, errs.ErrInvalidMuteExpiry
+	}
+
+	total, err := s.repo.CountMutedWords(ctx, uid)
+	if err != nil {
+		return nil, err
+	}
+	if total >= maxMutedWords {
+		return nil, errs.ErrTooManyMutedWords
+	}
+
+	word := &models.MutedWord{UserID: uid, Phrase: phrase}
+	if req.ExpiresAt != nil {
+		expiresAt := req.ExpiresAt.UTC()
+		word.ExpiresAt = &expiresAt
+	}
+	if err := s.repo.SaveMutedWord(ctx, word); err != nil {
+		return nil, err
+	}
+
+	resp := mapMutedWord(word)
+	return &resp, nil
+}
+
+func (s *mutedWordService) ListMutedWords(ctx context.Context, userID string) ([]dto.MutedWordResponse, error) {
+	uid, err := uuid.Parse(userID)
+	if err != nil {
+		return nil, errs.ErrInvalidToken.Wrap(err)
+	}
+
+	words, err := s.repo.ListMutedWords(ctx, uid)
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	responses := make([]dto.MutedWordResponse, 0, len(words))
+	for i := range words {
+		if words[i].IsActive(now) {
+			responses = append(responses, mapMutedWord(&words[i]))
+		}
+	}
+	return responses, nil
+}
+
+func (s *mutedWordService) RemoveMutedWord(ctx context.Context, userID string, id uint) error {
+	uid, err := uuid.Parse(userID)
+	if err != nil {
+		return errs.ErrInvalidToken.Wrap(err)
+	}
+
+	removed, err := s.repo.DeleteMutedWord(ctx, uid, id)
+	if err != nil {
+		return err
+	}
+	if !removed {
+		return errs.ErrMutedWordNotFound
+	}
+	return nil
+}
+
+func mapMutedWord(w *models.MutedWord) dto.MutedWordResponse {
+	return dto.MutedWordResponse{
+		ID:        w.ID,
+		Phrase:    w.Phrase,
+		ExpiresAt: w.ExpiresAt,
+		CreatedAt: w.CreatedAt,
+	}
+}
+
+// mutedWordMatcher loads the viewer's active muted words. Anonymous
+// viewers get an empty matcher.
+func mutedWordMatcher(ctx context.Context, repo repository.MutedWordRepository, viewerID uuid.UUID) (*textfilter.Matcher, error) {
+	if viewerID == uuid.Nil {
+		return nil, nil
+	}
+	phrases, err := repo.ListActivePhrases(ctx, viewerID, time.Now())
+	if err != nil {
+		return nil, err
+	}
+	return textfilter.NewMatcher(phrases), nil
+}
+
+// filterPosts marks the posts and embedded replies that match m. The
+// viewer's own posts are never filtered.
+func filterPosts(m *textfilter.Matcher, viewerID string, posts []dto.PostResponse) {
+	if m.Empty() {
+		return
+	}
+	for i := range posts {
+		p := &posts[i]
+		if p.Author.ID != viewerID {
+			p.Filtered = filterReason(m.Match(p.Content, p.Tags))
+		}
+		filterReplies(m, p.Replies)
+	}
+}
+
+// filterReplies marks the replies that match m.
+func filterReplies(m *textfilter.Matcher, replies []dto.ReplyResponse) {
+	if m.Empty() {
+		return
+	}
+	for i := range replies {
+		replies[i].Filtered = filterReason(m.Match(replies[i].Content))
+	}
+}
+
+func filterReason(matches []string) *dto.FilterReason {
+	if len(matches) == 0 {
+		return nil
+	}
+	return &dto.FilterReason{Reason: FilterReasonMutedWord, Matches: matches}
+}
+
+// applyMutedWords marks feed posts matching the viewer's muted words.
+func (s *postService) applyMutedWords(ctx context.Context, viewerID string, posts []dto.PostResponse) error {
+	vid, _ := uuid.Parse(viewerID)
+	matcher, err := mutedWordMatcher(ctx, s.mutedWordRepo, vid)
+	if err != nil {
+		return err
+	}
+	filterPosts(matcher, viewerID, posts)
+	return nil
+}
@@ -0,0 +1,126 @@
+package service
+
+import (
+	"context"
+	"errors"
+
+	"github.com/google/uuid"
+	"github.com/maulana1k/forum-app/internal/app/dto"
+	"github.com/maulana1k/forum-app/internal/domain/errs"
+	"github.com/maulana1k/forum-app/internal/domain/models"
+	"github.com/maulana1k/forum-app/internal/pkg/pagination"
+	"gorm.io/gorm"
+)
+
+// postCommunity returns the community a post belongs to and userID's
+// membership of it. Both are nil for posts outside any community.
+func (s *postService) postCommunity(ctx context.Context, communityID *uuid.UUID, userID uuid.UUID) (*models.Community, *models.CommunityMember, error) {
+	if communityID == nil {
+		return nil, nil, nil
+	}
+
+	community, err := s.communityRepo.GetCommunityByID(ctx, *communityID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil, errs.ErrCommunityNotFound
+		}
+		return nil, nil, err
+	}
+
+	member, err := findMember(ctx, s.communityRepo, community.ID, userID)
+	if err != nil {
+		return nil, nil, err
+	}
+	return community, member, nil
+}
+
+// checkCommunityVisible hides posts in private communities from
+// non-members.
+func (s *postService) checkCommunityVisible(ctx context.Context, communityID *uuid.UUID, viewerID uuid.UUID) error {
+	community, member, err := s.postCommunity(ctx, communityID, viewerID)
+	if err != nil {
+		if errors.Is(err, errs.ErrCommunityNotFound) {
+			return errs.ErrPostNotFound
+		}
+		return err
+	}
+	if community != nil && !canViewCommunity(community, member) {
+		return errs.ErrPostNotFound
+	}
+	return nil
+}
+
+// checkPostInteract lets users react and vote on posts they can see,
+// unless they were banned from the post's community.
+func (s *postService) checkPostInteract(ctx context.Context, post *models.Post, userID uuid.UUID) error {
+	if err := s.checkNotBlocked(ctx, post.AuthorID, userID); err != nil {
+		return err
+	}
+
+	community, member, err := s.postCommunity(ctx, post.CommunityID, userID)
+	if err != nil {
+		return err
+	}
+	if community == nil {
+		return nil
+	}
+	if !canViewCommunity(community, member) {
+		return errs.ErrPostNotFound
+	}
+	if member.IsBanned() {
+		return errs.ErrCommunityBanned
+	}
+	return nil
+}
+
+// GetCommunityPosts lists the published posts of a community, newest
+// first.
+func (s *postService) GetCommunityPosts(ctx context.Context, slug string, query *dto.PostQueryParams, viewerID string) (*dto.PaginatedPostsResponse, error) {
+	page, err := pagination.NewParams(query.Limit, query.Page, query.Cursor)
+	if err != nil {
+		return nil, err
+	}
+
+	community, err := s.communityRepo.GetCommunityBySlug(ctx, slug)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, errs.ErrCommunityNotFound
+		}
+		return nil, err
+	}
+	uid, err := parseViewerID(viewerID)
+	if err != nil {
+		return nil, err
+	}
+	member, err := findMember(ctx, s.communityRepo, community.ID, uid)
+	if err != nil {
+		return nil, err
+	}
+	if !canViewCommunity(community, member) {
+		return nil, errs.ErrCommunityPrivate
+	}
+
+	posts, err := s.postRepo.GetPostsByCommunityID(ctx, community.ID, page, uid)
+	if err != nil {
+		return nil, err
+	}
+
+	var resp *dto.PaginatedPostsResponse
+	if page.IsOffset() {
+		total, err := s.postRepo.CountPostsByCommunityID(ctx, community.ID, uid)
+		if err != nil {
+			return nil, err
+		}
+		resp = s.offsetPostsPage(posts, total, page)
+	} else {
+		resp = s.cursorPostsPage(posts, page)
+	}
+
+	if err := s.applyViewer(ctx, viewerID, resp.Posts); err != nil {
+		return nil, err
+	}
+	if err := s.applyMutedWords(ctx, viewerID, resp.Posts); err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
@@ -0,0 +1,232 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/maulana1k/forum-app/internal/app/dto"
+	"github.com/maulana1k/forum-app/internal/domain/errs"
+	"github.com/maulana1k/forum-app/internal/domain/events"
+	"github.com/maulana1k/forum-app/internal/domain/models"
+	"github.com/maulana1k/forum-app/internal/pkg/utils"
+	"github.com/maulana1k/forum-app/internal/provider/broker"
+	"gorm.io/gorm"
+)
+
+// PollClosedQueue receives the final results of every poll once it closes.
+const PollClosedQueue = "poll-closed"
+
+const (
+	pollMinDuration = 5 * time.Minute
+	pollMaxDuration = 30 * 24 * time.Hour
+	pollCloseBatch  = 100
+)
+
+// newPoll builds the poll for a new post from req.
+func newPoll(req *dto.CreatePollRequest, now time.Time) (*models.Poll, error) {
+	if req.ClosesAt.Before(now.Add(pollMinDuration)) || req.ClosesAt.After(now.Add(pollMaxDuration)) {
+		return nil, errs.ErrInvalidPollClose
+	}
+
+	poll := &models.Poll{
+		MultipleChoice: req.MultipleChoice,
+		ClosesAt:       req.ClosesAt.UTC(),
+		Options:        make([]models.PollOption, len(req.Options)),
+	}
+	for i, text := range req.Options {
+		poll.Options[i] = models.PollOption{Position: i, Text: text}
+	}
+	return poll, nil
+}
+
+// Vote records the user's choice in a post's poll and returns the poll
+// with its results, which the voter may now see.
+func (s *postService) Vote(ctx context.Context, postID, userID string, req *dto.VoteRequest) (*dto.PollResponse, error) {
+	pid, uid, err := s.reactionTarget(ctx, postID, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	poll, err := s.getPoll(ctx, pid)
+	if err != nil {
+		return nil, err
+	}
+	if !poll.MultipleChoice && len(req.OptionIDs) > 1 {
+		return nil, errs.ErrPollSingleChoice
+	}
+
+	if err := s.pollRepo.Vote(ctx, poll.ID, uid, req.OptionIDs); err != nil {
+		return nil, err
+	}
+	s.events.Publish(ctx, events.PollVoted{PostID: postID, PollID: poll.ID.String(), UserID: userID})
+
+	return s.viewPoll(ctx, pid, uid)
+}
+
+// Unvote withdraws the user's vote so they can vote again.
+func (s *postService) Unvote(ctx context.Context, postID, userID string) (*dto.PollResponse, error) {
+	pid, uid, err := s.reactionTarget(ctx, postID, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	poll, err := s.getPoll(ctx, pid)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.pollRepo.Unvote(ctx, poll.ID, uid); err != nil {
+		return nil, err
+	}
+	s.events.Publish(ctx, events.PollVoted{PostID: postID, PollID: poll.ID.String(), UserID: userID, Withdrawn: true})
+
+	return s.viewPoll(ctx, pid, uid)
+}
+
+// ClosePolls closes every poll whose close time has passed and publishes
+// its final results to PollClosedQueue. It returns how many polls closed.
+func (s *postService) ClosePolls(ctx context.Context) (int, error) {
+	closed := 0
+	for {
+		polls, err := s.pollRepo.ClaimClosedPolls(ctx, time.Now().UTC(), pollCloseBatch)
+		if err != nil {
+			return closed, err
+		}
+
+		for i := range polls {
+			s.publishPollClosed(ctx, &polls[i])
+		}
+		closed += len(polls)
+
+		if len(polls) < pollCloseBatch {
+			return closed, nil
+		}
+	}
+}
+
+func (s *postService) publishPollClosed(ctx context.Context, poll *models.Poll) {
+	s.events.Publish(ctx, events.PollClosed{PostID: poll.PostID.String(), PollID: poll.ID.String()})
+
+	type option struct {
+		ID    uint   `json:"id"`
+		Text  string `json:"text"`
+		Votes int    `json:"votes"`
+	}
+	options := make([]option, len(poll.Options))
+	for i, o := range poll.Options {
+		options[i] = option{ID: o.ID, Text: o.Text, Votes: o.VotesCount}
+	}
+
+	body, _ := json.Marshal(map[string]any{
+		"poll_id":         poll.ID,
+		"post_id":         poll.PostID,
+		"multiple_choice": poll.MultipleChoice,
+		"closes_at":       poll.ClosesAt,
+		"voters_count":    poll.VotersCount,
+		"options":         options,
+	})
+
+	producer := broker.NewProducer(s.broker, PollClosedQueue)
+	if err := producer.Publish(ctx, body); err != nil {
+		utils.LoggerFromContext(ctx).WithError(err).WithField("poll_id", poll.ID).Error("failed to publish poll closed message")
+	}
+}
+
+func (s *postService) getPoll(ctx context.Context, postID uuid.UUID) (*models.Poll, error) {
+	poll, err := s.pollRepo.GetPollByPostID(ctx, postID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, errs.ErrPollNotFound
+		}
+		return nil, err
+	}
+	return poll, nil
+}
+
+// viewPoll reloads a post's poll as seen by viewer.
+func (s *postService) viewPoll(ctx context.Context, postID, viewer uuid.UUID) (*dto.PollResponse, error) {
+	poll, err := s.getPoll(ctx, postID)
+	if err != nil {
+		return nil, err
+	}
+
+	posts := []dto.PostResponse{{ID: postID.String(), Poll: mapPoll(poll)}}
+	if err := s.applyPollViewer(ctx, viewer, posts); err != nil {
+		return nil, err
+	}
+	return posts[0].Poll, nil
+}
+
+// applyPollViewer fills the viewer's votes and hides tallies until the
+// viewer has voted or the poll has closed. Polls are copied first since
+// posts may share them with the cache. viewer is uuid.Nil for anonymous
+// requests.
+func (s *postService) applyPollViewer(ctx context.Context, viewer uuid.UUID, posts []dto.PostResponse) error {
+	var pollIDs []uuid.UUID
+	for _, p := range posts {
+		if p.Poll != nil {
+			if id, err := uuid.Parse(p.Poll.ID); err == nil {
+				pollIDs = append(pollIDs, id)
+			}
+		}
+	}
+	if len(pollIDs) == 0 {
+		return nil
+	}
+
+	votes := map[uuid.UUID][]uint{}
+	if viewer != uuid.Nil {
+		var err error
+		if votes, err = s.pollRepo.GetUserVotes(ctx, viewer, pollIDs); err != nil {
+			return err
+		}
+	}
+
+	now := time.Now()
+	for i := range posts {
+		if posts[i].Poll == nil {
+			continue
+		}
+		poll := *posts[i].Poll
+		poll.Options = append([]dto.PollOptionResponse(nil), poll.Options...)
+
+		id, _ := uuid.Parse(poll.ID)
+		poll.MyVotes = votes[id]
+		poll.Closed = !now.Before(poll.ClosesAt)
+		poll.ResultsVisible = poll.Closed || len(poll.MyVotes) > 0
+		if !poll.ResultsVisible {
+			poll.VotersCount = nil
+			for j := range poll.Options {
+				poll.Options[j].Votes = nil
+			}
+		}
+		posts[i].Poll = &poll
+	}
+	return nil
+}
+
+// mapPoll maps a poll with its full tallies; applyPollViewer decides what
+// the viewer gets to see.
+func mapPoll(p *models.Poll) *dto.PollResponse {
+	if p == nil {
+		return nil
+	}
+
+	voters := p.VotersCount
+	options := make([]dto.PollOptionResponse, len(p.Options))
+	for i, o := range p.Options {
+		votes := o.VotesCount
+		options[i] = dto.PollOptionResponse{ID: o.ID, Text: o.Text, Votes: &votes}
+	}
+
+	return &dto.PollResponse{
+		ID:             p.ID.String(),
+		MultipleChoice: p.MultipleChoice,
+		ClosesAt:       p.ClosesAt,
+		VotersCount:    &voters,
+		Options:        options,
+	}
+}
@@ -0,0 +1,258 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"strconv"
+
+	"github.com/google/uuid"
+	"github.com/maulana1k/forum-app/internal/app/dto"
+	"github.com/maulana1k/forum-app/internal/domain/errs"
+	"github.com/maulana1k/forum-app/internal/domain/events"
+	"github.com/maulana1k/forum-app/internal/domain/models"
+	"github.com/maulana1k/forum-app/internal/pkg/pagination"
+	"github.com/maulana1k/forum-app/internal/provider/monitoring"
+	"gorm.io/gorm"
+)
+
+// LikePost is React with the like reaction, kept for the original API. A
+// user who already reacted differently switches to like.
+func (s *postService) LikePost(ctx context.Context, postID, userID string) error {
+	pid, uid, err := s.reactionTarget(ctx, postID, userID)
+	if err != nil {
+		return err
+	}
+
+	current, err := s.reactionRepo.GetUserReaction(ctx, pid, uid)
+	if err != nil {
+		return err
+	}
+	if current == models.ReactionLike {
+		return errs.ErrPostAlreadyLiked
+	}
+
+	return s.setReaction(ctx, pid, uid, models.ReactionLike)
+}
+
+// UnlikePost removes the viewer's like. Other reactions are left alone.
+func (s *postService) UnlikePost(ctx context.Context, postID, userID string) error {
+	pid, uid, err := parseIDs(postID, userID)
+	if err != nil {
+		return err
+	}
+
+	current, err := s.reactionRepo.GetUserReaction(ctx, pid, uid)
+	if err != nil {
+		return err
+	}
+	if current != models.ReactionLike {
+		return errs.ErrPostNotLiked
+	}
+
+	return s.Unreact(ctx, postID, userID)
+}
+
+// React sets the user's reaction to a post, replacing any earlier one.
+func (s *postService) React(ctx context.Context, postID, userID, reaction string) error {
+	if !s.reactions[reaction] {
+		return errs.ErrUnknownReaction
+	}
+
+	pid, uid, err := s.reactionTarget(ctx, postID, userID)
+	if err != nil {
+		return err
+	}
+	return s.setReaction(ctx, pid, uid, reaction)
+}
+
+func (s *postService) setReaction(ctx context.Context, postID, userID uuid.UUID, reaction string) error {
+	previous, err := s.reactionRepo.SetReaction(ctx, postID, userID, reaction)
+	if err != nil {
+		return err
+	}
+	if previous == reaction {
+		return nil
+	}
+
+	if reaction == models.ReactionLike {
+		monitoring.PostLikes.Inc()
+	}
+	s.events.Publish(ctx, events.PostReacted{
+		PostID:   postID.String(),
+		UserID:   userID.String(),
+		Reaction: reaction,
+		Previous: previous,
+	})
+	return nil
+}
+
+// Unreact removes the user's reaction to a post, if any.
+func (s *postService) Unreact(ctx context.Context, postID, userID string) error {
+	pid, uid, err := parseIDs(postID, userID)
+	if err != nil {
+		return err
+	}
+
+	removed, err := s.reactionRepo.RemoveReaction(ctx, pid, uid)
+	if err != nil {
+		return err
+	}
+	if removed == "" {
+		return errs.ErrReactionNotFound
+	}
+
+	s.events.Publish(ctx, events.PostUnreacted{PostID: postID, UserID: userID, Reaction: removed})
+	return nil
+}
+
+// GetReactionUsers lists the users who reacted to a post with reaction,
+// most recent first. The post must be visible to the viewer. Users the
+// viewer blocked or was blocked by, and users hiding their likes, are left
+// out.
+func (s *postService) GetReactionUsers(ctx context.Context, postID, reaction string, query *dto.PostQueryParams, viewerID string) (*dto.PaginatedReactionUsersResponse, error) {
+	if !s.reactions[reaction] {
+		return nil, errs.ErrUnknownReaction
+	}
+
+	page, err := pagination.NewParams(query.Limit, 0, query.Cursor)
+	if err != nil {
+		return nil, err
+	}
+
+	pid, err := uuid.Parse(postID)
+	if err != nil {
+		return nil, errs.ErrInvalidID.Wrap(err)
+	}
+
+	post, err := s.postRepo.GetPostByID(ctx, postID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, errs.ErrPostNotFound
+		}
+		return nil, err
+	}
+	vid, err := parseViewerID(viewerID)
+	if err != nil {
+		return nil, err
+	}
+	if !post.IsVisibleTo(vid) {
+		return nil, errs.ErrPostNotFound
+	}
+	if err := s.checkPostVisible(ctx, post.AuthorID, post.CommunityID, vid); err != nil {
+		return nil, err
+	}
+
+	rows, err := s.reactionRepo.ListReactionUsers(ctx, pid, reaction, page, vid)
+	if err != nil {
+		return nil, err
+	}
+
+	window := pagination.Window(rows, page, func(r models.PostReaction) pagination.Cursor {
+		return pagination.Cursor{CreatedAt: r.CreatedAt, ID: strconv.FormatUint(uint64(r.ID), 10)}
+	})
+
+	users := make([]dto.ReactionUser, len(window.Items))
+	for i, r := range window.Items {
+		users[i] = dto.ReactionUser{
+			User:      mapAuthor(r.User),
+			ReactedAt: r.UpdatedAt,
+		}
+	}
+
+	return &dto.PaginatedReactionUsersResponse{
+		Users:       users,
+		Limit:       page.Limit,
+		HasNextPage: window.HasNext,
+		HasPrevPage: window.HasPrev,
+		NextCursor:  window.NextCursor,
+		PrevCursor:  window.PrevCursor,
+	}, nil
+}
+
+// applyViewer fills the viewer specific fields of posts. Anonymous
+// viewers only get poll results hidden.
+func (s *postService) applyViewer(ctx context.Context, viewerID string, posts []dto.PostResponse) error {
+	uid, err := parseViewerID(viewerID)
+	if err != nil {
+		return err
+	}
+	if err := s.applyPollViewer(ctx, uid, posts); err != nil {
+		return err
+	}
+	if uid == uuid.Nil || len(posts) == 0 {
+		return nil
+	}
+
+	ids := make([]uuid.UUID, 0, len(posts))
+	for _, p := range posts {
+		if id, err := uuid.Parse(p.ID); err == nil {
+			ids = append(ids, id)
+		}
+	}
+
+	reactions, err := s.reactionRepo.GetUserReactions(ctx, uid, ids)
+	if err != nil {
+		return err
+	}
+
+	for i := range posts {
+		id, _ := uuid.Parse(posts[i].ID)
+		posts[i].MyReaction = reactions[id]
+		posts[i].IsLiked = reactions[id] == models.ReactionLike
+	}
+	return nil
+}
+
+// reactionTarget parses the IDs and checks that the post exists, is
+// published and the user may interact with it.
+func (s *postService) reactionTarget(ctx context.Context, postID, userID string) (uuid.UUID, uuid.UUID, error) {
+	pid, uid, err := parseIDs(postID, userID)
+	if err != nil {
+		return uuid.Nil, uuid.Nil, err
+	}
+
+	post, err := s.postRepo.GetPostByID(ctx, postID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return uuid.Nil, uuid.Nil, errs.ErrPostNotFound
+		}
+		return uuid.Nil, uuid.Nil, err
+	}
+	if !post.IsPublished() || !post.IsVisibleTo(uid) {
+		return uuid.Nil, uuid.Nil, errs.ErrPostNotFound
+	}
+	if err := s.checkPostInteract(ctx, post, uid); err != nil {
+		return uuid.Nil, uuid.Nil, err
+	}
+	return pid, uid, nil
+}
+
+func parseIDs(postID, userID string) (uuid.UUID, uuid.UUID, error) {
+	pid, err := uuid.Parse(postID)
+	if err != nil {
+		return uuid.Nil, uuid.Nil, errs.ErrInvalidID.Wrap(err)
+	}
+	uid, err := uuid.Parse(userID)
+	if err != nil {
+		return uuid.Nil, uuid.Nil, errs.ErrInvalidToken.Wrap(err)
+	}
+	return pid, uid, nil
+}
+
+// parseUserID parses the ID of the authenticated caller.
+func parseUserID(userID string) (uuid.UUID, error) {
+	uid, err := uuid.Parse(userID)
+	if err != nil {
+		return uuid.Nil, errs.ErrInvalidToken.Wrap(err)
+	}
+	return uid, nil
+}
+
+// parseViewerID parses the ID of the user a response is tailored to. An
+// anonymous viewer, "", is uuid.Nil.
+func parseViewerID(viewerID string) (uuid.UUID, error) {
+	if viewerID == "" {
+		return uuid.Nil, nil
+	}
+	return parseUserID(viewerID)
+}
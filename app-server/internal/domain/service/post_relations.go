@@ -0,0 +1,81 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"slices"
+
+	"github.com/google/uuid"
+	"github.com/maulana1k/forum-app/internal/app/dto"
+	"github.com/maulana1k/forum-app/internal/domain/errs"
+	"gorm.io/gorm"
+)
+
+// checkPostVisible hides a post from viewers its author blocked or was
+// blocked by, from non-members of its private community, and from everyone
+// but its author while the author is deactivated.
+func (s *postService) checkPostVisible(ctx context.Context, authorID uuid.UUID, communityID *uuid.UUID, viewerID uuid.UUID) error {
+	if err := s.checkNotBlocked(ctx, authorID, viewerID); err != nil {
+		return err
+	}
+	if err := s.checkAuthorActive(ctx, authorID, viewerID); err != nil {
+		return err
+	}
+	return s.checkCommunityVisible(ctx, communityID, viewerID)
+}
+
+// checkNotBlocked reports a post as missing to a viewer who blocked its
+// author or was blocked by them. Mutes do not count: a muted user's posts
+// are only kept out of feeds.
+func (s *postService) checkNotBlocked(ctx context.Context, authorID, viewerID uuid.UUID) error {
+	blocked, err := s.relationRepo.IsBlocked(ctx, authorID, viewerID)
+	if err != nil {
+		return err
+	}
+	if blocked {
+		return errs.ErrPostNotFound
+	}
+	return nil
+}
+
+func (s *postService) checkAuthorActive(ctx context.Context, authorID, viewerID uuid.UUID) error {
+	if authorID == viewerID {
+		return nil
+	}
+	author, err := s.userRepo.GetUserProfileByUserID(ctx, authorID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return errs.ErrPostNotFound
+		}
+		return err
+	}
+	if !author.IsActive() {
+		return errs.ErrPostNotFound
+	}
+	return nil
+}
+
+// dropBlockedReplies removes replies by users viewerID blocked or was
+// blocked by. Lists filter replies in their query; this is for posts built
+// from GetPostWithDetails, whose response is shared through the cache.
+func (s *postService) dropBlockedReplies(ctx context.Context, viewerID uuid.UUID, posts []dto.PostResponse) error {
+	if viewerID == uuid.Nil {
+		return nil
+	}
+	authors, err := s.relationRepo.BlockedReplyAuthors(ctx, viewerID)
+	if err != nil || len(authors) == 0 {
+		return err
+	}
+
+	for i := range posts {
+		// A new slice, so the cached replies are left alone.
+		replies := make([]dto.ReplyResponse, 0, len(posts[i].Replies))
+		for _, r := range posts[i].Replies {
+			if !slices.Contains(authors, r.Author) {
+				replies = append(replies, r)
+			}
+		}
+		posts[i].Replies = replies
+	}
+	return nil
+}
@@ -0,0 +1,122 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/maulana1k/forum-app/internal/app/dto"
+	"github.com/maulana1k/forum-app/internal/domain/errs"
+	"github.com/maulana1k/forum-app/internal/domain/models"
+	"github.com/maulana1k/forum-app/internal/pkg/textdiff"
+	"gorm.io/gorm"
+)
+
+// checkEditAllowed lets the author edit a post within the edit window and
+// moderators, including the moderators of the post's community, edit it at
+// any time.
+func (s *postService) checkEditAllowed(ctx context.Context, post *models.Post, editorID uuid.UUID) error {
+	_, member, err := s.postCommunity(ctx, post.CommunityID, editorID)
+	if err != nil {
+		return err
+	}
+	if member.CanModerate() {
+		return nil
+	}
+
+	isAuthor := post.AuthorID == editorID
+	// Unpublished posts can be reworked freely until they go live.
+	withinWindow := !post.IsPublished() || s.editWindow <= 0 || time.Since(post.CreatedAt) <= s.editWindow
+	if isAuthor && withinWindow && !member.IsBanned() {
+		return nil
+	}
+
+	editor, err := s.userRepo.GetUserProfileByUserID(ctx, editorID)
+	if err != nil && !errors.Is(err, gorm.ErrRecordNotFound) {
+		return err
+	}
+	if editor != nil && editor.IsModerator() {
+		return nil
+	}
+
+	if !isAuthor {
+		return errs.ErrPostUpdateDenied
+	}
+	if member.IsBanned() {
+		return errs.ErrCommunityBanned
+	}
+	return errs.ErrPostEditExpired
+}
+
+// GetRevisions returns the edit history of a post with a diff for each
+// edit.
+func (s *postService) GetRevisions(ctx context.Context, postID, viewerID string) (*dto.PostRevisionsResponse, error) {
+	pid, err := uuid.Parse(postID)
+	if err != nil {
+		return nil, errs.ErrInvalidID.Wrap(err)
+	}
+
+	post, err := s.postRepo.GetPostByID(ctx, postID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, errs.ErrPostNotFound
+		}
+		return nil, err
+	}
+	vid, err := parseViewerID(viewerID)
+	if err != nil {
+		return nil, err
+	}
+	if !post.IsPublished() || !post.IsVisibleTo(vid) {
+		return nil, errs.ErrPostNotFound
+	}
+	if err := s.checkPostVisible(ctx, post.AuthorID, post.CommunityID, vid); err != nil {
+		return nil, err
+	}
+
+	revisions, err := s.postRepo.ListRevisions(ctx, pid)
+	if err != nil {
+		return nil, err
+	}
+
+	return &dto.PostRevisionsResponse{
+		PostID:    postID,
+		Revisions: mapRevisions(revisions),
+	}, nil
+}
+
+func mapRevisions(revisions []models.PostRevision) []dto.PostRevisionResponse {
+	out := make([]dto.PostRevisionResponse, len(revisions))
+	for i, r := range revisions {
+		out[i] = dto.PostRevisionResponse{
+			Revision:    r.Revision,
+			EditorID:    r.EditorID.String(),
+			Content:     r.Content,
+			ContentHTML: contentHTML(r.Content, r.ContentHTML),
+			Tags:        r.Tags,
+			ImageURL:    r.ImageURL,
+			CreatedAt:   r.CreatedAt,
+		}
+		if i > 0 {
+			out[i].Diff = diffRevisions(&revisions[i-1], &r)
+		}
+	}
+	return out
+}
+
+func diffRevisions(prev, next *models.PostRevision) *dto.RevisionDiff {
+	segments := textdiff.Words(prev.Content, next.Content)
+	diff := &dto.RevisionDiff{Content: make([]dto.DiffSegment, len(segments))}
+	for i, seg := range segments {
+		diff.Content[i] = dto.DiffSegment{Op: string(seg.Op), Text: seg.Text}
+	}
+
+	if prev.Tags != next.Tags {
+		diff.Tags = &dto.FieldChange{From: prev.Tags, To: next.Tags}
+	}
+	if prev.ImageURL != next.ImageURL {
+		diff.ImageURL = &dto.FieldChange{From: prev.ImageURL, To: next.ImageURL}
+	}
+	return diff
+}
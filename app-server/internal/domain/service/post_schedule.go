@@ -0,0 +1,156 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/maulana1k/forum-app/internal/app/dto"
+	"github.com/maulana1k/forum-app/internal/domain/errs"
+	"github.com/maulana1k/forum-app/internal/domain/events"
+	"github.com/maulana1k/forum-app/internal/domain/models"
+	"github.com/maulana1k/forum-app/internal/pkg/pagination"
+	"github.com/maulana1k/forum-app/internal/pkg/utils"
+	"github.com/maulana1k/forum-app/internal/provider/broker"
+	"github.com/maulana1k/forum-app/internal/provider/monitoring"
+	"gorm.io/gorm"
+)
+
+const (
+	maxScheduleAhead = 365 * 24 * time.Hour
+	publishBatch     = 100
+)
+
+// postSchedule decides the initial status of a new post.
+func postSchedule(req *dto.CreatePostRequest, now time.Time) (models.PostStatus, *time.Time, error) {
+	switch {
+	case req.Draft && req.PublishAt != nil:
+		return "", nil, errs.ErrDraftScheduled
+	case req.Draft:
+		return models.PostDraft, nil, nil
+	case req.PublishAt == nil:
+		return models.PostPublished, nil, nil
+	}
+
+	at := req.PublishAt.UTC()
+	if !at.After(now) || at.After(now.Add(maxScheduleAhead)) {
+		return "", nil, errs.ErrInvalidSchedule
+	}
+	return models.PostScheduled, &at, nil
+}
+
+// announcePost tells the rest of the system that post went live.
+func (s *postService) announcePost(ctx context.Context, post *models.Post) {
+	monitoring.PostsCreated.Inc()
+
+	created := events.PostCreated{PostID: post.ID.String(), AuthorID: post.AuthorID.String()}
+	if post.QuotedPostID != nil {
+		created.QuotedPostID = post.QuotedPostID.String()
+	}
+	s.events.Publish(ctx, created)
+
+	event := map[string]any{
+		"post_id": post.ID,
+		"author":  post.AuthorID,
+		"content": post.Content,
+	}
+	body, _ := json.Marshal(event)
+
+	producer := broker.NewProducer(s.broker, "post-create")
+
+	err := producer.Publish(ctx, body)
+	if err != nil {
+		utils.LoggerFromContext(ctx).WithError(err).WithField("post_id", post.ID).Error("failed to publish post message")
+	}
+
+	s.requestLinkPreview(ctx, post)
+}
+
+// GetDrafts lists the user's drafts and scheduled posts, newest first.
+func (s *postService) GetDrafts(ctx context.Context, userID string, query *dto.PostQueryParams) (*dto.PaginatedPostsResponse, error) {
+	uid, err := uuid.Parse(userID)
+	if err != nil {
+		return nil, errs.ErrInvalidToken.Wrap(err)
+	}
+
+	page, err := pagination.NewParams(query.Limit, 0, query.Cursor)
+	if err != nil {
+		return nil, err
+	}
+
+	posts, err := s.postRepo.GetDraftsByUserID(ctx, uid, page)
+	if err != nil {
+		return nil, err
+	}
+
+	resp := s.cursorPostsPage(posts, page)
+	if err := s.applyViewer(ctx, userID, resp.Posts); err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
+
+// PublishPost publishes the author's draft or scheduled post right away.
+func (s *postService) PublishPost(ctx context.Context, postID, userID string) (*dto.PostResponse, error) {
+	pid, uid, err := parseIDs(postID, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	post, err := s.postRepo.GetPostByID(ctx, postID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, errs.ErrPostNotFound
+		}
+		return nil, err
+	}
+	if post.AuthorID != uid {
+		return nil, errs.ErrPostPublishDenied
+	}
+
+	published, err := s.postRepo.PublishPost(ctx, pid)
+	if err != nil {
+		return nil, err
+	}
+	if !published {
+		return nil, errs.ErrPostPublished
+	}
+
+	post, err = s.postRepo.GetPostWithDetails(ctx, postID)
+	if err != nil {
+		return nil, err
+	}
+	s.announcePost(ctx, post)
+
+	posts := []dto.PostResponse{*s.MapPostToResponse(post)}
+	if err := s.dropBlockedReplies(ctx, uid, posts); err != nil {
+		return nil, err
+	}
+	if err := s.applyViewer(ctx, userID, posts); err != nil {
+		return nil, err
+	}
+	return &posts[0], nil
+}
+
+// PublishDuePosts publishes every scheduled post whose time has come and
+// returns how many went live.
+func (s *postService) PublishDuePosts(ctx context.Context) (int, error) {
+	total := 0
+	for {
+		posts, err := s.postRepo.ClaimDuePosts(ctx, time.Now().UTC(), publishBatch)
+		if err != nil {
+			return total, err
+		}
+
+		for i := range posts {
+			s.announcePost(ctx, &posts[i])
+		}
+		total += len(posts)
+
+		if len(posts) < publishBatch {
+			return total, nil
+		}
+	}
+}
@@ -1,289 +1,720 @@
 package service
 
 import (
-	"encoding/json"
+	"context"
 	"errors"
-	"log"
+	"strconv"
 	"time"
 
 	"github.com/google/uuid"
 	"github.com/maulana1k/forum-app/internal/app/dto"
+	"github.com/maulana1k/forum-app/internal/domain/errs"
+	"github.com/maulana1k/forum-app/internal/domain/events"
 	"github.com/maulana1k/forum-app/internal/domain/models"
 	"github.com/maulana1k/forum-app/internal/domain/repository"
+	"github.com/maulana1k/forum-app/internal/pkg/markdown"
+	"github.com/maulana1k/forum-app/internal/pkg/pagination"
 	"github.com/maulana1k/forum-app/internal/provider/broker"
+	"github.com/maulana1k/forum-app/internal/provider/cache"
 	"gorm.io/gorm"
 )
 
 type PostService interface {
-	CreatePost(userID string, req *dto.CreatePostRequest) (*dto.PostResponse, error)
-	GetPostByID(id string) (*dto.PostResponse, error)
-	GetAllPosts(page, limit int) (*dto.PaginatedPostsResponse, error)
-	UpdatePost(postID, userID string, req *dto.UpdatePostRequest) (*dto.PostResponse, error)
-	DeletePost(postID, userID string) error
-	GetPostsByUserID(userID string, page, limit int) (*dto.PaginatedPostsResponse, error)
-	LikePost(postID, userID string) error
-	UnlikePost(postID, userID string) error
-	BookmarkPost(postID, userID string) error
-	UnbookmarkPost(postID, userID string) error
+	CreatePost(ctx context.Context, userID string, req *dto.CreatePostRequest) (*dto.PostResponse, error)
+	GetPostByID(ctx context.Context, id, viewerID string) (*dto.PostResponse, error)
+	GetAllPosts(ctx context.Context, query *dto.PostQueryParams, viewerID string) (*dto.PaginatedPostsResponse, error)
+	// GetPostsByIDs returns the listed posts as the main feed would show
+	// them to viewerID, in the order given. Posts they may not see are
+	// left out.
+	GetPostsByIDs(ctx context.Context, ids []uuid.UUID, viewerID string) ([]dto.PostResponse, error)
+	// ListPostsSince returns up to limit main feed posts for backfills,
+	// oldest first, starting after the post created at since with ID
+	// after. uuid.Nil as after starts with the posts created at since.
+	ListPostsSince(ctx context.Context, since time.Time, after uuid.UUID, limit int) ([]dto.PostResponse, error)
+	UpdatePost(ctx context.Context, postID, userID string, req *dto.UpdatePostRequest) (*dto.PostResponse, error)
+	DeletePost(ctx context.Context, postID, userID string) error
+	GetPostsByUserID(ctx context.Context, userID string, query *dto.PostQueryParams, viewerID string) (*dto.PaginatedPostsResponse, error)
+	GetCommunityPosts(ctx context.Context, slug string, query *dto.PostQueryParams, viewerID string) (*dto.PaginatedPostsResponse, error)
+	GetReplies(ctx context.Context, postID string, query *dto.PostQueryParams, viewerID string) (*dto.PaginatedRepliesResponse, error)
+	GetRevisions(ctx context.Context, postID, viewerID string) (*dto.PostRevisionsResponse, error)
+	GetDrafts(ctx context.Context, userID string, query *dto.PostQueryParams) (*dto.PaginatedPostsResponse, error)
+	PublishPost(ctx context.Context, postID, userID string) (*dto.PostResponse, error)
+	PublishDuePosts(ctx context.Context) (int, error)
+	LikePost(ctx context.Context, postID, userID string) error
+	UnlikePost(ctx context.Context, postID, userID string) error
+	React(ctx context.Context, postID, userID, reaction string) error
+	Unreact(ctx context.Context, postID, userID string) error
+	GetReactionUsers(ctx context.Context, postID, reaction string, query *dto.PostQueryParams, viewerID string) (*dto.PaginatedReactionUsersResponse, error)
+	Vote(ctx context.Context, postID, userID string, req *dto.VoteRequest) (*dto.PollResponse, error)
+	Unvote(ctx context.Context, postID, userID string) (*dto.PollResponse, error)
+	ClosePolls(ctx context.Context) (int, error)
+	BookmarkPost(ctx context.Context, postID, userID string) error
+	UnbookmarkPost(ctx context.Context, postID, userID string) error
+	ReconcileCounters(ctx context.Context) (int, error)
 }
 
+// postCacheTTL bounds how stale author details embedded in a cached post
+// can get; everything else is invalidated by events.
+const postCacheTTL = 5 * time.Minute
+
 type postService struct {
-	postRepo repository.PostRepository
-	broker   *broker.RabbitMQ
+	postRepo      repository.PostRepository
+	reactionRepo  repository.ReactionRepository
+	pollRepo      repository.PollRepository
+	mediaRepo     repository.MediaRepository
+	userRepo      repository.UserRepository
+	communityRepo repository.CommunityRepository
+	relationRepo  repository.RelationRepository
+	mutedWordRepo repository.MutedWordRepository
+	settingsRepo  repository.SettingsRepository
+	broker        *broker.RabbitMQ
+	linkPreviews  *broker.Producer
+	events        *events.Bus
+	posts         *cache.Loader[*dto.PostResponse]
+	reactions     map[string]bool
+	editWindow    time.Duration
 }
 
-func NewPostService(postRepo repository.PostRepository, brokerc *broker.RabbitMQ) PostService {
-	return &postService{
-		postRepo: postRepo,
-		broker:   brokerc,
-	}
+// PostOptions tunes the post service from configuration.
+type PostOptions struct {
+	// Reactions is the allowed reaction palette; empty means
+	// models.DefaultReactions.
+	Reactions []string
+	// EditWindow is how long after publishing an author may still edit a
+	// post. Zero means no limit. Moderators are not bound by it.
+	EditWindow time.Duration
+}
+
+// NewPostService builds the post service.
+func NewPostService(postRepo repository.PostRepository, reactionRepo repository.ReactionRepository, pollRepo repository.PollRepository, mediaRepo repository.MediaRepository, userRepo repository.UserRepository, communityRepo repository.CommunityRepository, relationRepo repository.RelationRepository, mutedWordRepo repository.MutedWordRepository, settingsRepo repository.SettingsRepository, brokerc *broker.RabbitMQ, linkPreviews *broker.Producer, bus *events.Bus, store cache.Cache, opts PostOptions) PostService {
+	reactions := opts.Reactions
+	if len(reactions) == 0 {
+		reactions = models.DefaultReactions
+	}
+
+	s := &postService{
+		postRepo:      postRepo,
+		reactionRepo:  reactionRepo,
+		pollRepo:      pollRepo,
+		mediaRepo:     mediaRepo,
+		userRepo:      userRepo,
+		communityRepo: communityRepo,
+		relationRepo:  relationRepo,
+		mutedWordRepo: mutedWordRepo,
+		settingsRepo:  settingsRepo,
+		broker:        brokerc,
+		linkPreviews:  linkPreviews,
+		events:        bus,
+		posts:         cache.NewLoader[*dto.PostResponse](store, "post", postCacheTTL),
+		reactions:     make(map[string]bool, len(reactions)),
+		editWindow:    opts.EditWindow,
+	}
+	for _, r := range reactions {
+		s.reactions[r] = true
+	}
+
+	bus.Subscribe(s.invalidatePost,
+		events.PostCreatedEvent,
+		events.PostUpdatedEvent,
+		events.PostDeletedEvent,
+		events.PostReactedEvent,
+		events.PostUnreactedEvent,
+		events.PollVotedEvent,
+		events.PollClosedEvent,
+	)
+	return s
 }
 
-func (s *postService) CreatePost(userID string, req *dto.CreatePostRequest) (*dto.PostResponse, error) {
-	id, _ := uuid.Parse(userID)
+func (s *postService) CreatePost(ctx context.Context, userID string, req *dto.CreatePostRequest) (*dto.PostResponse, error) {
+	id, err := parseUserID(userID)
+	if err != nil {
+		return nil, err
+	}
+	status, publishAt, err := postSchedule(req, time.Now())
+	if err != nil {
+		return nil, err
+	}
+	visibility, err := s.postVisibility(ctx, id, req.Visibility)
+	if err != nil {
+		return nil, err
+	}
+
 	post := &models.Post{
-		Content:  req.Content,
-		Tags:     req.Tags,
-		ImageURL: req.ImageURL,
-		AuthorID: id,
+		Content:     req.Content,
+		ContentHTML: markdown.Render(req.Content),
+		Tags:        req.Tags,
+		AuthorID:    id,
+		Status:      status,
+		Visibility:  visibility,
+		PublishAt:   publishAt,
+	}
+
+	if req.MediaID != "" {
+		media, err := resolveMedia(ctx, s.mediaRepo, id, req.MediaID)
+		if err != nil {
+			return nil, err
+		}
+		post.MediaID = &media.ID
+		post.ImageURL = media.URL
+	}
+
+	if req.CommunityID != "" {
+		cid, err := uuid.Parse(req.CommunityID)
+		if err != nil {
+			return nil, errs.ErrInvalidID.Wrap(err)
+		}
+		community, member, err := s.postCommunity(ctx, &cid, id)
+		if err != nil {
+			return nil, err
+		}
+		if err := checkCommunityPost(community, member); err != nil {
+			return nil, err
+		}
+		post.CommunityID = &community.ID
 	}
 
 	if req.QuotedPostID != "" {
 		// Verify quoted post exists
-		quotedPost, err := s.postRepo.GetPostByID(req.QuotedPostID)
+		quotedPost, err := s.postRepo.GetPostByID(ctx, req.QuotedPostID)
 		if err != nil {
-			return nil, errors.New("quoted post not found")
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				return nil, errs.ErrQuotedPostNotFound
+			}
+			return nil, err
+		}
+		// A quote shows the quoted post to everyone who sees the quote.
+		if !quotedPost.IsPublished() || quotedPost.Visibility == models.PostOnlyMe {
+			return nil, errs.ErrQuotedPostNotFound
+		}
+		if err := s.checkPostVisible(ctx, quotedPost.AuthorID, quotedPost.CommunityID, id); err != nil {
+			if errors.Is(err, errs.ErrPostNotFound) {
+				return nil, errs.ErrQuotedPostNotFound
+			}
+			return nil, err
 		}
 		post.QuotedPostID = &quotedPost.ID
 	}
 
-	if err := s.postRepo.CreatePost(post); err != nil {
+	if req.Poll != nil {
+		// A scheduled poll must still be open once it is published.
+		opensAt := time.Now()
+		if publishAt != nil {
+			opensAt = *publishAt
+		}
+		poll, err := newPoll(req.Poll, opensAt)
+		if err != nil {
+			return nil, err
+		}
+		post.Poll = poll
+	}
+
+	if err := s.postRepo.CreatePost(ctx, post); err != nil {
 		return nil, err
 	}
+	if post.IsPublished() {
+		s.announcePost(ctx, post)
+	}
 
-	event := map[string]any{
-		"post_id": post.ID,
-		"author":  post.AuthorID,
-		"content": post.Content,
+	posts := []dto.PostResponse{*s.MapPostToResponse(post)}
+	if err := s.applyPollViewer(ctx, id, posts); err != nil {
+		return nil, err
 	}
-	body, _ := json.Marshal(event)
+	return &posts[0], nil
+}
 
-	producer := broker.NewProducer(s.broker, "post-create")
+// postVisibility returns the visibility a new post gets: the requested
+// one, or else the author's default.
+func (s *postService) postVisibility(ctx context.Context, authorID uuid.UUID, requested string) (models.PostVisibility, error) {
+	if requested != "" {
+		return models.PostVisibility(requested), nil
+	}
+	settings, err := s.settingsRepo.GetSettings(ctx, authorID)
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return models.DefaultUserSettings(authorID).DefaultPostVisibility, nil
+	}
+	if err != nil {
+		return "", err
+	}
+	return settings.DefaultPostVisibility, nil
+}
 
-	err := producer.Publish(body)
+func (s *postService) GetPostByID(ctx context.Context, id, viewerID string) (*dto.PostResponse, error) {
+	cached, err := s.posts.Get(ctx, id, func(ctx context.Context) (*dto.PostResponse, error) {
+		post, err := s.postRepo.GetPostWithDetails(ctx, id)
+		if err != nil {
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				return nil, errs.ErrPostNotFound
+			}
+			return nil, err
+		}
+		return s.MapPostToResponse(post), nil
+	})
 	if err != nil {
-		log.Printf("Failed to publish post message: %v", err)
+		return nil, err
+	}
+	// Drafts, scheduled posts and posts kept to the author are only
+	// visible to their author.
+	private := cached.Status != "" && cached.Status != string(models.PostPublished) ||
+		cached.Visibility == string(models.PostOnlyMe)
+	if private && cached.Author.ID != viewerID {
+		return nil, errs.ErrPostNotFound
+	}
+	// Checked against the database, not the cached response, so blocks
+	// and a community going private take effect at once.
+	authorID, _ := uuid.Parse(cached.Author.ID)
+	var communityID *uuid.UUID
+	if cached.Community != nil {
+		cid, _ := uuid.Parse(cached.Community.ID)
+		communityID = &cid
+	}
+	vid, err := parseViewerID(viewerID)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.checkPostVisible(ctx, authorID, communityID, vid); err != nil {
+		return nil, err
 	}
 
-	return s.MapPostToResponse(post), nil
+	// The cached value is shared; viewer specific fields go on a copy.
+	posts := []dto.PostResponse{*cached}
+	if err := s.dropBlockedReplies(ctx, vid, posts); err != nil {
+		return nil, err
+	}
+	if err := s.applyViewer(ctx, viewerID, posts); err != nil {
+		return nil, err
+	}
+	return &posts[0], nil
 }
 
-func (s *postService) GetPostByID(id string) (*dto.PostResponse, error) {
-	post, err := s.postRepo.GetPostWithDetails(id)
+func (s *postService) GetAllPosts(ctx context.Context, query *dto.PostQueryParams, viewerID string) (*dto.PaginatedPostsResponse, error) {
+	page, err := pagination.NewParams(query.Limit, query.Page, query.Cursor)
 	if err != nil {
-		if errors.Is(err, gorm.ErrRecordNotFound) {
-			return nil, errors.New("post not found")
-		}
 		return nil, err
 	}
 
-	return s.MapPostToResponse(post), nil
-}
+	vid, err := parseViewerID(viewerID)
+	if err != nil {
+		return nil, err
+	}
+	posts, err := s.postRepo.GetAllPosts(ctx, page, vid)
+	if err != nil {
+		return nil, err
+	}
+
+	var resp *dto.PaginatedPostsResponse
+	if page.IsOffset() {
+		total, err := s.postRepo.CountPosts(ctx, vid)
+		if err != nil {
+			return nil, err
+		}
+		resp = s.offsetPostsPage(posts, total, page)
+	} else {
+		resp = s.cursorPostsPage(posts, page)
+	}
 
-func (s *postService) GetAllPosts(page, limit int) (*dto.PaginatedPostsResponse, error) {
-	if page <= 0 {
-		page = 1
+	if err := s.applyViewer(ctx, viewerID, resp.Posts); err != nil {
+		return nil, err
 	}
-	if limit <= 0 || limit > 100 {
-		limit = 10
+	if err := s.applyMutedWords(ctx, viewerID, resp.Posts); err != nil {
+		return nil, err
 	}
+	return resp, nil
+}
 
-	offset := (page - 1) * limit
-	posts, total, err := s.postRepo.GetAllPosts(offset, limit)
+func (s *postService) GetPostsByIDs(ctx context.Context, ids []uuid.UUID, viewerID string) ([]dto.PostResponse, error) {
+	vid, err := parseViewerID(viewerID)
+	if err != nil {
+		return nil, err
+	}
+	posts, err := s.postRepo.GetPostsByIDs(ctx, ids, vid)
 	if err != nil {
 		return nil, err
 	}
 
-	postResponses := make([]dto.PostResponse, len(posts))
-	for i, post := range posts {
-		postResponses[i] = *s.MapPostToResponse(&post)
+	byID := make(map[uuid.UUID]*models.Post, len(posts))
+	for i := range posts {
+		byID[posts[i].ID] = &posts[i]
+	}
+	resp := make([]dto.PostResponse, 0, len(posts))
+	for _, id := range ids {
+		if p, ok := byID[id]; ok {
+			resp = append(resp, *s.MapPostToResponse(p))
+			// A repeated ID is only shown once.
+			delete(byID, id)
+		}
 	}
 
-	totalPages := (int(total) + limit - 1) / limit
+	if err := s.applyViewer(ctx, viewerID, resp); err != nil {
+		return nil, err
+	}
+	if err := s.applyMutedWords(ctx, viewerID, resp); err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
 
-	return &dto.PaginatedPostsResponse{
-		Posts:       postResponses,
-		Total:       int(total),
-		Page:        page,
-		Limit:       limit,
-		TotalPages:  totalPages,
-		HasNextPage: page < totalPages,
-		HasPrevPage: page > 1,
-	}, nil
+func (s *postService) ListPostsSince(ctx context.Context, since time.Time, after uuid.UUID, limit int) ([]dto.PostResponse, error) {
+	posts, err := s.postRepo.ListPostsSince(ctx, since, after, limit)
+	if err != nil {
+		return nil, err
+	}
+	return s.mapPosts(posts), nil
 }
 
-func (s *postService) UpdatePost(postID, userID string, req *dto.UpdatePostRequest) (*dto.PostResponse, error) {
+func (s *postService) UpdatePost(ctx context.Context, postID, userID string, req *dto.UpdatePostRequest) (*dto.PostResponse, error) {
 	// Check if post exists and user is the author
-	existingPost, err := s.postRepo.GetPostByID(postID)
+	existingPost, err := s.postRepo.GetPostByID(ctx, postID)
 	if err != nil {
 		if errors.Is(err, gorm.ErrRecordNotFound) {
-			return nil, errors.New("post not found")
+			return nil, errs.ErrPostNotFound
 		}
 		return nil, err
 	}
 
-	// Note: You'll need to implement proper user authorization check
-	if existingPost.AuthorID.String() != userID {
-		return nil, errors.New("unauthorized to update this post")
+	editorID, err := parseUserID(userID)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.checkEditAllowed(ctx, existingPost, editorID); err != nil {
+		return nil, err
 	}
 
 	updateData := &models.Post{}
 	if req.Content != nil {
 		updateData.Content = *req.Content
+		updateData.ContentHTML = markdown.Render(*req.Content)
 	}
 	if req.Tags != nil {
 		updateData.Tags = *req.Tags
 	}
-	if req.ImageURL != nil {
-		updateData.ImageURL = *req.ImageURL
+	if req.MediaID != nil {
+		// Attached media must belong to the author, not the moderator.
+		media, err := resolveMedia(ctx, s.mediaRepo, existingPost.AuthorID, *req.MediaID)
+		if err != nil {
+			return nil, err
+		}
+		updateData.MediaID = &media.ID
+		updateData.ImageURL = media.URL
 	}
-	updateData.UpdatedAt = time.Now()
 
-	if err := s.postRepo.UpdatePost(postID, updateData); err != nil {
+	if err := s.postRepo.UpdatePost(ctx, postID, updateData, editorID); err != nil {
 		return nil, err
 	}
+	s.events.Publish(ctx, events.PostUpdated{PostID: postID})
 
-	updatedPost, err := s.postRepo.GetPostWithDetails(postID)
+	updatedPost, err := s.postRepo.GetPostWithDetails(ctx, postID)
 	if err != nil {
 		return nil, err
 	}
 
-	return s.MapPostToResponse(updatedPost), nil
+	posts := []dto.PostResponse{*s.MapPostToResponse(updatedPost)}
+	if err := s.dropBlockedReplies(ctx, editorID, posts); err != nil {
+		return nil, err
+	}
+	if err := s.applyViewer(ctx, userID, posts); err != nil {
+		return nil, err
+	}
+	return &posts[0], nil
 }
 
-func (s *postService) DeletePost(postID, userID string) error {
+func (s *postService) DeletePost(ctx context.Context, postID, userID string) error {
+	uid, err := parseUserID(userID)
+	if err != nil {
+		return err
+	}
+
 	// Check if post exists and user is the author
-	existingPost, err := s.postRepo.GetPostByID(postID)
+	existingPost, err := s.postRepo.GetPostByID(ctx, postID)
 	if err != nil {
 		if errors.Is(err, gorm.ErrRecordNotFound) {
-			return errors.New("post not found")
+			return errs.ErrPostNotFound
+		}
+		return err
+	}
+
+	if existingPost.AuthorID != uid {
+		// Community moderators may remove posts from their community.
+		_, member, err := s.postCommunity(ctx, existingPost.CommunityID, uid)
+		if err != nil {
+			return err
 		}
+		if !member.CanModerate() {
+			return errs.ErrPostDeleteDenied
+		}
+	}
+
+	if err := s.postRepo.DeletePost(ctx, postID); err != nil {
 		return err
 	}
+	s.events.Publish(ctx, events.PostDeleted{PostID: postID, AuthorID: existingPost.AuthorID.String()})
+
+	return nil
+}
 
-	// Note: Implement proper user authorization check
-	if existingPost.AuthorID.String() != userID {
-		return errors.New("unauthorized to delete this post")
+func (s *postService) GetPostsByUserID(ctx context.Context, userID string, query *dto.PostQueryParams, viewerID string) (*dto.PaginatedPostsResponse, error) {
+	page, err := pagination.NewParams(query.Limit, query.Page, query.Cursor)
+	if err != nil {
+		return nil, err
 	}
 
-	return s.postRepo.DeletePost(postID)
+	vid, err := parseViewerID(viewerID)
+	if err != nil {
+		return nil, err
+	}
+	posts, err := s.postRepo.GetPostsByUserID(ctx, userID, page, vid)
+	if err != nil {
+		return nil, err
+	}
+
+	var resp *dto.PaginatedPostsResponse
+	if page.IsOffset() {
+		total, err := s.postRepo.CountPostsByUserID(ctx, userID, vid)
+		if err != nil {
+			return nil, err
+		}
+		resp = s.offsetPostsPage(posts, total, page)
+	} else {
+		resp = s.cursorPostsPage(posts, page)
+	}
+
+	if err := s.applyViewer(ctx, viewerID, resp.Posts); err != nil {
+		return nil, err
+	}
+	if err := s.applyMutedWords(ctx, viewerID, resp.Posts); err != nil {
+		return nil, err
+	}
+	return resp, nil
 }
 
-func (s *postService) GetPostsByUserID(userID string, page, limit int) (*dto.PaginatedPostsResponse, error) {
-	if page <= 0 {
-		page = 1
+func (s *postService) GetReplies(ctx context.Context, postID string, query *dto.PostQueryParams, viewerID string) (*dto.PaginatedRepliesResponse, error) {
+	page, err := pagination.NewParams(query.Limit, query.Page, query.Cursor)
+	if err != nil {
+		return nil, err
 	}
-	if limit <= 0 || limit > 100 {
-		limit = 10
+
+	post, err := s.postRepo.GetPostByID(ctx, postID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, errs.ErrPostNotFound
+		}
+		return nil, err
+	}
+	vid, err := parseViewerID(viewerID)
+	if err != nil {
+		return nil, err
+	}
+	if !post.IsVisibleTo(vid) {
+		return nil, errs.ErrPostNotFound
+	}
+	if err := s.checkPostVisible(ctx, post.AuthorID, post.CommunityID, vid); err != nil {
+		return nil, err
 	}
 
-	offset := (page - 1) * limit
-	posts, total, err := s.postRepo.GetPostsByUserID(userID, offset, limit)
+	replies, err := s.postRepo.GetRepliesByPostID(ctx, postID, page, vid)
 	if err != nil {
 		return nil, err
 	}
 
-	postResponses := make([]dto.PostResponse, len(posts))
-	for i, post := range posts {
-		postResponses[i] = *s.MapPostToResponse(&post)
+	matcher, err := mutedWordMatcher(ctx, s.mutedWordRepo, vid)
+	if err != nil {
+		return nil, err
 	}
 
-	totalPages := (int(total) + limit - 1) / limit
+	if page.IsOffset() {
+		total, err := s.postRepo.CountRepliesByPostID(ctx, postID, vid)
+		if err != nil {
+			return nil, err
+		}
+		totalPages := (int(total) + page.Limit - 1) / page.Limit
+		responses := mapReplies(replies)
+		filterReplies(matcher, responses)
+		return &dto.PaginatedRepliesResponse{
+			Replies:     responses,
+			Total:       int(total),
+			Page:        page.Page,
+			Limit:       page.Limit,
+			TotalPages:  totalPages,
+			HasNextPage: page.Page < totalPages,
+			HasPrevPage: page.Page > 1,
+		}, nil
+	}
+
+	window := pagination.Window(replies, page, func(r models.Replies) pagination.Cursor {
+		return pagination.Cursor{CreatedAt: r.CreatedAt, ID: strconv.FormatUint(uint64(r.ID), 10)}
+	})
+	responses := mapReplies(window.Items)
+	filterReplies(matcher, responses)
+	return &dto.PaginatedRepliesResponse{
+		Replies:     responses,
+		Limit:       page.Limit,
+		HasNextPage: window.HasNext,
+		HasPrevPage: window.HasPrev,
+		NextCursor:  window.NextCursor,
+		PrevCursor:  window.PrevCursor,
+	}, nil
+}
+
+// reconcileBatchSize bounds how many posts one reconciliation UPDATE
+// touches, so a run never holds locks on the whole table.
+const reconcileBatchSize = 500
+
+// ReconcileCounters walks every post, repairs engagement counters that
+// drifted from the interaction tables and returns how many were fixed.
+func (s *postService) ReconcileCounters(ctx context.Context) (int, error) {
+	var (
+		after uuid.UUID
+		fixed int
+	)
+	for {
+		ids, err := s.postRepo.ListPostIDs(ctx, after, reconcileBatchSize)
+		if err != nil {
+			return fixed, err
+		}
+		if len(ids) == 0 {
+			return fixed, nil
+		}
+
+		repaired, err := s.postRepo.ReconcileCounters(ctx, ids)
+		if err != nil {
+			return fixed, err
+		}
+		drifted, err := s.reactionRepo.ReconcileCounts(ctx, ids)
+		if err != nil {
+			return fixed, err
+		}
+		repaired = append(repaired, drifted...)
+
+		if len(repaired) > 0 {
+			keys := make([]string, len(repaired))
+			for i, id := range repaired {
+				keys[i] = id.String()
+			}
+			s.posts.Invalidate(ctx, keys...)
+			fixed += len(repaired)
+		}
+
+		after = ids[len(ids)-1]
+	}
+}
+
+// invalidatePost drops cached posts whose content or counters changed.
+func (s *postService) invalidatePost(ctx context.Context, event events.Event) error {
+	switch e := event.(type) {
+	case events.PostCreated:
+		// The author may have a cached copy of the unpublished post.
+		s.posts.Invalidate(ctx, e.PostID)
+		// A quote bumps the quoted post's repost count.
+		if e.QuotedPostID != "" {
+			s.posts.Invalidate(ctx, e.QuotedPostID)
+		}
+	case events.PostUpdated:
+		s.posts.Invalidate(ctx, e.PostID)
+	case events.PostDeleted:
+		s.posts.Invalidate(ctx, e.PostID)
+	case events.PostReacted:
+		s.posts.Invalidate(ctx, e.PostID)
+	case events.PostUnreacted:
+		s.posts.Invalidate(ctx, e.PostID)
+	case events.PollVoted:
+		s.posts.Invalidate(ctx, e.PostID)
+	case events.PollClosed:
+		s.posts.Invalidate(ctx, e.PostID)
+	}
+	return nil
+}
+
+func (s *postService) offsetPostsPage(posts []models.Post, total int64, page pagination.Params) *dto.PaginatedPostsResponse {
+	totalPages := (int(total) + page.Limit - 1) / page.Limit
 
 	return &dto.PaginatedPostsResponse{
-		Posts:       postResponses,
+		Posts:       s.mapPosts(posts),
 		Total:       int(total),
-		Page:        page,
-		Limit:       limit,
+		Page:        page.Page,
+		Limit:       page.Limit,
 		TotalPages:  totalPages,
-		HasNextPage: page < totalPages,
-		HasPrevPage: page > 1,
-	}, nil
+		HasNextPage: page.Page < totalPages,
+		HasPrevPage: page.Page > 1,
+	}
 }
 
-func (s *postService) LikePost(postID, userID string) error {
-	// Check if post exists
-	_, err := s.postRepo.GetPostByID(postID)
-	if err != nil {
-		return errors.New("post not found")
-	}
+func (s *postService) cursorPostsPage(posts []models.Post, page pagination.Params) *dto.PaginatedPostsResponse {
+	window := pagination.Window(posts, page, func(p models.Post) pagination.Cursor {
+		return pagination.Cursor{CreatedAt: p.CreatedAt, ID: p.ID.String()}
+	})
 
-	// Check if already liked
-	isLiked, err := s.postRepo.IsPostLikedByUser(postID, userID)
-	if err != nil {
-		return err
-	}
-	if isLiked {
-		return errors.New("post already liked")
+	return &dto.PaginatedPostsResponse{
+		Posts:       s.mapPosts(window.Items),
+		Limit:       page.Limit,
+		HasNextPage: window.HasNext,
+		HasPrevPage: window.HasPrev,
+		NextCursor:  window.NextCursor,
+		PrevCursor:  window.PrevCursor,
 	}
+}
 
-	return s.postRepo.LikePost(postID, userID)
+func (s *postService) mapPosts(posts []models.Post) []dto.PostResponse {
+	postResponses := make([]dto.PostResponse, len(posts))
+	for i, post := range posts {
+		postResponses[i] = *s.MapPostToResponse(&post)
+	}
+	return postResponses
 }
 
-func (s *postService) UnlikePost(postID, userID string) error {
-	// Check if post is liked
-	isLiked, err := s.postRepo.IsPostLikedByUser(postID, userID)
+func (s *postService) BookmarkPost(ctx context.Context, postID, userID string) error {
+	_, uid, err := parseIDs(postID, userID)
 	if err != nil {
 		return err
 	}
-	if !isLiked {
-		return errors.New("post not liked")
-	}
-
-	return s.postRepo.UnlikePost(postID, userID)
-}
 
-func (s *postService) BookmarkPost(postID, userID string) error {
-	// Check if post exists
-	_, err := s.postRepo.GetPostByID(postID)
+	// Check if post exists and the user may see it
+	post, err := s.postRepo.GetPostByID(ctx, postID)
 	if err != nil {
-		return errors.New("post not found")
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return errs.ErrPostNotFound
+		}
+		return err
+	}
+	if !post.IsVisibleTo(uid) {
+		return errs.ErrPostNotFound
+	}
+	if err := s.checkPostVisible(ctx, post.AuthorID, post.CommunityID, uid); err != nil {
+		return err
 	}
 
 	// Check if already bookmarked
-	isBookmarked, err := s.postRepo.IsPostBookmarkedByUser(postID, userID)
+	isBookmarked, err := s.postRepo.IsPostBookmarkedByUser(ctx, postID, userID)
 	if err != nil {
 		return err
 	}
 	if isBookmarked {
-		return errors.New("post already bookmarked")
+		return errs.ErrPostAlreadySaved
 	}
 
-	return s.postRepo.BookmarkPost(postID, userID)
+	return s.postRepo.BookmarkPost(ctx, postID, userID)
 }
 
-func (s *postService) UnbookmarkPost(postID, userID string) error {
+func (s *postService) UnbookmarkPost(ctx context.Context, postID, userID string) error {
 	// Check if post is bookmarked
-	isBookmarked, err := s.postRepo.IsPostBookmarkedByUser(postID, userID)
+	isBookmarked, err := s.postRepo.IsPostBookmarkedByUser(ctx, postID, userID)
 	if err != nil {
 		return err
 	}
 	if !isBookmarked {
-		return errors.New("post not bookmarked")
+		return errs.ErrPostNotSaved
 	}
 
-	return s.postRepo.UnbookmarkPost(postID, userID)
+	return s.postRepo.UnbookmarkPost(ctx, postID, userID)
 }
 
 // func (s *postService) RepostPost(postID, userID uint) error {
 // 	// Check if post exists
-// 	_, err := s.postRepo.GetPostByID(postID)
+// 	_, err := s.postRepo.GetPostByID(ctx, postID)
 // 	if err != nil {
 // 		return errors.New("post not found")
 // 	}
 
 // 	// Check if already reposted
-// 	isReposted, err := s.postRepo.IsPostRepostedByUser(postID, userID)
+// 	isReposted, err := s.postRepo.IsPostRepostedByUser(ctx, postID, userID)
 // 	if err != nil {
 // 		return err
 // 	}
@@ -291,12 +722,12 @@ func (s *postService) UnbookmarkPost(postID, userID string) error {
 // 		return errors.New("post already reposted")
 // 	}
 
-// 	return s.postRepo.RepostByUser(postID, userID)
+// 	return s.postRepo.RepostByUser(ctx, postID, userID)
 // }
 
 // func (s *postService) UnrepostPost(postID, userID uint) error {
 // 	// Check if post is reposted
-// 	isReposted, err := s.postRepo.IsPostRepostedByUser(postID, userID)
+// 	isReposted, err := s.postRepo.IsPostRepostedByUser(ctx, postID, userID)
 // 	if err != nil {
 // 		return err
 // 	}
@@ -304,25 +735,18 @@ func (s *postService) UnbookmarkPost(postID, userID string) error {
 // 		return errors.New("post not reposted")
 // 	}
 
-// 	return s.postRepo.UnrepostByUser(postID, userID)
+// 	return s.postRepo.UnrepostByUser(ctx, postID, userID)
 // }
 
 func (s *postService) MapPostToResponse(p *models.Post) *dto.PostResponse {
-	author := dto.PostAuthor{
-		ID:          p.Author.ID.String(),
-		Username:    p.Author.Username,
-		DisplayName: p.Author.DisplayName,
-		AvatarURL:   p.Author.AvatarURL,
-		Bio:         p.Author.Bio,
-	}
+	author := mapAuthor(p.Author)
 
-	replies := make([]dto.ReplyResponse, len(p.Replies))
-	for i, r := range p.Replies {
-		replies[i] = dto.ReplyResponse{
-			ID:        r.ID,
-			Content:   r.Content,
-			Author:    r.Author,
-			CreatedAt: r.CreatedAt,
+	replies := mapReplies(p.Replies)
+
+	reactions := make(map[string]int, len(p.ReactionCounts))
+	for _, rc := range p.ReactionCounts {
+		if rc.Count > 0 {
+			reactions[rc.Reaction] = rc.Count
 		}
 	}
 
@@ -332,21 +756,66 @@ func (s *postService) MapPostToResponse(p *models.Post) *dto.PostResponse {
 	}
 
 	return &dto.PostResponse{
-		ID:           p.ID.String(),
-		Content:      p.Content,
-		Tags:         p.Tags,
-		ImageURL:     p.ImageURL,
-		CreatedAt:    p.CreatedAt,
-		UpdatedAt:    p.UpdatedAt,
-		Author:       author,
-		Replies:      replies,
-		QuotedPost:   quotedPostID,
-		LikesCount:   p.LikesCount,
-		RepliesCount: p.RepliesCount,
-		RepostsCount: p.RepostsCount,
-		// set these flags according to your business logic
+		ID:            p.ID.String(),
+		Content:       p.Content,
+		ContentHTML:   contentHTML(p.Content, p.ContentHTML),
+		Tags:          p.Tags,
+		ImageURL:      p.ImageURL,
+		Media:         mapMedia(p.Media),
+		LinkPreview:   mapLinkPreview(p.LinkPreview),
+		CreatedAt:     p.CreatedAt,
+		UpdatedAt:     p.UpdatedAt,
+		Author:        author,
+		Replies:       replies,
+		QuotedPost:    quotedPostID,
+		LikesCount:    p.LikesCount,
+		RepliesCount:  p.RepliesCount,
+		RepostsCount:  p.RepostsCount,
+		Reactions:     reactions,
+		Poll:          mapPoll(p.Poll),
+		Community:     mapCommunitySummary(p.Community),
+		EditedAt:      p.EditedAt,
+		RevisionCount: p.RevisionCount,
+		Status:        string(p.Status),
+		Visibility:    string(p.Visibility),
+		PublishAt:     p.PublishAt,
+		// IsLiked is set per viewer by applyViewer. Bookmarks have no route
+		// and reposts are not tracked per user yet, so those stay false.
 		IsLiked:      false,
 		IsBookmarked: false,
 		IsReposted:   false,
 	}
 }
+
+func mapReplies(replies []models.Replies) []dto.ReplyResponse {
+	responses := make([]dto.ReplyResponse, len(replies))
+	for i, r := range replies {
+		responses[i] = dto.ReplyResponse{
+			ID:        r.ID,
+			Content:   r.Content,
+			Author:    r.Author,
+			CreatedAt: r.CreatedAt,
+			UpdatedAt: r.UpdatedAt,
+		}
+	}
+	return responses
+}
+
+func mapAuthor(u models.User) dto.PostAuthor {
+	return dto.PostAuthor{
+		ID:          u.ID.String(),
+		Username:    u.Username,
+		DisplayName: u.DisplayName,
+		AvatarURL:   u.AvatarURL,
+		Bio:         u.Bio,
+	}
+}
+
+// contentHTML returns the stored rendering of content, rendering on the fly
+// for posts written before rendering was stored.
+func contentHTML(content, rendered string) string {
+	if rendered == "" && content != "" {
+		return markdown.Render(content)
+	}
+	return rendered
+}
@@ -1,39 +1,325 @@
 package service
 
 import (
+	"context"
+	"errors"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	pb "github.com/maulana1k/forum-app/gen/recommender"
 	"github.com/maulana1k/forum-app/internal/app/dto"
+	"github.com/maulana1k/forum-app/internal/domain/errs"
+	"github.com/maulana1k/forum-app/internal/domain/events"
+	"github.com/maulana1k/forum-app/internal/domain/models"
 	"github.com/maulana1k/forum-app/internal/domain/repository"
+	"github.com/maulana1k/forum-app/internal/pkg/pagination"
+	"github.com/maulana1k/forum-app/internal/pkg/resilience"
+	"github.com/maulana1k/forum-app/internal/pkg/utils"
+	"github.com/maulana1k/forum-app/internal/provider/monitoring"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"gorm.io/gorm"
+)
+
+// Where a page of recommendations came from.
+const (
+	sourceRecommender = "recommender"
+	sourceFallback    = "fallback"
 )
 
+// fallbackCursorPrefix marks cursors of fallback pages, so the next page
+// comes from the same ranking even if the recommender is back.
+const fallbackCursorPrefix = "fallback:"
+
 type RecommendationService interface {
-	GetRecommendedPosts(userID, topic string, limit int) ([]dto.PostResponse, error)
+	// GetRecommendedPosts returns a page of recommended posts for viewerID,
+	// loaded and filtered like the main feed, and records it as shown.
+	// While the recommender is down, popular recent posts are served
+	// instead. Admins may ask for another user's page with AsUser.
+	GetRecommendedPosts(ctx context.Context, viewerID string, query *dto.RecommendationQueryParams) (*dto.RecommendedPostsResponse, error)
+	// RecordClick records that userID opened a post they were recommended.
+	RecordClick(ctx context.Context, userID string, req *dto.RecommendationClickRequest) error
+}
+
+// RecommendationOptions tunes the fallback ranking.
+type RecommendationOptions struct {
+	// FallbackWindow is how far back the fallback looks for posts.
+	FallbackWindow time.Duration
 }
 
 type recommendationService struct {
-	repo repository.RecommendationRepository
+	repo           repository.RecommendationRepository
+	impressionRepo repository.ImpressionRepository
+	postRepo       repository.PostRepository
+	posts          PostService
+	relationRepo   repository.RelationRepository
+	userRepo       repository.UserRepository
+	events         *events.Bus
+	opts           RecommendationOptions
+}
+
+func NewRecommendationService(repo repository.RecommendationRepository, impressionRepo repository.ImpressionRepository, postRepo repository.PostRepository, posts PostService, relationRepo repository.RelationRepository, userRepo repository.UserRepository, bus *events.Bus, opts RecommendationOptions) RecommendationService {
+	if opts.FallbackWindow <= 0 {
+		opts.FallbackWindow = 72 * time.Hour
+	}
+	return &recommendationService{
+		repo:           repo,
+		impressionRepo: impressionRepo,
+		postRepo:       postRepo,
+		posts:          posts,
+		relationRepo:   relationRepo,
+		userRepo:       userRepo,
+		events:         bus,
+		opts:           opts,
+	}
 }
 
-func NewRecommendationService(repo repository.RecommendationRepository) RecommendationService {
-	return &recommendationService{repo: repo}
+func (s *recommendationService) GetRecommendedPosts(ctx context.Context, viewerID string, query *dto.RecommendationQueryParams) (*dto.RecommendedPostsResponse, error) {
+	vid, err := uuid.Parse(viewerID)
+	if err != nil {
+		return nil, errs.ErrInvalidToken.Wrap(err)
+	}
+
+	userID := viewerID
+	if query.AsUser != "" && query.AsUser != viewerID {
+		viewer, err := s.userRepo.GetUserProfileByUserID(ctx, vid)
+		if err != nil {
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				return nil, errs.ErrRecommendationAsUserDenied
+			}
+			return nil, err
+		}
+		if !viewer.IsAdmin() {
+			return nil, errs.ErrRecommendationAsUserDenied
+		}
+		userID = query.AsUser
+	}
+
+	out, err := s.recommend(ctx, userID, query)
+	if err != nil {
+		return nil, err
+	}
+
+	// Debugging someone else's page must not count as them seeing it.
+	if userID == viewerID {
+		s.recordImpressions(ctx, vid, out)
+	}
+	return out, nil
 }
 
-func (s *recommendationService) GetRecommendedPosts(userID, topic string, limit int) ([]dto.PostResponse, error) {
-	posts, err := s.repo.GetRecommendedPosts(userID, topic, limit)
+func (s *recommendationService) recommend(ctx context.Context, userID string, query *dto.RecommendationQueryParams) (*dto.RecommendedPostsResponse, error) {
+	if strings.HasPrefix(query.Cursor, fallbackCursorPrefix) {
+		return s.fallback(ctx, userID, query)
+	}
+
+	// The recommender knows nothing of blocks and mutes, so it is told
+	// which authors to leave out.
+	uid, _ := uuid.Parse(userID)
+	hidden, err := s.relationRepo.HiddenAuthorIDs(ctx, uid)
+	if err != nil {
+		return nil, err
+	}
+	excludeAuthors := make([]string, len(hidden))
+	for i, id := range hidden {
+		excludeAuthors[i] = id.String()
+	}
+
+	resp, err := s.repo.GetRecommendedPosts(ctx, repository.RecommendationQuery{
+		UserID:           userID,
+		Topic:            query.Topic,
+		Limit:            query.Limit,
+		PageToken:        query.Cursor,
+		ExcludePostIDs:   query.Exclude,
+		ExcludeAuthorIDs: excludeAuthors,
+	})
+	if err != nil {
+		if status.Code(err) == codes.InvalidArgument {
+			return nil, pagination.ErrInvalidCursor.Wrap(err)
+		}
+		logger := utils.LoggerFromContext(ctx).WithError(err)
+		if errors.Is(err, resilience.ErrOpen) {
+			logger.Debug("recommender circuit open, serving fallback recommendations")
+		} else {
+			logger.Warn("recommender failed, serving fallback recommendations")
+		}
+		// The recommender's cursor means nothing to the fallback, which
+		// starts over; exclude keeps posts already shown out.
+		query.Cursor = ""
+		return s.fallback(ctx, userID, query)
+	}
+
+	// The recommender only ranks; the posts themselves come from the
+	// database in one batch, so they render like any other feed.
+	ids := make([]uuid.UUID, 0, len(resp.Posts))
+	items := make(map[string]*pb.PostItem, len(resp.Posts))
+	for _, item := range resp.Posts {
+		id, err := uuid.Parse(item.PostId)
+		if err != nil {
+			continue
+		}
+		ids = append(ids, id)
+		items[id.String()] = item
+	}
+	posts, err := s.posts.GetPostsByIDs(ctx, ids, userID)
 	if err != nil {
 		return nil, err
 	}
 
-	// Convert to DTO
-	postResponses := make([]dto.PostResponse, len(posts))
-	for i, p := range posts {
-		postResponses[i] = dto.PostResponse{
-			ID:           p.PostId,
-			Content:      p.Content,
-			LikesCount:   0,
-			RepliesCount: 0,
-			RepostsCount: 0,
+	out := &dto.RecommendedPostsResponse{
+		Posts:      make([]dto.RecommendedPostResponse, 0, len(posts)),
+		NextCursor: resp.NextPageToken,
+		Source:     sourceRecommender,
+	}
+	for _, post := range posts {
+		item := items[post.ID]
+		out.Posts = append(out.Posts, dto.RecommendedPostResponse{
+			PostResponse: post,
+			Score:        item.Score,
+			Reasons:      reasonNames(item.Reasons),
+		})
+	}
+	monitoring.RecommendationsServed.WithLabelValues(sourceRecommender).Inc()
+	return out, nil
+}
+
+// fallback ranks popular recent posts from the database. It pages by
+// offset, which is good enough for a ranking that changes slowly.
+func (s *recommendationService) fallback(ctx context.Context, userID string, query *dto.RecommendationQueryParams) (*dto.RecommendedPostsResponse, error) {
+	offset := 0
+	if query.Cursor != "" {
+		n, err := strconv.Atoi(strings.TrimPrefix(query.Cursor, fallbackCursorPrefix))
+		if err != nil || n < 0 {
+			return nil, pagination.ErrInvalidCursor
 		}
+		offset = n
 	}
 
-	return postResponses, nil
+	exclude := make([]uuid.UUID, 0, len(query.Exclude))
+	for _, raw := range query.Exclude {
+		if id, err := uuid.Parse(raw); err == nil {
+			exclude = append(exclude, id)
+		}
+	}
+
+	uid, _ := uuid.Parse(userID)
+	// One extra row tells whether there is a next page.
+	scored, err := s.postRepo.RankPopularPosts(ctx, repository.PopularPostsQuery{
+		ViewerID:       uid,
+		Since:          time.Now().Add(-s.opts.FallbackWindow),
+		Topic:          query.Topic,
+		ExcludePostIDs: exclude,
+		Limit:          query.Limit + 1,
+		Offset:         offset,
+	})
+	if err != nil {
+		return nil, errs.ErrRecommenderUnavailable.Wrap(err)
+	}
+
+	out := &dto.RecommendedPostsResponse{Source: sourceFallback}
+	if len(scored) > query.Limit {
+		scored = scored[:query.Limit]
+		out.NextCursor = fallbackCursorPrefix + strconv.Itoa(offset+query.Limit)
+	}
+
+	ids := make([]uuid.UUID, len(scored))
+	items := make(map[string]repository.ScoredPost, len(scored))
+	for i, sp := range scored {
+		ids[i] = sp.ID
+		items[sp.ID.String()] = sp
+	}
+	posts, err := s.posts.GetPostsByIDs(ctx, ids, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	out.Posts = make([]dto.RecommendedPostResponse, 0, len(posts))
+	for _, post := range posts {
+		sp := items[post.ID]
+		reasons := []string{"trending"}
+		if sp.TopicMatch {
+			reasons = []string{"topic_match", "trending"}
+		}
+		out.Posts = append(out.Posts, dto.RecommendedPostResponse{
+			PostResponse: post,
+			Score:        sp.Score,
+			Reasons:      reasons,
+		})
+	}
+	monitoring.RecommendationsServed.WithLabelValues(sourceFallback).Inc()
+	return out, nil
+}
+
+// recordImpressions stores the served page and sets its RecommendationID.
+// Losing feedback is better than failing the page, so errors are only
+// logged and leave the ID unset.
+func (s *recommendationService) recordImpressions(ctx context.Context, userID uuid.UUID, out *dto.RecommendedPostsResponse) {
+	if len(out.Posts) == 0 {
+		return
+	}
+
+	serveID := uuid.New()
+	impressions := make([]models.RecommendationImpression, 0, len(out.Posts))
+	for i, post := range out.Posts {
+		postID, err := uuid.Parse(post.ID)
+		if err != nil {
+			continue
+		}
+		impressions = append(impressions, models.RecommendationImpression{
+			ServeID:  serveID,
+			PostID:   postID,
+			UserID:   userID,
+			Position: i,
+			Score:    post.Score,
+			Source:   out.Source,
+		})
+	}
+	if err := s.impressionRepo.RecordImpressions(ctx, impressions); err != nil {
+		utils.LoggerFromContext(ctx).WithError(err).Warn("failed to record recommendation impressions")
+		return
+	}
+	out.RecommendationID = serveID.String()
+}
+
+func (s *recommendationService) RecordClick(ctx context.Context, userID string, req *dto.RecommendationClickRequest) error {
+	uid, err := uuid.Parse(userID)
+	if err != nil {
+		return errs.ErrInvalidToken.Wrap(err)
+	}
+	serveID, err := uuid.Parse(req.RecommendationID)
+	if err != nil {
+		return errs.ErrInvalidID.Wrap(err)
+	}
+	postID, err := uuid.Parse(req.PostID)
+	if err != nil {
+		return errs.ErrInvalidID.Wrap(err)
+	}
+
+	found, err := s.impressionRepo.RecordClick(ctx, serveID, postID, uid, time.Now())
+	if err != nil {
+		return err
+	}
+	if !found {
+		return errs.ErrRecommendationNotFound
+	}
+
+	s.events.Publish(ctx, events.RecommendationClicked{
+		UserID:           userID,
+		PostID:           req.PostID,
+		RecommendationID: req.RecommendationID,
+	})
+	return nil
+}
+
+// reasonNames turns REASON_TOPIC_MATCH into "topic_match".
+func reasonNames(codes []pb.ReasonCode) []string {
+	names := make([]string, 0, len(codes))
+	for _, code := range codes {
+		if code == pb.ReasonCode_REASON_UNSPECIFIED {
+			continue
+		}
+		names = append(names, strings.ToLower(strings.TrimPrefix(code.String(), "REASON_")))
+	}
+	return names
 }
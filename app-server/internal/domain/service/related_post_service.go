@@ -0,0 +1,169 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"strings"
+
+	"github.com/google/uuid"
+	"github.com/maulana1k/forum-app/internal/app/dto"
+	"github.com/maulana1k/forum-app/internal/domain/models"
+	"github.com/maulana1k/forum-app/internal/domain/repository"
+	"github.com/maulana1k/forum-app/internal/provider/embedding"
+	"gorm.io/gorm"
+)
+
+type RelatedPostService interface {
+	// GetRelatedPosts returns posts similar to postID that viewerID may
+	// see, leaving out the post's own author.
+	GetRelatedPosts(ctx context.Context, postID, viewerID string, query *dto.RelatedPostsQueryParams) (*dto.RelatedPostsResponse, error)
+	// IndexPosts embeds the published posts that have no embedding yet or
+	// were edited since, and returns how many it embedded.
+	IndexPosts(ctx context.Context) (int, error)
+}
+
+const defaultRelatedLimit = 5
+
+// RelatedPostOptions tunes related posts from configuration.
+type RelatedPostOptions struct {
+	// BatchSize posts are embedded per call to the embedder.
+	BatchSize int
+	// Disabled turns related posts off, for databases without pgvector:
+	// nothing is indexed and every post has no related posts.
+	Disabled bool
+}
+
+type relatedPostService struct {
+	embeddingRepo repository.EmbeddingRepository
+	posts         PostService
+	embedder      embedding.Embedder
+	opts          RelatedPostOptions
+}
+
+func NewRelatedPostService(embeddingRepo repository.EmbeddingRepository, posts PostService, embedder embedding.Embedder, opts RelatedPostOptions) RelatedPostService {
+	if opts.BatchSize <= 0 {
+		opts.BatchSize = 32
+	}
+	return &relatedPostService{
+		embeddingRepo: embeddingRepo,
+		posts:         posts,
+		embedder:      embedder,
+		opts:          opts,
+	}
+}
+
+func (s *relatedPostService) GetRelatedPosts(ctx context.Context, postID, viewerID string, query *dto.RelatedPostsQueryParams) (*dto.RelatedPostsResponse, error) {
+	// Applies the same visibility rules as opening the post.
+	post, err := s.posts.GetPostByID(ctx, postID, viewerID)
+	if err != nil {
+		return nil, err
+	}
+
+	resp := &dto.RelatedPostsResponse{Posts: []dto.RelatedPostResponse{}}
+	if s.opts.Disabled {
+		return resp, nil
+	}
+	id, _ := uuid.Parse(post.ID)
+	source, err := s.embeddingRepo.GetEmbedding(ctx, id)
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return resp, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	limit := query.Limit
+	if limit <= 0 {
+		limit = defaultRelatedLimit
+	}
+	authorID, _ := uuid.Parse(post.Author.ID)
+	vid, _ := uuid.Parse(viewerID)
+	// Posts hidden by the viewer's muted words are dropped below, so
+	// fetch some spare.
+	similar, err := s.embeddingRepo.FindSimilarPosts(ctx, repository.SimilarPostsQuery{
+		Embedding: source.Embedding,
+		PostID:    id,
+		AuthorID:  authorID,
+		ViewerID:  vid,
+		Limit:     2 * limit,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	ids := make([]uuid.UUID, len(similar))
+	scores := make(map[string]float64, len(similar))
+	for i, p := range similar {
+		ids[i] = p.ID
+		scores[p.ID.String()] = p.Score
+	}
+	posts, err := s.posts.GetPostsByIDs(ctx, ids, viewerID)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, p := range posts {
+		if p.Filtered != nil {
+			continue
+		}
+		resp.Posts = append(resp.Posts, dto.RelatedPostResponse{PostResponse: p, Score: scores[p.ID]})
+		if len(resp.Posts) == limit {
+			break
+		}
+	}
+	return resp, nil
+}
+
+func (s *relatedPostService) IndexPosts(ctx context.Context) (int, error) {
+	if s.opts.Disabled {
+		return 0, nil
+	}
+	total := 0
+	for {
+		posts, err := s.embeddingRepo.ListPostsToEmbed(ctx, s.opts.BatchSize)
+		if err != nil || len(posts) == 0 {
+			return total, err
+		}
+
+		texts := make([]string, len(posts))
+		for i, p := range posts {
+			texts[i] = embeddingText(&p)
+		}
+		vectors, model, err := s.embedder.Embed(ctx, texts)
+		if err != nil {
+			return total, err
+		}
+
+		embeddings := make([]models.PostEmbedding, len(posts))
+		for i, p := range posts {
+			contentAt := p.CreatedAt
+			if p.EditedAt != nil {
+				contentAt = *p.EditedAt
+			}
+			embeddings[i] = models.PostEmbedding{
+				PostID:    p.ID,
+				Embedding: vectors[i],
+				Model:     model,
+				ContentAt: contentAt,
+			}
+		}
+		if err := s.embeddingRepo.SaveEmbeddings(ctx, embeddings); err != nil {
+			return total, err
+		}
+		total += len(posts)
+
+		if len(posts) < s.opts.BatchSize {
+			return total, nil
+		}
+	}
+}
+
+// embeddingText is what a post is embedded from: its tags, which name its
+// topics, then its text.
+func embeddingText(p *models.Post) string {
+	tags := strings.TrimSpace(p.Tags)
+	if tags == "" {
+		return p.Content
+	}
+	return tags + "\n" + p.Content
+}
@@ -0,0 +1,142 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"strconv"
+
+	"github.com/google/uuid"
+	"github.com/maulana1k/forum-app/internal/app/dto"
+	"github.com/maulana1k/forum-app/internal/domain/errs"
+	"github.com/maulana1k/forum-app/internal/domain/models"
+	"github.com/maulana1k/forum-app/internal/domain/repository"
+	"github.com/maulana1k/forum-app/internal/pkg/pagination"
+	"gorm.io/gorm"
+)
+
+// RelationService manages blocks and mutes. Blocked users cannot see or
+// interact with each other's posts; muted users are only kept out of the
+// muter's feeds and recommendations.
+type RelationService interface {
+	Block(ctx context.Context, userID, targetID string) error
+	Unblock(ctx context.Context, userID, targetID string) error
+	Mute(ctx context.Context, userID, targetID string) error
+	Unmute(ctx context.Context, userID, targetID string) error
+	ListBlocked(ctx context.Context, userID string, query *dto.PostQueryParams) (*dto.PaginatedRelatedUsersResponse, error)
+	ListMuted(ctx context.Context, userID string, query *dto.PostQueryParams) (*dto.PaginatedRelatedUsersResponse, error)
+}
+
+type relationService struct {
+	relationRepo repository.RelationRepository
+	userRepo     repository.UserRepository
+}
+
+func NewRelationService(relationRepo repository.RelationRepository, userRepo repository.UserRepository) RelationService {
+	return &relationService{relationRepo: relationRepo, userRepo: userRepo}
+}
+
+func (s *relationService) Block(ctx context.Context, userID, targetID string) error {
+	return s.add(ctx, userID, targetID, models.RelationBlock)
+}
+
+func (s *relationService) Unblock(ctx context.Context, userID, targetID string) error {
+	return s.remove(ctx, userID, targetID, models.RelationBlock, errs.ErrNotBlocked)
+}
+
+func (s *relationService) Mute(ctx context.Context, userID, targetID string) error {
+	return s.add(ctx, userID, targetID, models.RelationMute)
+}
+
+func (s *relationService) Unmute(ctx context.Context, userID, targetID string) error {
+	return s.remove(ctx, userID, targetID, models.RelationMute, errs.ErrNotMuted)
+}
+
+func (s *relationService) ListBlocked(ctx context.Context, userID string, query *dto.PostQueryParams) (*dto.PaginatedRelatedUsersResponse, error) {
+	return s.list(ctx, userID, models.RelationBlock, query)
+}
+
+func (s *relationService) ListMuted(ctx context.Context, userID string, query *dto.PostQueryParams) (*dto.PaginatedRelatedUsersResponse, error) {
+	return s.list(ctx, userID, models.RelationMute, query)
+}
+
+// add is idempotent: blocking or muting someone twice is not an error.
+func (s *relationService) add(ctx context.Context, userID, targetID string, kind models.RelationKind) error {
+	uid, tid, err := parseRelationIDs(userID, targetID)
+	if err != nil {
+		return err
+	}
+
+	if _, err := s.userRepo.GetUserProfileByUserID(ctx, tid); err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return errs.ErrUserNotFound
+		}
+		return err
+	}
+
+	_, err = s.relationRepo.AddRelation(ctx, uid, tid, kind)
+	return err
+}
+
+func (s *relationService) remove(ctx context.Context, userID, targetID string, kind models.RelationKind, missing error) error {
+	uid, tid, err := parseRelationIDs(userID, targetID)
+	if err != nil {
+		return err
+	}
+
+	removed, err := s.relationRepo.RemoveRelation(ctx, uid, tid, kind)
+	if err != nil {
+		return err
+	}
+	if !removed {
+		return missing
+	}
+	return nil
+}
+
+func (s *relationService) list(ctx context.Context, userID string, kind models.RelationKind, query *dto.PostQueryParams) (*dto.PaginatedRelatedUsersResponse, error) {
+	page, err := pagination.NewParams(query.Limit, 0, query.Cursor)
+	if err != nil {
+		return nil, err
+	}
+
+	uid, err := uuid.Parse(userID)
+	if err != nil {
+		return nil, errs.ErrInvalidToken.Wrap(err)
+	}
+
+	relations, err := s.relationRepo.ListRelations(ctx, uid, kind, page)
+	if err != nil {
+		return nil, err
+	}
+
+	window := pagination.Window(relations, page, func(r models.UserRelation) pagination.Cursor {
+		return pagination.Cursor{CreatedAt: r.CreatedAt, ID: strconv.FormatUint(uint64(r.ID), 10)}
+	})
+	users := make([]dto.RelatedUserResponse, len(window.Items))
+	for i, r := range window.Items {
+		users[i] = dto.RelatedUserResponse{User: mapAuthor(r.Target), Since: r.CreatedAt}
+	}
+	return &dto.PaginatedRelatedUsersResponse{
+		Users:       users,
+		Limit:       page.Limit,
+		HasNextPage: window.HasNext,
+		HasPrevPage: window.HasPrev,
+		NextCursor:  window.NextCursor,
+		PrevCursor:  window.PrevCursor,
+	}, nil
+}
+
+func parseRelationIDs(userID, targetID string) (uuid.UUID, uuid.UUID, error) {
+	uid, err := uuid.Parse(userID)
+	if err != nil {
+		return uuid.Nil, uuid.Nil, errs.ErrInvalidToken.Wrap(err)
+	}
+	tid, err := uuid.Parse(targetID)
+	if err != nil {
+		return uuid.Nil, uuid.Nil, errs.ErrInvalidID.Wrap(err)
+	}
+	if uid == tid {
+		return uuid.Nil, uuid.Nil, errs.ErrSelfRelation
+	}
+	return uid, tid, nil
+}
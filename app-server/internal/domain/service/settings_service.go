@@ -0,0 +1,160 @@
+package service
+
+import (
+	"context"
+	"errors"
+
+	"github.com/google/uuid"
+	"github.com/maulana1k/forum-app/internal/app/dto"
+	"github.com/maulana1k/forum-app/internal/domain/errs"
+	"github.com/maulana1k/forum-app/internal/domain/models"
+	"github.com/maulana1k/forum-app/internal/domain/repository"
+	"gorm.io/gorm"
+)
+
+type SettingsService interface {
+	GetSettings(ctx context.Context, userID string) (*dto.SettingsResponse, error)
+	// UpdateSettings applies a partial update. It fails with
+	// ErrSettingsVersionConflict if req.Version is not the current
+	// version.
+	UpdateSettings(ctx context.Context, userID string, req *dto.UpdateSettingsRequest) (*dto.SettingsResponse, error)
+}
+
+type settingsService struct {
+	repo repository.SettingsRepository
+}
+
+func NewSettingsService(repo repository.SettingsRepository) SettingsService {
+	return &settingsService{repo: repo}
+}
+
+func (s *settingsService) GetSettings(ctx context.Context, userID string) (*dto.SettingsResponse, error) {
+	uid, err := uuid.Parse(userID)
+	if err != nil {
+		return nil, errs.ErrInvalidToken.Wrap(err)
+	}
+
+	settings, err := s.findSettings(ctx, uid)
+	if err != nil {
+		return nil, err
+	}
+	return mapSettings(settings), nil
+}
+
+func (s *settingsService) UpdateSettings(ctx context.Context, userID string, req *dto.UpdateSettingsRequest) (*dto.SettingsResponse, error) {
+	uid, err := uuid.Parse(userID)
+	if err != nil {
+		return nil, errs.ErrInvalidToken.Wrap(err)
+	}
+
+	settings, err := s.findSettings(ctx, uid)
+	if err != nil {
+		return nil, err
+	}
+	version := settings.Version
+	if *req.Version != version {
+		return nil, errs.ErrSettingsVersionConflict
+	}
+
+	applySettingsPatch(settings, req)
+
+	var saved bool
+	if version == 0 {
+		settings.Version = 1
+		saved, err = s.repo.CreateSettings(ctx, settings)
+	} else {
+		saved, err = s.repo.UpdateSettings(ctx, settings, version)
+	}
+	if err != nil {
+		return nil, err
+	}
+	if !saved {
+		return nil, errs.ErrSettingsVersionConflict
+	}
+
+	// Reload to pick up the new version and timestamps.
+	settings, err = s.findSettings(ctx, uid)
+	if err != nil {
+		return nil, err
+	}
+	return mapSettings(settings), nil
+}
+
+// findSettings returns the user's settings, or the defaults at version 0
+// if they never saved any.
+func (s *settingsService) findSettings(ctx context.Context, userID uuid.UUID) (*models.UserSettings, error) {
+	settings, err := s.repo.GetSettings(ctx, userID)
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return models.DefaultUserSettings(userID), nil
+	}
+	return settings, err
+}
+
+func applySettingsPatch(settings *models.UserSettings, req *dto.UpdateSettingsRequest) {
+	if req.Theme != nil {
+		settings.Theme = *req.Theme
+	}
+	if req.Language != nil {
+		settings.Language = *req.Language
+	}
+	if req.DefaultPostVisibility != nil {
+		settings.DefaultPostVisibility = models.PostVisibility(*req.DefaultPostVisibility)
+	}
+	if p := req.Privacy; p != nil && p.HideLikes != nil {
+		settings.HideLikes = *p.HideLikes
+	}
+	if n := req.Notifications; n != nil {
+		prefs := &settings.Notifications
+		applyChannelsPatch(&prefs.Replies, n.Replies)
+		applyChannelsPatch(&prefs.Mentions, n.Mentions)
+		applyChannelsPatch(&prefs.Reactions, n.Reactions)
+		applyChannelsPatch(&prefs.Quotes, n.Quotes)
+		applyChannelsPatch(&prefs.Follows, n.Follows)
+		applyChannelsPatch(&prefs.PollResults, n.PollResults)
+	}
+}
+
+func applyChannelsPatch(channels *models.NotificationChannels, patch *dto.NotificationChannelsPatch) {
+	if patch == nil {
+		return
+	}
+	if patch.InApp != nil {
+		channels.InApp = *patch.InApp
+	}
+	if patch.Email != nil {
+		channels.Email = *patch.Email
+	}
+	if patch.Push != nil {
+		channels.Push = *patch.Push
+	}
+}
+
+func mapSettings(settings *models.UserSettings) *dto.SettingsResponse {
+	n := settings.Notifications
+	resp := &dto.SettingsResponse{
+		Theme:    settings.Theme,
+		Language: settings.Language,
+		Notifications: dto.NotificationSettings{
+			Replies:     mapChannels(n.Replies),
+			Mentions:    mapChannels(n.Mentions),
+			Reactions:   mapChannels(n.Reactions),
+			Quotes:      mapChannels(n.Quotes),
+			Follows:     mapChannels(n.Follows),
+			PollResults: mapChannels(n.PollResults),
+		},
+		DefaultPostVisibility: string(settings.DefaultPostVisibility),
+		Privacy: dto.PrivacySettings{
+			HideLikes: settings.HideLikes,
+		},
+		Version: settings.Version,
+	}
+	if !settings.UpdatedAt.IsZero() {
+		updatedAt := settings.UpdatedAt
+		resp.UpdatedAt = &updatedAt
+	}
+	return resp
+}
+
+func mapChannels(c models.NotificationChannels) dto.NotificationChannels {
+	return dto.NotificationChannels{InApp: c.InApp, Email: c.Email, Push: c.Push}
+}
@@ -0,0 +1,249 @@
+package service
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/maulana1k/forum-app/internal/app/dto"
+	"github.com/maulana1k/forum-app/internal/domain/errs"
+	"github.com/maulana1k/forum-app/internal/domain/events"
+	"github.com/maulana1k/forum-app/internal/domain/models"
+	"github.com/maulana1k/forum-app/internal/domain/repository"
+	"github.com/maulana1k/forum-app/internal/pkg/utils"
+	"github.com/maulana1k/forum-app/internal/provider/broker"
+	"golang.org/x/crypto/bcrypt"
+	"gorm.io/gorm"
+)
+
+// EmailVerificationQueue carries verification tokens for the mailer to
+// send to a user's new email address.
+const EmailVerificationQueue = "user-email-verification"
+
+// EmailVerificationRequest is the EmailVerificationQueue message body.
+type EmailVerificationRequest struct {
+	UserID    string    `json:"user_id"`
+	Email     string    `json:"email"`
+	Token     string    `json:"token"`
+	ExpiresAt time.Time `json:"expires_at"`
+}
+
+func (s *userService) GetUserByUsername(ctx context.Context, username string) (*models.User, error) {
+	user, err := s.userRepo.GetUserByUsername(ctx, username)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, errs.ErrUserNotFound
+		}
+		return nil, err
+	}
+	if !user.IsActive() {
+		return nil, errs.ErrUserNotFound
+	}
+	user.Password = ""
+	return user, nil
+}
+
+func (s *userService) GetAccount(ctx context.Context, userID uuid.UUID) (*models.User, *models.EmailChange, error) {
+	profile, err := s.loadProfile(ctx, userID)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	change, err := s.userRepo.GetEmailChange(ctx, userID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return profile, nil, nil
+		}
+		return nil, nil, err
+	}
+	if !change.ExpiresAt.After(time.Now()) {
+		return profile, nil, nil
+	}
+	return profile, change, nil
+}
+
+func (s *userService) UpdateUserProfile(ctx context.Context, userID uuid.UUID, req *dto.UpdateProfileRequest) (*models.User, error) {
+	profile, err := s.userRepo.GetUserProfileByUserID(ctx, userID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, errs.ErrUserNotFound
+		}
+		return nil, err
+	}
+
+	var rename *repository.UsernameChange
+	if req.Username != nil && *req.Username != profile.Username {
+		if rename, err = s.usernameChange(ctx, profile, *req.Username); err != nil {
+			return nil, err
+		}
+		profile.Username = rename.NewName
+		profile.UsernameChangedAt = &rename.ChangedAt
+	}
+
+	// Only the fields sent are written, so concurrent changes to the rest
+	// of the row are kept.
+	fields := map[string]any{}
+	if req.DisplayName.Present {
+		profile.DisplayName = strings.TrimSpace(req.DisplayName.Value)
+		if profile.DisplayName == "" {
+			profile.DisplayName = profile.Username
+		}
+		fields["display_name"] = profile.DisplayName
+	}
+	if req.Bio.Present {
+		profile.Bio = strings.TrimSpace(req.Bio.Value)
+		fields["bio"] = profile.Bio
+	}
+	if req.Location.Present {
+		profile.Location = strings.TrimSpace(req.Location.Value)
+		fields["location"] = profile.Location
+	}
+
+	changed, err := s.userRepo.UpdateUserProfile(ctx, userID, fields, rename)
+	if err != nil {
+		return nil, err
+	}
+	if !changed {
+		return nil, errs.ErrUsernameTaken
+	}
+	s.events.Publish(ctx, events.UserProfileUpdated{UserID: userID.String()})
+
+	profile.Password = ""
+	return profile, nil
+}
+
+// usernameChange checks that profile's user may take username and returns
+// the rename, which reserves the old username for them.
+func (s *userService) usernameChange(ctx context.Context, profile *models.User, username string) (*repository.UsernameChange, error) {
+	if s.UsernameChangeableAt(profile) != nil {
+		return nil, errs.ErrUsernameCooldown
+	}
+
+	available, err := s.userRepo.IsUsernameAvailable(ctx, username, profile.ID)
+	if err != nil {
+		return nil, err
+	}
+	if !available {
+		return nil, errs.ErrUsernameTaken
+	}
+
+	now := time.Now()
+	return &repository.UsernameChange{
+		OldName:       profile.Username,
+		NewName:       username,
+		ChangedAt:     now,
+		ReservedUntil: now.Add(s.opts.UsernameReservation),
+	}, nil
+}
+
+func (s *userService) UsernameChangeableAt(u *models.User) *time.Time {
+	if u.UsernameChangedAt == nil {
+		return nil
+	}
+	at := u.UsernameChangedAt.Add(s.opts.UsernameCooldown)
+	if !at.After(time.Now()) {
+		return nil
+	}
+	return &at
+}
+
+func (s *userService) RequestEmailChange(ctx context.Context, userID uuid.UUID, req *dto.ChangeEmailRequest) (*models.EmailChange, error) {
+	user, err := s.userRepo.GetUserProfileByUserID(ctx, userID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, errs.ErrUserNotFound
+		}
+		return nil, err
+	}
+	if err := bcrypt.CompareHashAndPassword([]byte(user.Password), []byte(req.Password)); err != nil {
+		return nil, errs.ErrIncorrectPassword
+	}
+
+	email := strings.TrimSpace(req.Email)
+	if strings.EqualFold(email, user.Email) {
+		return nil, errs.ErrEmailUnchanged
+	}
+	inUse, err := s.userRepo.IsEmailInUse(ctx, email)
+	if err != nil {
+		return nil, err
+	}
+	if inUse {
+		return nil, errs.ErrEmailTaken
+	}
+
+	token, hash, err := newEmailToken()
+	if err != nil {
+		return nil, err
+	}
+	change := &models.EmailChange{
+		UserID:    userID,
+		Email:     email,
+		TokenHash: hash,
+		ExpiresAt: time.Now().Add(s.opts.EmailTokenTTL),
+	}
+	if err := s.userRepo.SaveEmailChange(ctx, change); err != nil {
+		return nil, err
+	}
+
+	body, _ := json.Marshal(EmailVerificationRequest{
+		UserID:    userID.String(),
+		Email:     email,
+		Token:     token,
+		ExpiresAt: change.ExpiresAt,
+	})
+	producer := broker.NewProducer(s.broker, EmailVerificationQueue)
+	if err := producer.Publish(ctx, body); err != nil {
+		utils.LoggerFromContext(ctx).WithError(err).WithField("user_id", userID).Error("failed to publish email verification message")
+		return nil, err
+	}
+
+	return change, nil
+}
+
+func (s *userService) VerifyEmailChange(ctx context.Context, userID uuid.UUID, token string) (*models.User, error) {
+	change, err := s.userRepo.GetEmailChangeByToken(ctx, hashEmailToken(token))
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, errs.ErrInvalidEmailToken
+		}
+		return nil, err
+	}
+	// A token only works for the account that asked for it.
+	if change.UserID != userID || !change.ExpiresAt.After(time.Now()) {
+		return nil, errs.ErrInvalidEmailToken
+	}
+
+	applied, err := s.userRepo.ApplyEmailChange(ctx, change)
+	if err != nil {
+		return nil, err
+	}
+	if !applied {
+		return nil, errs.ErrEmailTaken
+	}
+	s.events.Publish(ctx, events.UserProfileUpdated{UserID: userID.String()})
+
+	return s.loadProfile(ctx, userID)
+}
+
+// newEmailToken returns a random token to mail to the user and the hash
+// to store in its place.
+func newEmailToken() (token, hash string, err error) {
+	buf := make([]byte, 32)
+	if _, err := rand.Read(buf); err != nil {
+		return "", "", err
+	}
+	token = base64.RawURLEncoding.EncodeToString(buf)
+	return token, hashEmailToken(token), nil
+}
+
+func hashEmailToken(token string) string {
+	sum := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(sum[:])
+}
@@ -1,40 +1,95 @@
 package service
 
 import (
+	"context"
 	"errors"
+	"time"
 
 	"github.com/google/uuid"
+	"github.com/maulana1k/forum-app/internal/app/dto"
+	"github.com/maulana1k/forum-app/internal/domain/errs"
+	"github.com/maulana1k/forum-app/internal/domain/events"
 	"github.com/maulana1k/forum-app/internal/domain/models"
 	"github.com/maulana1k/forum-app/internal/domain/repository"
+	"github.com/maulana1k/forum-app/internal/provider/broker"
+	"github.com/maulana1k/forum-app/internal/provider/cache"
+	"gorm.io/gorm"
 )
 
 type UserService interface {
-	GetAllUsers() ([]models.User, error)
-	CreateUserProfile(userID uuid.UUID, username string) error
-	GetUserProfile(userID uuid.UUID) (*models.User, error)
-	UpdateUserProfile(userID uuid.UUID, displayName, bio, location, avatarURL string) error
+	GetAllUsers(ctx context.Context) ([]models.User, error)
+	CreateUserProfile(ctx context.Context, userID uuid.UUID, username string) error
+	GetUserProfile(ctx context.Context, userID uuid.UUID) (*models.User, error)
+	// GetUserProfiles returns the profiles of the listed users in the
+	// order given, leaving out unknown and deactivated ones.
+	GetUserProfiles(ctx context.Context, userIDs []uuid.UUID) ([]models.User, error)
+	GetUserByUsername(ctx context.Context, username string) (*models.User, error)
+	// GetAccount returns the user's profile and their pending email
+	// change, if any.
+	GetAccount(ctx context.Context, userID uuid.UUID) (*models.User, *models.EmailChange, error)
+	// UpdateUserProfile applies a partial update. Username changes are
+	// subject to UserOptions.UsernameCooldown.
+	UpdateUserProfile(ctx context.Context, userID uuid.UUID, req *dto.UpdateProfileRequest) (*models.User, error)
+	// UsernameChangeableAt returns when u may next change their username,
+	// or nil if they may do so now.
+	UsernameChangeableAt(u *models.User) *time.Time
+	// RequestEmailChange mails a verification token to the new address.
+	RequestEmailChange(ctx context.Context, userID uuid.UUID, req *dto.ChangeEmailRequest) (*models.EmailChange, error)
+	// VerifyEmailChange completes the user's pending email change.
+	VerifyEmailChange(ctx context.Context, userID uuid.UUID, token string) (*models.User, error)
+	// SetAvatar makes an uploaded image the user's avatar.
+	SetAvatar(ctx context.Context, userID uuid.UUID, mediaID string) (*models.User, error)
 	// FollowUser(userID uint) error
 }
 
+const profileCacheTTL = 10 * time.Minute
+
+// UserOptions tunes account management from configuration.
+type UserOptions struct {
+	// UsernameCooldown is the minimum time between username changes.
+	UsernameCooldown time.Duration
+	// UsernameReservation is how long a username stays reserved for the
+	// user who changed away from it.
+	UsernameReservation time.Duration
+	// EmailTokenTTL is how long an email verification token is valid.
+	EmailTokenTTL time.Duration
+}
+
 type userService struct {
-	userRepo repository.UserRepository
+	userRepo  repository.UserRepository
+	mediaRepo repository.MediaRepository
+	broker    *broker.RabbitMQ
+	events    *events.Bus
+	profiles  *cache.Loader[*models.User]
+	opts      UserOptions
 }
 
-func NewUserService(userRepo repository.UserRepository) UserService {
-	return &userService{
-		userRepo: userRepo,
+func NewUserService(userRepo repository.UserRepository, mediaRepo repository.MediaRepository, brokerc *broker.RabbitMQ, bus *events.Bus, store cache.Cache, opts UserOptions) UserService {
+	if opts.EmailTokenTTL <= 0 {
+		opts.EmailTokenTTL = 24 * time.Hour
 	}
+	s := &userService{
+		userRepo:  userRepo,
+		mediaRepo: mediaRepo,
+		broker:    brokerc,
+		events:    bus,
+		profiles:  cache.NewLoader[*models.User](store, "user", profileCacheTTL),
+		opts:      opts,
+	}
+
+	bus.Subscribe(s.invalidateProfile, events.UserProfileUpdatedEvent)
+	return s
 }
 
-func (s *userService) GetAllUsers() ([]models.User, error) {
-	return s.userRepo.GetAll()
+func (s *userService) GetAllUsers(ctx context.Context) ([]models.User, error) {
+	return s.userRepo.GetAll(ctx)
 }
 
-func (s *userService) CreateUserProfile(userID uuid.UUID, username string) error {
+func (s *userService) CreateUserProfile(ctx context.Context, userID uuid.UUID, username string) error {
 	// Check if profile already exists
-	existingProfile, err := s.userRepo.GetUserProfileByUserID(userID)
+	existingProfile, err := s.userRepo.GetUserProfileByUserID(ctx, userID)
 	if err == nil && existingProfile != nil {
-		return errors.New("user profile already exists")
+		return errs.ErrUserProfileExists
 	}
 
 	profile := &models.User{
@@ -45,36 +100,89 @@ func (s *userService) CreateUserProfile(userID uuid.UUID, username string) error
 		Location:    "",       // Empty by default
 	}
 
-	return s.userRepo.CreateUserProfile(profile)
+	return s.userRepo.CreateUserProfile(ctx, profile)
 }
 
-func (s *userService) GetUserProfile(userID uuid.UUID) (*models.User, error) {
-	profile, err := s.userRepo.GetUserProfileByUserID(userID)
+func (s *userService) GetUserProfile(ctx context.Context, userID uuid.UUID) (*models.User, error) {
+	profile, err := s.loadProfile(ctx, userID)
 	if err != nil {
-		return nil, errors.New("user profile not found")
+		return nil, err
+	}
+	// Deactivated users are hidden until they come back.
+	if !profile.IsActive() {
+		return nil, errs.ErrUserNotFound
 	}
 	return profile, nil
 }
 
-func (s *userService) UpdateUserProfile(userID uuid.UUID, displayName, bio, location, avatarURL string) error {
-	profile, err := s.userRepo.GetUserProfileByUserID(userID)
-	if err != nil {
-		return errors.New("user profile not found")
-	}
+func (s *userService) GetUserProfiles(ctx context.Context, userIDs []uuid.UUID) ([]models.User, error) {
+	profiles := make([]models.User, 0, len(userIDs))
+	seen := make(map[uuid.UUID]bool, len(userIDs))
+	for _, id := range userIDs {
+		if seen[id] {
+			continue
+		}
+		seen[id] = true
 
-	// Update fields only if they are provided
-	if displayName != "" {
-		profile.DisplayName = displayName
+		// Profiles are cached one by one, so a batch is mostly cache hits.
+		profile, err := s.GetUserProfile(ctx, id)
+		if errors.Is(err, errs.ErrUserNotFound) {
+			continue
+		}
+		if err != nil {
+			return nil, err
+		}
+		profiles = append(profiles, *profile)
 	}
-	if bio != "" {
-		profile.Bio = bio
+	return profiles, nil
+}
+
+// loadProfile returns the user's cached profile, deactivated or not.
+func (s *userService) loadProfile(ctx context.Context, userID uuid.UUID) (*models.User, error) {
+	return s.profiles.Get(ctx, userID.String(), func(ctx context.Context) (*models.User, error) {
+		profile, err := s.userRepo.GetUserProfileByUserID(ctx, userID)
+		if err != nil {
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				return nil, errs.ErrUserNotFound
+			}
+			return nil, err
+		}
+		// Never let the password hash reach the cache.
+		profile.Password = ""
+		return profile, nil
+	})
+}
+
+func (s *userService) SetAvatar(ctx context.Context, userID uuid.UUID, mediaID string) (*models.User, error) {
+	media, err := resolveMedia(ctx, s.mediaRepo, userID, mediaID)
+	if err != nil {
+		return nil, err
 	}
-	if location != "" {
-		profile.Location = location
+
+	profile, err := s.userRepo.GetUserProfileByUserID(ctx, userID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, errs.ErrUserNotFound
+		}
+		return nil, err
 	}
-	if avatarURL != "" {
-		profile.AvatarURL = avatarURL
+
+	// Avatars render small everywhere, so point at the thumbnail.
+	profile.AvatarMediaID = &media.ID
+	profile.AvatarURL = media.ThumbnailURL
+	fields := map[string]any{"avatar_media_id": profile.AvatarMediaID, "avatar_url": profile.AvatarURL}
+	if _, err := s.userRepo.UpdateUserProfile(ctx, userID, fields, nil); err != nil {
+		return nil, err
 	}
+	s.events.Publish(ctx, events.UserProfileUpdated{UserID: userID.String()})
 
-	return s.userRepo.UpdateUserProfile(profile)
+	profile.Password = ""
+	return profile, nil
+}
+
+func (s *userService) invalidateProfile(ctx context.Context, event events.Event) error {
+	if e, ok := event.(events.UserProfileUpdated); ok {
+		s.profiles.Invalidate(ctx, e.UserID)
+	}
+	return nil
 }
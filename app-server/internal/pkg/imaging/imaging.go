@@ -0,0 +1,107 @@
+// Package imaging inspects uploaded images and renders thumbnails.
+package imaging
+
+import (
+	"bytes"
+	"errors"
+	"image"
+	"image/color"
+	"image/draw"
+	_ "image/gif" // register decoders for Inspect and Thumbnail
+	"image/jpeg"
+	_ "image/png"
+	"net/http"
+
+	xdraw "golang.org/x/image/draw"
+	_ "golang.org/x/image/webp"
+)
+
+// MaxPixels bounds the decoded size of an image so a small file cannot
+// expand into gigabytes of pixels.
+const MaxPixels = 40_000_000
+
+var (
+	ErrUnsupported = errors.New("imaging: unsupported content type")
+	ErrTooLarge    = errors.New("imaging: image dimensions too large")
+)
+
+// allowed maps sniffed content types to the decoder format name.
+var allowed = map[string]string{
+	"image/jpeg": "jpeg",
+	"image/png":  "png",
+	"image/gif":  "gif",
+	"image/webp": "webp",
+}
+
+// Allowed reports whether contentType is an accepted upload type.
+func Allowed(contentType string) bool {
+	_, ok := allowed[contentType]
+	return ok
+}
+
+// Info describes an inspected image.
+type Info struct {
+	ContentType string
+	Width       int
+	Height      int
+}
+
+// Inspect sniffs the content type of data from its bytes, ignoring any
+// client supplied type, and reads the image dimensions.
+func Inspect(data []byte) (Info, error) {
+	contentType := http.DetectContentType(data)
+	format, ok := allowed[contentType]
+	if !ok {
+		return Info{}, ErrUnsupported
+	}
+
+	cfg, decoded, err := image.DecodeConfig(bytes.NewReader(data))
+	if err != nil {
+		return Info{}, err
+	}
+	// A PNG renamed to .jpg sniffs fine; a payload that only pretends to
+	// be an image does not decode as the sniffed format.
+	if decoded != format {
+		return Info{}, ErrUnsupported
+	}
+	if cfg.Width*cfg.Height > MaxPixels {
+		return Info{}, ErrTooLarge
+	}
+
+	return Info{ContentType: contentType, Width: cfg.Width, Height: cfg.Height}, nil
+}
+
+// Thumbnail scales the image in data to fit within size x size pixels and
+// encodes it as JPEG. Images already small enough keep their dimensions.
+// Transparent areas are flattened onto white.
+func Thumbnail(data []byte, size int) ([]byte, error) {
+	src, _, err := image.Decode(bytes.NewReader(data))
+	if err != nil {
+		return nil, err
+	}
+
+	b := src.Bounds()
+	w, h := fit(b.Dx(), b.Dy(), size)
+
+	dst := image.NewRGBA(image.Rect(0, 0, w, h))
+	draw.Draw(dst, dst.Bounds(), image.NewUniform(color.White), image.Point{}, draw.Src)
+	xdraw.CatmullRom.Scale(dst, dst.Bounds(), src, b, draw.Over, nil)
+
+	var buf bytes.Buffer
+	if err := jpeg.Encode(&buf, dst, &jpeg.Options{Quality: 80}); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+// fit scales w x h down to fit within size x size, keeping the aspect
+// ratio and never returning a zero dimension.
+func fit(w, h, size int) (int, int) {
+	if w <= size && h <= size {
+		return w, h
+	}
+	if w >= h {
+		return size, max(1, h*size/w)
+	}
+	return max(1, w*size/h), size
+}
@@ -0,0 +1,71 @@
+package imaging
+
+import (
+	"bytes"
+	"image"
+	"image/color"
+	"image/png"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func encodePNG(t *testing.T, w, h int) []byte {
+	t.Helper()
+	img := image.NewNRGBA(image.Rect(0, 0, w, h))
+	img.Set(0, 0, color.NRGBA{R: 255, A: 255})
+
+	var buf bytes.Buffer
+	require.NoError(t, png.Encode(&buf, img))
+	return buf.Bytes()
+}
+
+func TestInspect(t *testing.T) {
+	info, err := Inspect(encodePNG(t, 640, 480))
+	require.NoError(t, err)
+	assert.Equal(t, Info{ContentType: "image/png", Width: 640, Height: 480}, info)
+}
+
+func TestInspectRejectsNonImages(t *testing.T) {
+	_, err := Inspect([]byte("<html><script>alert(1)</script></html>"))
+	assert.ErrorIs(t, err, ErrUnsupported)
+
+	_, err = Inspect([]byte("%PDF-1.7\n"))
+	assert.ErrorIs(t, err, ErrUnsupported)
+}
+
+func TestInspectRejectsTruncatedImage(t *testing.T) {
+	data := encodePNG(t, 10, 10)
+	_, err := Inspect(data[:20])
+	assert.Error(t, err)
+}
+
+func TestThumbnail(t *testing.T) {
+	thumb, err := Thumbnail(encodePNG(t, 1000, 500), 320)
+	require.NoError(t, err)
+
+	info, err := Inspect(thumb)
+	require.NoError(t, err)
+	assert.Equal(t, Info{ContentType: "image/jpeg", Width: 320, Height: 160}, info)
+}
+
+func TestThumbnailKeepsSmallImages(t *testing.T) {
+	thumb, err := Thumbnail(encodePNG(t, 40, 90), 320)
+	require.NoError(t, err)
+
+	info, err := Inspect(thumb)
+	require.NoError(t, err)
+	assert.Equal(t, 40, info.Width)
+	assert.Equal(t, 90, info.Height)
+}
+
+func TestFit(t *testing.T) {
+	w, h := fit(3000, 1, 320)
+	assert.Equal(t, 320, w)
+	assert.Equal(t, 1, h)
+
+	w, h = fit(500, 1000, 320)
+	assert.Equal(t, 160, w)
+	assert.Equal(t, 320, h)
+}
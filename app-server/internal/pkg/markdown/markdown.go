@@ -0,0 +1,66 @@
+// Package markdown renders the post Markdown subset to sanitised HTML.
+//
+// The subset is CommonMark paragraphs, emphasis, strong, inline code,
+// fenced and indented code blocks, block quotes, lists and links, plus
+// ~~strikethrough~~, bare URL autolinks and ||spoilers||. Raw HTML is never
+// passed through; headings, images and anything else outside the subset
+// are reduced to their text.
+package markdown
+
+import (
+	"bytes"
+	stdhtml "html"
+	"regexp"
+
+	"github.com/microcosm-cc/bluemonday"
+	"github.com/yuin/goldmark"
+	"github.com/yuin/goldmark/extension"
+	"github.com/yuin/goldmark/renderer/html"
+)
+
+var md = goldmark.New(
+	goldmark.WithExtensions(
+		extension.Strikethrough,
+		extension.Linkify,
+		Spoiler,
+	),
+	// No html.WithUnsafe: raw HTML and javascript: style links are dropped
+	// by the renderer before the policy ever sees them.
+	goldmark.WithRendererOptions(html.WithHardWraps()),
+)
+
+// policy is the allow-list applied to the rendered HTML. It is the last
+// line of defence and must only admit what the subset produces.
+var policy = newPolicy()
+
+func newPolicy() *bluemonday.Policy {
+	p := bluemonday.NewPolicy()
+	p.AllowElements("p", "br", "em", "strong", "del", "code", "pre", "blockquote", "ul", "ol", "li")
+	p.AllowAttrs("start").Matching(bluemonday.Integer).OnElements("ol")
+	p.AllowAttrs("class").Matching(regexp.MustCompile(`^language-[\w+#-]+$`)).OnElements("code")
+	p.AllowAttrs("class").Matching(regexp.MustCompile(`^spoiler$`)).OnElements("span")
+
+	p.AllowAttrs("href").OnElements("a")
+	p.AllowURLSchemes("http", "https", "mailto")
+	p.RequireParseableURLs(true)
+	p.RequireNoFollowOnLinks(true)
+	p.RequireNoReferrerOnLinks(true)
+	p.AddTargetBlankToFullyQualifiedLinks(true)
+	return p
+}
+
+// Render converts src to sanitised HTML. It never fails: input the parser
+// cannot make sense of comes out as escaped text.
+func Render(src string) string {
+	if src == "" {
+		return ""
+	}
+
+	var buf bytes.Buffer
+	if err := md.Convert([]byte(src), &buf); err != nil {
+		// goldmark only fails on writer errors, which a bytes.Buffer
+		// does not produce; escape the text as a fallback anyway.
+		return "<p>" + stdhtml.EscapeString(src) + "</p>"
+	}
+	return policy.SanitizeReader(&buf).String()
+}
@@ -0,0 +1,61 @@
+package markdown
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRenderSubset(t *testing.T) {
+	cases := map[string]struct{ in, want string }{
+		"emphasis":      {"*a* **b** ~~c~~ `d`", "<p><em>a</em> <strong>b</strong> <del>c</del> <code>d</code></p>\n"},
+		"hard wraps":    {"one\ntwo", "<p>one<br>\ntwo</p>\n"},
+		"code block":    {"```go\nfmt.Println(\"<b>\")\n```", "<pre><code class=\"language-go\">fmt.Println(&#34;&lt;b&gt;&#34;)\n</code></pre>\n"},
+		"quote":         {"> quoted", "<blockquote>\n<p>quoted</p>\n</blockquote>\n"},
+		"list":          {"- a\n- b", "<ul>\n<li>a</li>\n<li>b</li>\n</ul>\n"},
+		"spoiler":       {"the end: ||he **lives**||", "<p>the end: <span class=\"spoiler\">he <strong>lives</strong></span></p>\n"},
+		"single pipe":   {"a | b", "<p>a | b</p>\n"},
+		"heading text":  {"# Title", "Title\n"},
+		"image dropped": {"![alt](https://example.com/x.png)", "<p></p>\n"},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			assert.Equal(t, tc.want, Render(tc.in))
+		})
+	}
+}
+
+func TestRenderLinks(t *testing.T) {
+	assert.Equal(t,
+		`<p><a href="https://example.com" rel="nofollow noreferrer noopener" target="_blank">site</a></p>`+"\n",
+		Render("[site](https://example.com)"))
+	assert.Equal(t,
+		`<p>see <a href="https://example.com/a" rel="nofollow noreferrer noopener" target="_blank">https://example.com/a</a></p>`+"\n",
+		Render("see https://example.com/a"))
+}
+
+func TestRenderNeutralisesInjection(t *testing.T) {
+	inputs := []string{
+		"<script>alert(1)</script>",
+		"<style>body{display:none}</style>",
+		"<img src=x onerror=alert(1)>",
+		"[click](javascript:alert(1))",
+		"[click](data:text/html;base64,PHNjcmlwdD4=)",
+		"<a href=\"https://x\" onclick=\"alert(1)\">x</a>",
+		"*<span style=\"color:red\">hi</span>*",
+	}
+	for _, in := range inputs {
+		out := Render(in)
+		assert.NotContains(t, out, "<script", in)
+		assert.NotContains(t, out, "<style", in)
+		assert.NotContains(t, out, "onerror", in)
+		assert.NotContains(t, out, "onclick", in)
+		assert.NotContains(t, out, "javascript:", in)
+		assert.NotContains(t, out, "data:", in)
+		assert.NotContains(t, out, "style=", in)
+	}
+}
+
+func TestRenderEmpty(t *testing.T) {
+	assert.Equal(t, "", Render(""))
+}
@@ -0,0 +1,92 @@
+package markdown
+
+import (
+	"github.com/yuin/goldmark"
+	gast "github.com/yuin/goldmark/ast"
+	"github.com/yuin/goldmark/parser"
+	"github.com/yuin/goldmark/renderer"
+	"github.com/yuin/goldmark/text"
+	"github.com/yuin/goldmark/util"
+)
+
+// SpoilerNode is inline text hidden until the reader reveals it, written
+// ||like this||.
+type SpoilerNode struct {
+	gast.BaseInline
+}
+
+var KindSpoiler = gast.NewNodeKind("Spoiler")
+
+func (n *SpoilerNode) Kind() gast.NodeKind {
+	return KindSpoiler
+}
+
+func (n *SpoilerNode) Dump(source []byte, level int) {
+	gast.DumpHelper(n, source, level, nil, nil)
+}
+
+// spoilerDelimiter pairs "||" runs the same way strikethrough pairs "~~",
+// so spoilers can wrap emphasis, links and code.
+type spoilerDelimiter struct{}
+
+func (spoilerDelimiter) IsDelimiter(b byte) bool {
+	return b == '|'
+}
+
+func (spoilerDelimiter) CanOpenCloser(opener, closer *parser.Delimiter) bool {
+	return opener.Char == closer.Char
+}
+
+func (spoilerDelimiter) OnMatch(consumes int) gast.Node {
+	return &SpoilerNode{}
+}
+
+type spoilerParser struct{}
+
+func (spoilerParser) Trigger() []byte {
+	return []byte{'|'}
+}
+
+func (spoilerParser) Parse(parent gast.Node, block text.Reader, pc parser.Context) gast.Node {
+	before := block.PrecendingCharacter()
+	line, segment := block.PeekLine()
+	node := parser.ScanDelimiter(line, before, 2, spoilerDelimiter{})
+	// Only exactly "||" delimits; a lone or tripled pipe is plain text.
+	if node == nil || node.OriginalLength != 2 || before == '|' {
+		return nil
+	}
+
+	node.Segment = segment.WithStop(segment.Start + node.OriginalLength)
+	block.Advance(node.OriginalLength)
+	pc.PushDelimiter(node)
+	return node
+}
+
+func (spoilerParser) CloseBlock(parent gast.Node, pc parser.Context) {}
+
+type spoilerRenderer struct{}
+
+func (spoilerRenderer) RegisterFuncs(reg renderer.NodeRendererFuncRegisterer) {
+	reg.Register(KindSpoiler, func(w util.BufWriter, source []byte, n gast.Node, entering bool) (gast.WalkStatus, error) {
+		if entering {
+			_, _ = w.WriteString(`<span class="spoiler">`)
+		} else {
+			_, _ = w.WriteString("</span>")
+		}
+		return gast.WalkContinue, nil
+	})
+}
+
+type spoiler struct{}
+
+// Spoiler is the goldmark extension for ||spoiler|| text.
+var Spoiler goldmark.Extender = spoiler{}
+
+func (spoiler) Extend(m goldmark.Markdown) {
+	m.Parser().AddOptions(parser.WithInlineParsers(
+		util.Prioritized(spoilerParser{}, 500),
+	))
+	m.Renderer().AddOptions(renderer.WithNodeRenderers(
+		util.Prioritized(spoilerRenderer{}, 500),
+	))
+}
@@ -0,0 +1,57 @@
+// Package nullable tells apart JSON fields that were left out of a request
+// from fields that were explicitly set to null, so PATCH handlers can clear
+// values without treating every empty field as a change.
+package nullable
+
+import (
+	"bytes"
+	"encoding/json"
+)
+
+// Field is a JSON field of type T. Present is false when the key was
+// missing; Null is true when it was sent as null.
+type Field[T any] struct {
+	Present bool
+	Null    bool
+	Value   T
+}
+
+// Of returns a field set to value.
+func Of[T any](value T) Field[T] {
+	return Field[T]{Present: true, Value: value}
+}
+
+// Null returns a field explicitly set to null.
+func Null[T any]() Field[T] {
+	return Field[T]{Present: true, Null: true}
+}
+
+// IsSet reports whether the field was sent with a non-null value.
+func (f Field[T]) IsSet() bool {
+	return f.Present && !f.Null
+}
+
+// IsNull reports whether the field was sent as null.
+func (f Field[T]) IsNull() bool {
+	return f.Present && f.Null
+}
+
+// UnmarshalJSON is only called for keys present in the input.
+func (f *Field[T]) UnmarshalJSON(data []byte) error {
+	f.Present = true
+	if bytes.Equal(bytes.TrimSpace(data), []byte("null")) {
+		f.Null = true
+		var zero T
+		f.Value = zero
+		return nil
+	}
+	f.Null = false
+	return json.Unmarshal(data, &f.Value)
+}
+
+func (f Field[T]) MarshalJSON() ([]byte, error) {
+	if !f.IsSet() {
+		return []byte("null"), nil
+	}
+	return json.Marshal(f.Value)
+}
@@ -0,0 +1,53 @@
+package nullable
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type patch struct {
+	Bio Field[string] `json:"bio"`
+}
+
+func TestUnmarshalDistinguishesMissingNullAndValue(t *testing.T) {
+	var missing, null, set patch
+	require.NoError(t, json.Unmarshal([]byte(`{}`), &missing))
+	require.NoError(t, json.Unmarshal([]byte(`{"bio": null}`), &null))
+	require.NoError(t, json.Unmarshal([]byte(`{"bio": "hi"}`), &set))
+
+	assert.False(t, missing.Bio.Present)
+	assert.False(t, missing.Bio.IsSet())
+	assert.False(t, missing.Bio.IsNull())
+
+	assert.True(t, null.Bio.IsNull())
+	assert.False(t, null.Bio.IsSet())
+
+	assert.True(t, set.Bio.IsSet())
+	assert.Equal(t, "hi", set.Bio.Value)
+}
+
+func TestEmptyStringIsAValue(t *testing.T) {
+	var p patch
+	require.NoError(t, json.Unmarshal([]byte(`{"bio": ""}`), &p))
+
+	assert.True(t, p.Bio.IsSet())
+	assert.Equal(t, "", p.Bio.Value)
+}
+
+func TestUnmarshalRejectsWrongType(t *testing.T) {
+	var p patch
+	assert.Error(t, json.Unmarshal([]byte(`{"bio": 42}`), &p))
+}
+
+func TestMarshal(t *testing.T) {
+	out, err := json.Marshal(patch{Bio: Of("hi")})
+	require.NoError(t, err)
+	assert.JSONEq(t, `{"bio": "hi"}`, string(out))
+
+	out, err = json.Marshal(patch{Bio: Null[string]()})
+	require.NoError(t, err)
+	assert.JSONEq(t, `{"bio": null}`, string(out))
+}
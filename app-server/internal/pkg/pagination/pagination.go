@@ -0,0 +1,143 @@
+package pagination
+
+import (
+	"fmt"
+	"slices"
+
+	"github.com/maulana1k/forum-app/internal/pkg/utils"
+	"gorm.io/gorm"
+)
+
+const (
+	DefaultLimit = 10
+	MaxLimit     = 100
+)
+
+// offsetEnabled keeps the legacy ?page= mode available to existing clients.
+var offsetEnabled = true
+
+// Configure sets the cursor signing secret and whether offset paging is
+// still accepted. Without a secret cursors are signed with a per-process
+// key and stop working after a restart.
+func Configure(cursorSecret string, allowOffset bool) {
+	if cursorSecret == "" {
+		utils.Logger.Warn("CURSOR_SECRET is not set, pagination cursors will not survive restarts")
+	} else {
+		secret = []byte(cursorSecret)
+	}
+	offsetEnabled = allowOffset
+}
+
+// Params describes one page request. Exactly one of Cursor and Page is
+// used: Page > 0 selects offset mode, otherwise keyset mode.
+type Params struct {
+	Limit  int
+	Page   int
+	Cursor *Cursor
+}
+
+// NewParams normalises raw query values into Params.
+func NewParams(limit, page int, cursor string) (Params, error) {
+	if limit <= 0 || limit > MaxLimit {
+		limit = DefaultLimit
+	}
+	p := Params{Limit: limit}
+
+	if cursor != "" {
+		c, err := Decode(cursor)
+		if err != nil {
+			return Params{}, err
+		}
+		p.Cursor = c
+		return p, nil
+	}
+
+	if page > 0 && offsetEnabled {
+		p.Page = page
+	}
+	return p, nil
+}
+
+func (p Params) IsOffset() bool {
+	return p.Page > 0
+}
+
+func (p Params) Offset() int {
+	return (p.Page - 1) * p.Limit
+}
+
+// Scope applies the page window to a query over table. In keyset mode it
+// fetches one extra row so Window can tell whether more rows follow. id
+// converts the cursor ID back into the column type.
+func (p Params) Scope(table string, id func(string) (any, error)) func(*gorm.DB) *gorm.DB {
+	return func(db *gorm.DB) *gorm.DB {
+		if p.IsOffset() {
+			return db.Order(fmt.Sprintf("%s.created_at DESC, %s.id DESC", table, table)).
+				Offset(p.Offset()).
+				Limit(p.Limit)
+		}
+
+		order, cmp := "DESC", "<"
+		if p.Cursor != nil && p.Cursor.Backward {
+			order, cmp = "ASC", ">"
+		}
+
+		if p.Cursor != nil {
+			cursorID, err := id(p.Cursor.ID)
+			if err != nil {
+				_ = db.AddError(ErrInvalidCursor.Wrap(err))
+				return db
+			}
+			db = db.Where(fmt.Sprintf("(%s.created_at, %s.id) %s (?, ?)", table, table, cmp), p.Cursor.CreatedAt, cursorID)
+		}
+
+		return db.Order(fmt.Sprintf("%s.created_at %s, %s.id %s", table, order, table, order)).
+			Limit(p.Limit + 1)
+	}
+}
+
+// Page is a window of items plus the cursors to its neighbours.
+type Page[T any] struct {
+	Items      []T
+	NextCursor string
+	PrevCursor string
+	HasNext    bool
+	HasPrev    bool
+}
+
+// Window trims the extra row fetched by Scope, restores newest-first order
+// for backward pages and builds the neighbour cursors.
+func Window[T any](rows []T, p Params, key func(T) Cursor) Page[T] {
+	more := len(rows) > p.Limit
+	if more {
+		rows = rows[:p.Limit]
+	}
+
+	backward := p.Cursor != nil && p.Cursor.Backward
+	if backward {
+		slices.Reverse(rows)
+	}
+
+	page := Page[T]{Items: rows}
+	if backward {
+		page.HasPrev = more
+		page.HasNext = true
+	} else {
+		page.HasNext = more
+		page.HasPrev = p.Cursor != nil
+	}
+
+	if len(rows) == 0 {
+		return page
+	}
+	if page.HasNext {
+		next := key(rows[len(rows)-1])
+		page.NextCursor = Encode(next)
+	}
+	if page.HasPrev {
+		prev := key(rows[0])
+		prev.Backward = true
+		page.PrevCursor = Encode(prev)
+	}
+	return page
+}
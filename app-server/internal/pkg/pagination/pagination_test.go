@@ -0,0 +1,104 @@
+package pagination
+
+import (
+	"errors"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type row struct {
+	id int
+	at time.Time
+}
+
+func rowCursor(r row) Cursor {
+	return Cursor{CreatedAt: r.at, ID: strconv.Itoa(r.id)}
+}
+
+func rows(ids ...int) []row {
+	base := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	out := make([]row, len(ids))
+	for i, id := range ids {
+		out[i] = row{id: id, at: base.Add(time.Duration(id) * time.Minute)}
+	}
+	return out
+}
+
+func TestCursorRoundTrip(t *testing.T) {
+	want := Cursor{CreatedAt: time.Now().UTC(), ID: "42", Backward: true}
+
+	got, err := Decode(Encode(want))
+	require.NoError(t, err)
+	assert.True(t, want.CreatedAt.Equal(got.CreatedAt))
+	assert.Equal(t, want.ID, got.ID)
+	assert.True(t, got.Backward)
+}
+
+func TestDecodeRejectsTamperedCursor(t *testing.T) {
+	token := Encode(Cursor{CreatedAt: time.Now(), ID: "1"})
+	forged := Encode(Cursor{CreatedAt: time.Now(), ID: "2"})
+
+	body, _, _ := strings.Cut(token, ".")
+	_, sig, _ := strings.Cut(forged, ".")
+
+	for _, bad := range []string{"", "garbage", body + "." + sig, token + "x"} {
+		_, err := Decode(bad)
+		assert.True(t, errors.Is(err, ErrInvalidCursor), "token %q", bad)
+	}
+}
+
+func TestWindowFirstPage(t *testing.T) {
+	p := Params{Limit: 2}
+
+	page := Window(rows(5, 4, 3), p, rowCursor)
+
+	assert.Len(t, page.Items, 2)
+	assert.True(t, page.HasNext)
+	assert.False(t, page.HasPrev)
+	assert.Empty(t, page.PrevCursor)
+
+	next, err := Decode(page.NextCursor)
+	require.NoError(t, err)
+	assert.Equal(t, "4", next.ID)
+	assert.False(t, next.Backward)
+}
+
+func TestWindowBackwardPageIsNewestFirst(t *testing.T) {
+	p := Params{Limit: 2, Cursor: &Cursor{ID: "3", Backward: true}}
+
+	// Backward queries come back oldest first with one extra row.
+	page := Window(rows(4, 5, 6), p, rowCursor)
+
+	require.Len(t, page.Items, 2)
+	assert.Equal(t, 5, page.Items[0].id)
+	assert.Equal(t, 4, page.Items[1].id)
+	assert.True(t, page.HasNext)
+	assert.True(t, page.HasPrev)
+
+	prev, err := Decode(page.PrevCursor)
+	require.NoError(t, err)
+	assert.Equal(t, "5", prev.ID)
+	assert.True(t, prev.Backward)
+}
+
+func TestNewParamsOffsetFlag(t *testing.T) {
+	defer func(v bool) { offsetEnabled = v }(offsetEnabled)
+
+	offsetEnabled = true
+	p, err := NewParams(0, 3, "")
+	require.NoError(t, err)
+	assert.True(t, p.IsOffset())
+	assert.Equal(t, DefaultLimit, p.Limit)
+	assert.Equal(t, 20, p.Offset())
+
+	offsetEnabled = false
+	p, err = NewParams(500, 3, "")
+	require.NoError(t, err)
+	assert.False(t, p.IsOffset())
+	assert.Equal(t, DefaultLimit, p.Limit)
+}
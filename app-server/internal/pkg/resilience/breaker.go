@@ -0,0 +1,131 @@
+// Package resilience guards calls to remote services with retries and a
+// circuit breaker.
+package resilience
+
+import (
+	"errors"
+	"sync"
+	"time"
+)
+
+// ErrOpen is returned instead of calling a service whose breaker is open.
+var ErrOpen = errors.New("circuit breaker is open")
+
+// State is the position of a Breaker.
+type State int
+
+const (
+	// Closed lets every call through.
+	Closed State = iota
+	// Open rejects calls until the cooldown has passed.
+	Open
+	// HalfOpen lets a single probe through to test the service.
+	HalfOpen
+)
+
+func (s State) String() string {
+	switch s {
+	case Open:
+		return "open"
+	case HalfOpen:
+		return "half_open"
+	default:
+		return "closed"
+	}
+}
+
+// BreakerOptions tunes a Breaker.
+type BreakerOptions struct {
+	// Threshold is how many failures in a row open the breaker.
+	Threshold int
+	// Cooldown is how long the breaker stays open before a probe.
+	Cooldown time.Duration
+}
+
+// Breaker stops calling a service after Threshold failures in a row, so a
+// service that is down fails fast instead of holding up every request.
+// After Cooldown one call is let through; its outcome closes the breaker
+// or opens it again.
+type Breaker struct {
+	opts BreakerOptions
+	now  func() time.Time
+
+	mu       sync.Mutex
+	state    State
+	failures int
+	openedAt time.Time
+	probing  bool
+}
+
+func NewBreaker(opts BreakerOptions) *Breaker {
+	if opts.Threshold <= 0 {
+		opts.Threshold = 5
+	}
+	if opts.Cooldown <= 0 {
+		opts.Cooldown = 30 * time.Second
+	}
+	return &Breaker{opts: opts, now: time.Now}
+}
+
+// State returns the current state, moving an open breaker whose cooldown
+// has passed to HalfOpen.
+func (b *Breaker) State() State {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.advance()
+	return b.state
+}
+
+// Do calls fn unless the breaker is open. isFailure decides which errors
+// count against the service; a nil isFailure counts every error.
+func (b *Breaker) Do(fn func() error, isFailure func(error) bool) error {
+	if err := b.acquire(); err != nil {
+		return err
+	}
+
+	err := fn()
+	b.record(err != nil && (isFailure == nil || isFailure(err)))
+	return err
+}
+
+func (b *Breaker) acquire() error {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.advance()
+	switch b.state {
+	case Open:
+		return ErrOpen
+	case HalfOpen:
+		if b.probing {
+			return ErrOpen
+		}
+		b.probing = true
+	}
+	return nil
+}
+
+func (b *Breaker) record(failed bool) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.probing = false
+	if !failed {
+		b.state = Closed
+		b.failures = 0
+		return
+	}
+
+	b.failures++
+	if b.state == HalfOpen || b.failures >= b.opts.Threshold {
+		b.state = Open
+		b.openedAt = b.now()
+	}
+}
+
+// advance must be called with mu held.
+func (b *Breaker) advance() {
+	if b.state == Open && b.now().Sub(b.openedAt) >= b.opts.Cooldown {
+		b.state = HalfOpen
+	}
+}
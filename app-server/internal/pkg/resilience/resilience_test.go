@@ -0,0 +1,136 @@
+package resilience
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+var errBoom = errors.New("boom")
+
+func fail() error { return errBoom }
+func ok() error   { return nil }
+
+func newTestBreaker(threshold int, cooldown time.Duration) (*Breaker, *time.Time) {
+	now := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	b := NewBreaker(BreakerOptions{Threshold: threshold, Cooldown: cooldown})
+	b.now = func() time.Time { return now }
+	return b, &now
+}
+
+func TestBreakerOpensAfterThreshold(t *testing.T) {
+	b, _ := newTestBreaker(3, time.Minute)
+
+	for i := 0; i < 2; i++ {
+		assert.ErrorIs(t, b.Do(fail, nil), errBoom)
+	}
+	assert.Equal(t, Closed, b.State())
+
+	// A success resets the count.
+	assert.NoError(t, b.Do(ok, nil))
+	for i := 0; i < 3; i++ {
+		assert.ErrorIs(t, b.Do(fail, nil), errBoom)
+	}
+	assert.Equal(t, Open, b.State())
+
+	called := false
+	err := b.Do(func() error { called = true; return nil }, nil)
+	assert.ErrorIs(t, err, ErrOpen)
+	assert.False(t, called)
+}
+
+func TestBreakerHalfOpenProbe(t *testing.T) {
+	b, now := newTestBreaker(1, time.Minute)
+	assert.ErrorIs(t, b.Do(fail, nil), errBoom)
+
+	*now = now.Add(time.Minute)
+	assert.Equal(t, HalfOpen, b.State())
+
+	// A failed probe opens the breaker again for a full cooldown.
+	assert.ErrorIs(t, b.Do(fail, nil), errBoom)
+	assert.Equal(t, Open, b.State())
+
+	*now = now.Add(time.Minute)
+	assert.NoError(t, b.Do(ok, nil))
+	assert.Equal(t, Closed, b.State())
+}
+
+func TestBreakerSingleProbe(t *testing.T) {
+	b, now := newTestBreaker(1, time.Second)
+	assert.ErrorIs(t, b.Do(fail, nil), errBoom)
+	*now = now.Add(time.Second)
+
+	err := b.Do(func() error {
+		// Another call while the probe is in flight is rejected.
+		assert.ErrorIs(t, b.Do(ok, nil), ErrOpen)
+		return nil
+	}, nil)
+	assert.NoError(t, err)
+	assert.Equal(t, Closed, b.State())
+}
+
+func TestBreakerIgnoresNonFailures(t *testing.T) {
+	b, _ := newTestBreaker(1, time.Minute)
+	notOurs := func(err error) bool { return false }
+
+	assert.ErrorIs(t, b.Do(fail, notOurs), errBoom)
+	assert.Equal(t, Closed, b.State())
+}
+
+func TestRetry(t *testing.T) {
+	opts := RetryOptions{Attempts: 3, BaseDelay: time.Millisecond}
+	always := func(error) bool { return true }
+
+	calls := 0
+	err := Retry(context.Background(), opts, func(context.Context) error {
+		calls++
+		if calls < 2 {
+			return errBoom
+		}
+		return nil
+	}, always)
+	assert.NoError(t, err)
+	assert.Equal(t, 2, calls)
+
+	calls = 0
+	err = Retry(context.Background(), opts, func(context.Context) error {
+		calls++
+		return errBoom
+	}, always)
+	assert.ErrorIs(t, err, errBoom)
+	assert.Equal(t, 3, calls)
+
+	calls = 0
+	err = Retry(context.Background(), opts, func(context.Context) error {
+		calls++
+		return errBoom
+	}, func(error) bool { return false })
+	assert.ErrorIs(t, err, errBoom)
+	assert.Equal(t, 1, calls)
+}
+
+func TestRetryStopsWhenContextDone(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	opts := RetryOptions{Attempts: 5, BaseDelay: time.Hour}
+
+	calls := 0
+	err := Retry(ctx, opts, func(context.Context) error {
+		calls++
+		cancel()
+		return errBoom
+	}, func(error) bool { return true })
+	assert.ErrorIs(t, err, errBoom)
+	assert.Equal(t, 1, calls)
+}
+
+func TestJitterBounds(t *testing.T) {
+	opts := RetryOptions{BaseDelay: 10 * time.Millisecond, MaxDelay: 30 * time.Millisecond}
+	for i := 0; i < 100; i++ {
+		assert.LessOrEqual(t, jitter(opts, 1), 10*time.Millisecond)
+		assert.LessOrEqual(t, jitter(opts, 2), 20*time.Millisecond)
+		assert.LessOrEqual(t, jitter(opts, 10), 30*time.Millisecond)
+	}
+}
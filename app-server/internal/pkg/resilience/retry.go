@@ -0,0 +1,62 @@
+package resilience
+
+import (
+	"context"
+	"math/rand/v2"
+	"time"
+)
+
+// RetryOptions tunes Retry.
+type RetryOptions struct {
+	// Attempts is the total number of calls, including the first.
+	Attempts int
+	// BaseDelay is the upper bound of the first wait; it doubles on every
+	// attempt up to MaxDelay.
+	BaseDelay time.Duration
+	MaxDelay  time.Duration
+}
+
+// Retry calls fn until it succeeds, returns an error retryable rejects, or
+// runs out of attempts. Waits are drawn at random up to an exponentially
+// growing bound, so clients that failed together do not retry together.
+// Only use it for idempotent calls. It gives up early once ctx is done and
+// returns the last error of fn.
+func Retry(ctx context.Context, opts RetryOptions, fn func(ctx context.Context) error, retryable func(error) bool) error {
+	if opts.Attempts <= 0 {
+		opts.Attempts = 1
+	}
+	if opts.BaseDelay <= 0 {
+		opts.BaseDelay = 50 * time.Millisecond
+	}
+	if opts.MaxDelay < opts.BaseDelay {
+		opts.MaxDelay = opts.BaseDelay
+	}
+
+	var err error
+	for attempt := 0; attempt < opts.Attempts; attempt++ {
+		if attempt > 0 {
+			timer := time.NewTimer(jitter(opts, attempt))
+			select {
+			case <-ctx.Done():
+				timer.Stop()
+				return err
+			case <-timer.C:
+			}
+		}
+
+		err = fn(ctx)
+		if err == nil || !retryable(err) || ctx.Err() != nil {
+			return err
+		}
+	}
+	return err
+}
+
+// jitter returns a random wait in [0, min(MaxDelay, BaseDelay*2^(attempt-1))].
+func jitter(opts RetryOptions, attempt int) time.Duration {
+	bound := opts.BaseDelay << (attempt - 1)
+	if bound <= 0 || bound > opts.MaxDelay {
+		bound = opts.MaxDelay
+	}
+	return rand.N(bound + 1)
+}
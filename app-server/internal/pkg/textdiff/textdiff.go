@@ -0,0 +1,91 @@
+// Package textdiff computes word level differences between two texts.
+package textdiff
+
+import "unicode"
+
+// Op is the kind of a diff segment.
+type Op string
+
+const (
+	Equal  Op = "equal"
+	Insert Op = "insert"
+	Delete Op = "delete"
+)
+
+// Segment is a run of text that is kept, inserted or deleted.
+type Segment struct {
+	Op   Op
+	Text string
+}
+
+// Words diffs a against b. Text is split into words and the whitespace
+// between them, so joining the Equal and Insert segments yields b and
+// joining the Equal and Delete segments yields a.
+func Words(a, b string) []Segment {
+	x, y := tokenize(a), tokenize(b)
+
+	// lcs[i][j] is the length of the longest common subsequence of x[i:]
+	// and y[j:].
+	lcs := make([][]int, len(x)+1)
+	for i := range lcs {
+		lcs[i] = make([]int, len(y)+1)
+	}
+	for i := len(x) - 1; i >= 0; i-- {
+		for j := len(y) - 1; j >= 0; j-- {
+			if x[i] == y[j] {
+				lcs[i][j] = lcs[i+1][j+1] + 1
+			} else {
+				lcs[i][j] = max(lcs[i+1][j], lcs[i][j+1])
+			}
+		}
+	}
+
+	var out []Segment
+	emit := func(op Op, text string) {
+		if n := len(out); n > 0 && out[n-1].Op == op {
+			out[n-1].Text += text
+			return
+		}
+		out = append(out, Segment{Op: op, Text: text})
+	}
+
+	i, j := 0, 0
+	for i < len(x) && j < len(y) {
+		switch {
+		case x[i] == y[j]:
+			emit(Equal, x[i])
+			i++
+			j++
+		case lcs[i+1][j] >= lcs[i][j+1]:
+			emit(Delete, x[i])
+			i++
+		default:
+			emit(Insert, y[j])
+			j++
+		}
+	}
+	for ; i < len(x); i++ {
+		emit(Delete, x[i])
+	}
+	for ; j < len(y); j++ {
+		emit(Insert, y[j])
+	}
+	return out
+}
+
+// tokenize splits s into alternating runs of whitespace and non-whitespace.
+func tokenize(s string) []string {
+	var tokens []string
+	start, space := 0, false
+	for i, r := range s {
+		if i > start && unicode.IsSpace(r) != space {
+			tokens = append(tokens, s[start:i])
+			start = i
+		}
+		space = unicode.IsSpace(r)
+	}
+	if start < len(s) {
+		tokens = append(tokens, s[start:])
+	}
+	return tokens
+}
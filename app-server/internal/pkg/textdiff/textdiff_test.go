@@ -0,0 +1,50 @@
+package textdiff
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func join(segs []Segment, skip Op) string {
+	var b strings.Builder
+	for _, s := range segs {
+		if s.Op != skip {
+			b.WriteString(s.Text)
+		}
+	}
+	return b.String()
+}
+
+func TestWords(t *testing.T) {
+	segs := Words("the quick brown fox", "the slow brown fox jumps")
+
+	assert.Equal(t, []Segment{
+		{Equal, "the "},
+		{Delete, "quick"},
+		{Insert, "slow"},
+		{Equal, " brown fox"},
+		{Insert, " jumps"},
+	}, segs)
+}
+
+func TestWordsRoundTrip(t *testing.T) {
+	cases := [][2]string{
+		{"", ""},
+		{"", "new text"},
+		{"old text", ""},
+		{"héllo  wörld\nline two", "héllo wörld\n\nline 2"},
+		{"same", "same"},
+	}
+	for _, c := range cases {
+		segs := Words(c[0], c[1])
+		assert.Equal(t, c[0], join(segs, Insert), "old side of %q", c)
+		assert.Equal(t, c[1], join(segs, Delete), "new side of %q", c)
+	}
+}
+
+func TestWordsUnchanged(t *testing.T) {
+	assert.Equal(t, []Segment{{Equal, "no change here"}}, Words("no change here", "no change here"))
+	assert.Empty(t, Words("", ""))
+}
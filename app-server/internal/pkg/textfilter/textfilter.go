@@ -0,0 +1,156 @@
+// Package textfilter matches muted words, phrases and hashtags against
+// post text.
+//
+// Text and terms are split into lower case tokens of letters, digits and
+// underscores, so matching is case-insensitive and only ever hits whole
+// words: muting "cat" hides "Cat!" but not "category". A phrase matches
+// its words in sequence, whatever the punctuation between them. A term
+// written "#tag" only matches the hashtag; a plain word also matches the
+// hashtag of the same name.
+package textfilter
+
+import (
+	"strings"
+	"unicode"
+)
+
+type token struct {
+	text    string
+	hashtag bool
+}
+
+type term struct {
+	raw     string
+	tokens  []token
+	hashtag bool
+}
+
+// Matcher holds a set of muted terms.
+type Matcher struct {
+	terms []term
+}
+
+// NewMatcher builds a Matcher for terms. Terms without any word in them
+// are ignored.
+func NewMatcher(terms []string) *Matcher {
+	m := &Matcher{}
+	for _, raw := range terms {
+		tokens := tokenize(raw)
+		if len(tokens) == 0 {
+			continue
+		}
+		m.terms = append(m.terms, term{
+			raw:     raw,
+			tokens:  tokens,
+			hashtag: len(tokens) == 1 && tokens[0].hashtag,
+		})
+	}
+	return m
+}
+
+// Empty reports whether the matcher has no terms.
+func (m *Matcher) Empty() bool {
+	return m == nil || len(m.terms) == 0
+}
+
+// Match returns the terms found in text, in the order they were given.
+// tags are treated as hashtags of the text.
+func (m *Matcher) Match(text string, tags ...string) []string {
+	if m.Empty() {
+		return nil
+	}
+
+	tokens := tokenize(text)
+	for _, t := range tags {
+		for _, tok := range tokenize(t) {
+			tok.hashtag = true
+			tokens = append(tokens, tok)
+		}
+	}
+
+	var matched []string
+	for _, t := range m.terms {
+		if t.matches(tokens) {
+			matched = append(matched, t.raw)
+		}
+	}
+	return matched
+}
+
+func (t term) matches(tokens []token) bool {
+	n := len(t.tokens)
+	for i := 0; i+n <= len(tokens); i++ {
+		if t.hashtag {
+			if tokens[i].hashtag && tokens[i].text == t.tokens[0].text {
+				return true
+			}
+			continue
+		}
+
+		found := true
+		for j := range t.tokens {
+			if tokens[i+j].text != t.tokens[j].text {
+				found = false
+				break
+			}
+		}
+		if found {
+			return true
+		}
+	}
+	return false
+}
+
+// Normalize returns the canonical form of a term: its words in lower case
+// separated by single spaces, with a leading "#" kept for hashtags. It
+// returns "" if term has no words.
+func Normalize(raw string) string {
+	tokens := tokenize(raw)
+	if len(tokens) == 0 {
+		return ""
+	}
+	if len(tokens) == 1 && tokens[0].hashtag {
+		return "#" + tokens[0].text
+	}
+
+	words := make([]string, len(tokens))
+	for i, t := range tokens {
+		words[i] = t.text
+	}
+	return strings.Join(words, " ")
+}
+
+func isWordRune(r rune) bool {
+	return unicode.IsLetter(r) || unicode.IsDigit(r) || r == '_'
+}
+
+func tokenize(s string) []token {
+	var (
+		tokens  []token
+		current strings.Builder
+		hashtag bool
+		prev    rune
+	)
+	flush := func() {
+		if current.Len() > 0 {
+			tokens = append(tokens, token{text: current.String(), hashtag: hashtag})
+			current.Reset()
+		}
+		hashtag = false
+	}
+
+	for _, r := range s {
+		switch {
+		case isWordRune(r):
+			if current.Len() == 0 && prev == '#' {
+				hashtag = true
+			}
+			current.WriteRune(unicode.ToLower(r))
+		default:
+			flush()
+		}
+		prev = r
+	}
+	flush()
+	return tokens
+}
@@ -0,0 +1,61 @@
+package textfilter
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMatchWholeWordsOnly(t *testing.T) {
+	m := NewMatcher([]string{"cat"})
+
+	assert.Equal(t, []string{"cat"}, m.Match("My CAT, again!"))
+	assert.Empty(t, m.Match("a category of its own"))
+	assert.Empty(t, m.Match("concatenate"))
+}
+
+func TestMatchPhrase(t *testing.T) {
+	m := NewMatcher([]string{"season finale"})
+
+	assert.Equal(t, []string{"season finale"}, m.Match("That Season -- finale though"))
+	assert.Empty(t, m.Match("the finale of the season"))
+	assert.Empty(t, m.Match("season"))
+}
+
+func TestMatchHashtags(t *testing.T) {
+	m := NewMatcher([]string{"#spoilers"})
+
+	assert.Equal(t, []string{"#spoilers"}, m.Match("no #Spoilers please"))
+	assert.Empty(t, m.Match("no spoilers please"), "a hashtag term only matches the hashtag")
+	assert.Equal(t, []string{"#spoilers"}, m.Match("plain text", "tv, spoilers"), "tags count as hashtags")
+
+	word := NewMatcher([]string{"spoilers"})
+	assert.Equal(t, []string{"spoilers"}, word.Match("#spoilers ahead"), "a word also matches its hashtag")
+}
+
+func TestMatchReturnsEveryTermFound(t *testing.T) {
+	m := NewMatcher([]string{"rust", "go", "zig"})
+
+	assert.Equal(t, []string{"rust", "go"}, m.Match("Go or Rust?"))
+}
+
+func TestMatchUnicode(t *testing.T) {
+	m := NewMatcher([]string{"Überraschung"})
+
+	assert.Equal(t, []string{"Überraschung"}, m.Match("eine ÜBERRASCHUNG!"))
+}
+
+func TestEmptyMatcher(t *testing.T) {
+	var m *Matcher
+	assert.True(t, m.Empty())
+	assert.Nil(t, m.Match("anything"))
+
+	assert.True(t, NewMatcher([]string{"", "  ", "!!"}).Empty())
+}
+
+func TestNormalize(t *testing.T) {
+	assert.Equal(t, "season finale", Normalize("  Season,  FINALE "))
+	assert.Equal(t, "#spoilers", Normalize("#Spoilers"))
+	assert.Equal(t, "spoilers", Normalize("spoilers"))
+	assert.Equal(t, "", Normalize("?!"))
+}
@@ -0,0 +1,280 @@
+// Package unfurl finds links in post text and fetches their OpenGraph and
+// Twitter card metadata.
+package unfurl
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"mime"
+	"net"
+	"net/http"
+	"net/url"
+	"regexp"
+	"strings"
+	"syscall"
+	"time"
+
+	"golang.org/x/net/html"
+)
+
+var (
+	// ErrBlocked is returned for URLs that resolve to loopback, private or
+	// otherwise internal addresses.
+	ErrBlocked = errors.New("unfurl: destination not allowed")
+	ErrNotHTML = errors.New("unfurl: response is not HTML")
+)
+
+// urlPattern matches http(s) links in free text. Trailing punctuation is
+// trimmed afterwards so "see https://x.com/a." yields "https://x.com/a".
+var urlPattern = regexp.MustCompile(`https?://[^\s<>"'` + "`" + `]+`)
+
+// ExtractURLs returns the distinct http(s) URLs in text, in order of
+// appearance.
+func ExtractURLs(text string) []string {
+	var urls []string
+	seen := make(map[string]bool)
+	for _, match := range urlPattern.FindAllString(text, -1) {
+		match = strings.TrimRight(match, ".,;:!?)]}")
+		u, err := url.Parse(match)
+		if err != nil || u.Host == "" || seen[match] {
+			continue
+		}
+		seen[match] = true
+		urls = append(urls, match)
+	}
+	return urls
+}
+
+// Preview is the card metadata of a page.
+type Preview struct {
+	URL         string
+	Title       string
+	Description string
+	ImageURL    string
+	SiteName    string
+}
+
+// Options bounds what a Fetcher is willing to do for a single URL.
+type Options struct {
+	Timeout      time.Duration
+	MaxBytes     int64
+	MaxRedirects int
+	UserAgent    string
+	// AllowPrivate disables the internal address check. Only for tests
+	// against local servers.
+	AllowPrivate bool
+}
+
+// Fetcher downloads pages and extracts their previews.
+type Fetcher struct {
+	client    *http.Client
+	maxBytes  int64
+	userAgent string
+}
+
+func NewFetcher(opts Options) *Fetcher {
+	if opts.Timeout <= 0 {
+		opts.Timeout = 5 * time.Second
+	}
+	if opts.MaxBytes <= 0 {
+		opts.MaxBytes = 1 << 20
+	}
+	if opts.MaxRedirects <= 0 {
+		opts.MaxRedirects = 5
+	}
+	if opts.UserAgent == "" {
+		opts.UserAgent = "ForumAppBot/1.0 (+link preview)"
+	}
+
+	dialer := &net.Dialer{Timeout: opts.Timeout}
+	if !opts.AllowPrivate {
+		// Checked on the resolved address at connect time, so DNS
+		// rebinding and redirects to internal hosts are caught too.
+		dialer.Control = func(network, address string, _ syscall.RawConn) error {
+			host, _, err := net.SplitHostPort(address)
+			if err != nil {
+				return err
+			}
+			if ip := net.ParseIP(host); ip == nil || !isPublic(ip) {
+				return ErrBlocked
+			}
+			return nil
+		}
+	}
+
+	transport := &http.Transport{
+		// A proxy would make the dialer check the proxy, not the target.
+		Proxy:                 nil,
+		DialContext:           dialer.DialContext,
+		TLSHandshakeTimeout:   opts.Timeout,
+		ResponseHeaderTimeout: opts.Timeout,
+		MaxIdleConns:          10,
+		IdleConnTimeout:       30 * time.Second,
+	}
+
+	return &Fetcher{
+		client: &http.Client{
+			Transport: transport,
+			Timeout:   opts.Timeout,
+			CheckRedirect: func(req *http.Request, via []*http.Request) error {
+				if len(via) >= opts.MaxRedirects {
+					return fmt.Errorf("unfurl: stopped after %d redirects", len(via))
+				}
+				return checkScheme(req.URL)
+			},
+		},
+		maxBytes:  opts.MaxBytes,
+		userAgent: opts.UserAgent,
+	}
+}
+
+// Fetch downloads rawURL and extracts its preview. At most MaxBytes of the
+// body are read; metadata after that point is ignored.
+func (f *Fetcher) Fetch(ctx context.Context, rawURL string) (*Preview, error) {
+	u, err := url.Parse(rawURL)
+	if err != nil {
+		return nil, err
+	}
+	if err := checkScheme(u); err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, u.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("User-Agent", f.userAgent)
+	req.Header.Set("Accept", "text/html,application/xhtml+xml")
+
+	resp, err := f.client.Do(req)
+	if err != nil {
+		if errors.Is(err, ErrBlocked) {
+			return nil, ErrBlocked
+		}
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("unfurl: %s returned %s", u.Host, resp.Status)
+	}
+	mediaType, _, _ := mime.ParseMediaType(resp.Header.Get("Content-Type"))
+	if mediaType != "text/html" && mediaType != "application/xhtml+xml" {
+		return nil, ErrNotHTML
+	}
+
+	preview := parse(io.LimitReader(resp.Body, f.maxBytes), resp.Request.URL)
+	preview.URL = rawURL
+	return preview, nil
+}
+
+func checkScheme(u *url.URL) error {
+	if u.Scheme != "http" && u.Scheme != "https" {
+		return ErrBlocked
+	}
+	return nil
+}
+
+// cgnat is the shared address space (RFC 6598), not covered by
+// net.IP.IsPrivate.
+var cgnat = &net.IPNet{IP: net.IPv4(100, 64, 0, 0), Mask: net.CIDRMask(10, 32)}
+
+// nat64 is the well-known NAT64 prefix (RFC 6052). Its addresses embed an
+// IPv4 address a NAT64 gateway forwards to, which may be an internal one.
+var nat64 = &net.IPNet{IP: net.ParseIP("64:ff9b::"), Mask: net.CIDRMask(96, 128)}
+
+func isPublic(ip net.IP) bool {
+	return !(ip.IsLoopback() || ip.IsPrivate() || ip.IsUnspecified() ||
+		ip.IsLinkLocalUnicast() || ip.IsLinkLocalMulticast() ||
+		ip.IsInterfaceLocalMulticast() || ip.IsMulticast() ||
+		cgnat.Contains(ip) || nat64.Contains(ip))
+}
+
+// parse reads card metadata from the document head. OpenGraph tags win
+// over Twitter tags, which win over <title> and the description meta tag.
+func parse(r io.Reader, base *url.URL) *Preview {
+	meta := make(map[string]string)
+	var title string
+
+	z := html.NewTokenizer(r)
+	for {
+		tt := z.Next()
+		if tt == html.ErrorToken {
+			break
+		}
+		name, hasAttr := z.TagName()
+		tag := string(name)
+
+		if tt == html.EndTagToken && tag == "head" {
+			break
+		}
+		if tt != html.StartTagToken && tt != html.SelfClosingTagToken {
+			continue
+		}
+		if tag == "body" {
+			break
+		}
+
+		switch {
+		case tag == "title" && title == "":
+			if z.Next() == html.TextToken {
+				title = strings.TrimSpace(string(z.Text()))
+			}
+		case tag == "meta" && hasAttr:
+			var key, content string
+			for {
+				k, v, more := z.TagAttr()
+				switch string(k) {
+				case "property", "name":
+					key = strings.ToLower(string(v))
+				case "content":
+					content = strings.TrimSpace(string(v))
+				}
+				if !more {
+					break
+				}
+			}
+			if key != "" && content != "" {
+				if _, ok := meta[key]; !ok {
+					meta[key] = content
+				}
+			}
+		}
+	}
+
+	return &Preview{
+		Title:       first(meta["og:title"], meta["twitter:title"], title),
+		Description: first(meta["og:description"], meta["twitter:description"], meta["description"]),
+		ImageURL:    resolve(base, first(meta["og:image"], meta["twitter:image"], meta["twitter:image:src"])),
+		SiteName:    first(meta["og:site_name"], base.Hostname()),
+	}
+}
+
+func first(values ...string) string {
+	for _, v := range values {
+		if v != "" {
+			return v
+		}
+	}
+	return ""
+}
+
+// resolve makes ref absolute against base, dropping anything that is not
+// http(s).
+func resolve(base *url.URL, ref string) string {
+	if ref == "" {
+		return ""
+	}
+	u, err := base.Parse(ref)
+	if err != nil || checkScheme(u) != nil {
+		return ""
+	}
+	return u.String()
+}
+
+// Empty reports whether the page offered nothing worth showing.
+func (p *Preview) Empty() bool {
+	return p.Title == "" && p.Description == "" && p.ImageURL == ""
+}
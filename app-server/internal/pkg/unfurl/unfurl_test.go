@@ -0,0 +1,149 @@
+package unfurl
+
+import (
+	"context"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const page = `<!doctype html>
+<html><head>
+<title>Fallback title</title>
+<meta property="og:title" content="Open Graph title">
+<meta name="twitter:title" content="Twitter title">
+<meta name="twitter:description" content="A card description">
+<meta property="og:image" content="/img/card.png">
+</head><body><meta property="og:site_name" content="ignored"></body></html>`
+
+func newServer(t *testing.T, handler http.HandlerFunc) *httptest.Server {
+	t.Helper()
+	srv := httptest.NewServer(handler)
+	t.Cleanup(srv.Close)
+	return srv
+}
+
+func localFetcher(opts Options) *Fetcher {
+	opts.AllowPrivate = true
+	return NewFetcher(opts)
+}
+
+func TestExtractURLs(t *testing.T) {
+	urls := ExtractURLs("see https://example.com/a. and (http://x.org/b?c=1) https://example.com/a again, ftp://no")
+	assert.Equal(t, []string{"https://example.com/a", "http://x.org/b?c=1"}, urls)
+	assert.Empty(t, ExtractURLs("no links here"))
+}
+
+func TestFetch(t *testing.T) {
+	srv := newServer(t, func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "text/html; charset=utf-8")
+		w.Write([]byte(page))
+	})
+
+	preview, err := localFetcher(Options{}).Fetch(context.Background(), srv.URL+"/post")
+	require.NoError(t, err)
+	assert.Equal(t, "Open Graph title", preview.Title)
+	assert.Equal(t, "A card description", preview.Description)
+	assert.Equal(t, srv.URL+"/img/card.png", preview.ImageURL)
+	assert.Equal(t, "127.0.0.1", preview.SiteName)
+	assert.Equal(t, srv.URL+"/post", preview.URL)
+}
+
+func TestFetchFallsBackToTitle(t *testing.T) {
+	srv := newServer(t, func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "text/html")
+		w.Write([]byte(`<html><head><title> Plain page </title><meta name="description" content="desc"></head></html>`))
+	})
+
+	preview, err := localFetcher(Options{}).Fetch(context.Background(), srv.URL)
+	require.NoError(t, err)
+	assert.Equal(t, "Plain page", preview.Title)
+	assert.Equal(t, "desc", preview.Description)
+	assert.Empty(t, preview.ImageURL)
+}
+
+func TestFetchBlocksInternalAddresses(t *testing.T) {
+	srv := newServer(t, func(w http.ResponseWriter, r *http.Request) {
+		t.Error("internal server must not be reached")
+	})
+
+	_, err := NewFetcher(Options{}).Fetch(context.Background(), srv.URL)
+	assert.ErrorIs(t, err, ErrBlocked)
+
+	_, err = NewFetcher(Options{}).Fetch(context.Background(), "file:///etc/passwd")
+	assert.ErrorIs(t, err, ErrBlocked)
+}
+
+func TestIsPublic(t *testing.T) {
+	tests := []struct {
+		ip     string
+		public bool
+	}{
+		{"93.184.215.14", true},
+		{"2606:2800:21f:cb07:6820:80da:af6b:8b2c", true},
+		{"127.0.0.1", false},
+		{"10.0.0.1", false},
+		{"169.254.169.254", false},
+		{"100.64.0.1", false},
+		{"::1", false},
+		{"fd00::1", false},
+		{"64:ff9b::a9fe:a9fe", false},
+		{"64:ff9b::7f00:1", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.ip, func(t *testing.T) {
+			assert.Equal(t, tt.public, isPublic(net.ParseIP(tt.ip)))
+		})
+	}
+}
+
+func TestFetchOnlyFollowsHTTPRedirects(t *testing.T) {
+	srv := newServer(t, func(w http.ResponseWriter, r *http.Request) {
+		http.Redirect(w, r, "gopher://example.com/", http.StatusFound)
+	})
+
+	_, err := localFetcher(Options{}).Fetch(context.Background(), srv.URL)
+	assert.ErrorIs(t, err, ErrBlocked)
+}
+
+func TestFetchRejectsNonHTML(t *testing.T) {
+	srv := newServer(t, func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/octet-stream")
+		w.Write([]byte("binary"))
+	})
+
+	_, err := localFetcher(Options{}).Fetch(context.Background(), srv.URL)
+	assert.ErrorIs(t, err, ErrNotHTML)
+}
+
+func TestFetchReadsAtMostMaxBytes(t *testing.T) {
+	srv := newServer(t, func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "text/html")
+		w.Write([]byte("<html><head>" + strings.Repeat("<!-- padding -->", 1000)))
+		w.Write([]byte(`<meta property="og:title" content="too late"></head></html>`))
+	})
+
+	preview, err := localFetcher(Options{MaxBytes: 1024}).Fetch(context.Background(), srv.URL)
+	require.NoError(t, err)
+	assert.Empty(t, preview.Title)
+	assert.True(t, preview.Empty())
+}
+
+func TestFetchTimesOut(t *testing.T) {
+	srv := newServer(t, func(w http.ResponseWriter, r *http.Request) {
+		select {
+		case <-time.After(time.Second):
+		case <-r.Context().Done():
+		}
+	})
+
+	_, err := localFetcher(Options{Timeout: 50 * time.Millisecond}).Fetch(context.Background(), srv.URL)
+	assert.Error(t, err)
+}
@@ -1,17 +1,26 @@
 package utils
 
 import (
+	"context"
+	"errors"
 	"os"
 	"time"
 
 	jwtware "github.com/gofiber/contrib/jwt"
 	"github.com/gofiber/fiber/v2"
 	"github.com/golang-jwt/jwt/v5"
+	"github.com/maulana1k/forum-app/internal/domain/errs"
 )
 
 var jwtSecret = []byte(os.Getenv("JWT_SECRET"))
 
-func Protected() fiber.Handler {
+// AccountCheck returns an error for a user whose tokens must no longer be
+// accepted, such as one who deactivated their account.
+type AccountCheck func(ctx context.Context, userID string) error
+
+// Protected requires a valid token whose user passes check. A nil check
+// accepts every user.
+func Protected(check AccountCheck) fiber.Handler {
 	return jwtware.New(jwtware.Config{
 		SigningKey:   jwtware.SigningKey{Key: jwtSecret},
 		ErrorHandler: jwtError,
@@ -21,29 +30,54 @@ func Protected() fiber.Handler {
 
 			uidRaw, ok := claims["user_id"]
 			if !ok {
-				return c.Status(fiber.StatusUnauthorized).JSON(fiber.Map{"error": "user_id missing"})
+				return errs.ErrInvalidToken
 			}
 
 			uid, ok := uidRaw.(string)
 			if !ok {
-				return c.Status(fiber.StatusUnauthorized).JSON(fiber.Map{"error": "user_id is not a string"})
+				return errs.ErrInvalidToken
+			}
+
+			if check != nil {
+				if err := check(c.UserContext(), uid); err != nil {
+					return err
+				}
 			}
 
 			c.Locals("userID", uid)
+			SetRequestLogger(c, RequestLogger(c).WithField("user_id", uid))
+
 			return c.Next()
 		},
 	})
 }
 
-func jwtError(c *fiber.Ctx, err error) error {
-	if err.Error() == "Missing or malformed JWT" {
-		c.Status(fiber.StatusBadRequest)
-		return c.JSON(fiber.Map{"status": "error", "message": "Missing or malformed JWT", "data": nil})
+// OptionalAuth authenticates requests that carry a token and lets
+// anonymous ones through, so public endpoints can tailor responses to a
+// signed-in viewer. A bad token is still rejected.
+func OptionalAuth(check AccountCheck) fiber.Handler {
+	protected := Protected(check)
+	return func(c *fiber.Ctx) error {
+		if c.Get(fiber.HeaderAuthorization) == "" {
+			return c.Next()
+		}
+		return protected(c)
+	}
+}
 
-	} else {
-		c.Status(fiber.StatusUnauthorized)
-		return c.JSON(fiber.Map{"status": "error", "message": "Invalid or expired JWT", "data": nil})
+// ViewerID returns the authenticated user ID, or "" for anonymous requests.
+func ViewerID(c *fiber.Ctx) string {
+	id, _ := c.Locals("userID").(string)
+	return id
+}
+
+// jwtError hands token failures to the app error handler so they are
+// rendered like every other problem response.
+func jwtError(c *fiber.Ctx, err error) error {
+	if errors.Is(err, jwtware.ErrJWTMissingOrMalformed) {
+		return errs.ErrMissingToken.Wrap(err)
 	}
+	return errs.ErrInvalidToken.Wrap(err)
 }
 
 func GenerateJWT(userID string) (string, error) {
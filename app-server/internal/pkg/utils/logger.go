@@ -1,27 +1,89 @@
 package utils
 
 import (
+	"context"
 	"os"
+	"time"
 
 	nested "github.com/antonfisher/nested-logrus-formatter"
+	"github.com/gofiber/fiber/v2"
 	"github.com/sirupsen/logrus"
+	"go.opentelemetry.io/otel/trace"
 )
 
-var Logger *logrus.Logger
+// Logger is the process-wide structured logger. It is usable before
+// InitLogger is called (e.g. while loading config) with logrus defaults.
+var Logger = logrus.New()
 
-func InitLogger() {
-	Logger = logrus.New()
+type loggerKey struct{}
 
-	Logger.SetFormatter(&nested.Formatter{
-		TimestampFormat: "Jan 02 15:04:05.000",
-		HideKeys:        true,
-		FieldsOrder:     []string{"component", "method", "path", "request_id"},
-	})
+// localsLogger is the fiber.Ctx locals key holding the request logger.
+const localsLogger = "logger"
+
+// InitLogger configures the global logger. format "json" emits one JSON
+// object per line for production log shippers; anything else uses the
+// human readable nested formatter.
+func InitLogger(format, level string) {
+	if format == "json" {
+		Logger.SetFormatter(&logrus.JSONFormatter{
+			TimestampFormat: time.RFC3339Nano,
+			FieldMap: logrus.FieldMap{
+				logrus.FieldKeyMsg: "message",
+			},
+		})
+	} else {
+		Logger.SetFormatter(&nested.Formatter{
+			TimestampFormat: "Jan 02 15:04:05.000",
+			HideKeys:        true,
+			FieldsOrder:     []string{"component", "request_id", "trace_id", "user_id", "method", "path"},
+		})
+	}
+
+	lvl, err := logrus.ParseLevel(level)
+	if err != nil {
+		lvl = logrus.InfoLevel
+	}
 
 	Logger.SetOutput(os.Stdout)
-	Logger.SetLevel(logrus.InfoLevel)
+	Logger.SetLevel(lvl)
 }
 
 func GetLogger() *logrus.Logger {
 	return Logger
 }
+
+// ContextWithLogger returns a copy of ctx carrying entry.
+func ContextWithLogger(ctx context.Context, entry *logrus.Entry) context.Context {
+	return context.WithValue(ctx, loggerKey{}, entry)
+}
+
+// LoggerFromContext returns the logger carried by ctx, or the global logger
+// when there is none. The trace and span IDs of the active span are always
+// attached so log lines can be joined with traces.
+func LoggerFromContext(ctx context.Context) *logrus.Entry {
+	entry, ok := ctx.Value(loggerKey{}).(*logrus.Entry)
+	if !ok {
+		entry = logrus.NewEntry(Logger)
+	}
+
+	if sc := trace.SpanContextFromContext(ctx); sc.IsValid() {
+		entry = entry.WithFields(logrus.Fields{
+			"trace_id": sc.TraceID().String(),
+			"span_id":  sc.SpanID().String(),
+		})
+	}
+
+	return entry.WithContext(ctx)
+}
+
+// RequestLogger returns the logger of the current request.
+func RequestLogger(c *fiber.Ctx) *logrus.Entry {
+	return LoggerFromContext(c.UserContext())
+}
+
+// SetRequestLogger stores entry on both the fiber.Ctx locals and the user
+// context so that handlers, services and repositories share it.
+func SetRequestLogger(c *fiber.Ctx, entry *logrus.Entry) {
+	c.Locals(localsLogger, entry)
+	c.SetUserContext(ContextWithLogger(c.UserContext(), entry))
+}
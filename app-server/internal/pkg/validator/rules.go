@@ -0,0 +1,71 @@
+package validator
+
+import (
+	"regexp"
+	"unicode"
+
+	playground "github.com/go-playground/validator/v10"
+	"github.com/google/uuid"
+)
+
+const minPasswordLength = 8
+
+var usernamePattern = regexp.MustCompile(`^[a-zA-Z0-9_]{3,30}$`)
+
+var slugPattern = regexp.MustCompile(`^[a-z0-9]+(?:-[a-z0-9]+)*$`)
+
+var rules = map[string]playground.Func{
+	"username":   validateUsername,
+	"password":   validatePassword,
+	"uuid_param": validateUUIDParam,
+	"slug":       validateSlug,
+}
+
+func registerRules(v *playground.Validate) error {
+	for tag, fn := range rules {
+		if err := v.RegisterValidation(tag, fn); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// validateUsername allows 3-30 letters, digits and underscores.
+func validateUsername(fl playground.FieldLevel) bool {
+	return usernamePattern.MatchString(fl.Field().String())
+}
+
+// validateSlug allows lower case letters and digits in words joined by
+// single hyphens, as used in community URLs.
+func validateSlug(fl playground.FieldLevel) bool {
+	return slugPattern.MatchString(fl.Field().String())
+}
+
+// validatePassword requires at least 8 characters with an upper case
+// letter, a lower case letter and a digit.
+func validatePassword(fl playground.FieldLevel) bool {
+	password := fl.Field().String()
+	if len(password) < minPasswordLength {
+		return false
+	}
+
+	var upper, lower, digit bool
+	for _, r := range password {
+		switch {
+		case unicode.IsUpper(r):
+			upper = true
+		case unicode.IsLower(r):
+			lower = true
+		case unicode.IsDigit(r):
+			digit = true
+		}
+	}
+	return upper && lower && digit
+}
+
+// validateUUIDParam accepts any form uuid.Parse understands, which is what
+// the repositories use to convert path params.
+func validateUUIDParam(fl playground.FieldLevel) bool {
+	_, err := uuid.Parse(fl.Field().String())
+	return err == nil
+}
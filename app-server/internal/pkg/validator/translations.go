@@ -0,0 +1,62 @@
+package validator
+
+import (
+	ut "github.com/go-playground/universal-translator"
+	playground "github.com/go-playground/validator/v10"
+	en_translations "github.com/go-playground/validator/v10/translations/en"
+	id_translations "github.com/go-playground/validator/v10/translations/id"
+)
+
+// Messages for our custom rules; the built-in rules come with the
+// validator's own translations.
+var customMessages = map[string]map[string]string{
+	"en": {
+		"username":   "{0} must be 3-30 characters of letters, digits or underscores",
+		"password":   "{0} must be at least 8 characters and contain upper case, lower case and a digit",
+		"uuid_param": "{0} must be a valid UUID",
+		"slug":       "{0} must be lower case letters and digits, optionally separated by single hyphens",
+	},
+	"id": {
+		"username":   "{0} harus terdiri dari 3-30 karakter huruf, angka, atau garis bawah",
+		"password":   "{0} minimal 8 karakter dan harus mengandung huruf besar, huruf kecil, dan angka",
+		"uuid_param": "{0} harus berupa UUID yang valid",
+		"slug":       "{0} harus berupa huruf kecil dan angka, boleh dipisahkan satu tanda hubung",
+	},
+}
+
+func registerTranslations(v *playground.Validate, uni *ut.UniversalTranslator) error {
+	enTrans, _ := uni.GetTranslator("en")
+	if err := en_translations.RegisterDefaultTranslations(v, enTrans); err != nil {
+		return err
+	}
+	idTrans, _ := uni.GetTranslator("id")
+	if err := id_translations.RegisterDefaultTranslations(v, idTrans); err != nil {
+		return err
+	}
+
+	for locale, messages := range customMessages {
+		trans, _ := uni.GetTranslator(locale)
+		for tag, message := range messages {
+			if err := v.RegisterTranslation(tag, trans, register(tag, message), translate(tag)); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+func register(tag, message string) playground.RegisterTranslationsFunc {
+	return func(trans ut.Translator) error {
+		return trans.Add(tag, message, true)
+	}
+}
+
+func translate(tag string) playground.TranslationFunc {
+	return func(trans ut.Translator, fe playground.FieldError) string {
+		msg, err := trans.T(tag, fe.Field())
+		if err != nil {
+			return fe.Error()
+		}
+		return msg
+	}
+}
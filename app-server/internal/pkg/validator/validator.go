@@ -0,0 +1,128 @@
+package validator
+
+import (
+	"errors"
+	"reflect"
+	"strings"
+
+	"github.com/go-playground/locales/en"
+	"github.com/go-playground/locales/id"
+	ut "github.com/go-playground/universal-translator"
+	playground "github.com/go-playground/validator/v10"
+	"github.com/gofiber/fiber/v2"
+	"github.com/maulana1k/forum-app/internal/domain/errs"
+	"github.com/maulana1k/forum-app/internal/pkg/nullable"
+)
+
+// DefaultLocale is used when the client sends no Accept-Language header or
+// asks for a language we have no messages for.
+const DefaultLocale = "en"
+
+var (
+	validate = playground.New(playground.WithRequiredStructEnabled())
+	uni      = ut.New(en.New(), en.New(), id.New())
+	locales  = []string{"en", "id"}
+)
+
+func init() {
+	// Report fields by the name the client sent, not the Go field name.
+	validate.RegisterTagNameFunc(fieldName)
+	// Validate the value of nullable fields; missing and null ones count
+	// as empty, so pair their rules with omitempty.
+	validate.RegisterCustomTypeFunc(nullableValue, nullable.Field[string]{})
+
+	if err := registerRules(validate); err != nil {
+		panic(err)
+	}
+	if err := registerTranslations(validate, uni); err != nil {
+		panic(err)
+	}
+}
+
+// Struct validates s and returns a validation *errs.Error whose field
+// messages are in the given locale.
+func Struct(s any, locale string) error {
+	err := validate.Struct(s)
+	if err == nil {
+		return nil
+	}
+
+	var verrs playground.ValidationErrors
+	if !errors.As(err, &verrs) {
+		return err
+	}
+
+	trans := translator(locale)
+	fields := make([]errs.FieldError, len(verrs))
+	for i, fe := range verrs {
+		fields[i] = errs.FieldError{
+			Field:   fe.Field(),
+			Code:    fe.Tag(),
+			Message: fe.Translate(trans),
+		}
+	}
+	return errs.Validation("validation_failed", "request validation failed", fields...)
+}
+
+// ParseAndValidateBody decodes the request body into T and validates it.
+func ParseAndValidateBody[T any](c *fiber.Ctx) (*T, error) {
+	var v T
+	if err := c.BodyParser(&v); err != nil {
+		return nil, errs.ErrInvalidBody.Wrap(err)
+	}
+	return &v, Struct(&v, Locale(c))
+}
+
+// ParseAndValidateQuery decodes the query string into T and validates it.
+func ParseAndValidateQuery[T any](c *fiber.Ctx) (*T, error) {
+	var v T
+	if err := c.QueryParser(&v); err != nil {
+		return nil, errs.ErrInvalidQuery.Wrap(err)
+	}
+	return &v, Struct(&v, Locale(c))
+}
+
+// ParseAndValidateParams decodes the route params into T and validates it.
+func ParseAndValidateParams[T any](c *fiber.Ctx) (*T, error) {
+	var v T
+	if err := c.ParamsParser(&v); err != nil {
+		return nil, errs.ErrInvalidID.Wrap(err)
+	}
+	return &v, Struct(&v, Locale(c))
+}
+
+// Locale picks the best supported language from Accept-Language.
+func Locale(c *fiber.Ctx) string {
+	if locale := c.AcceptsLanguages(locales...); locale != "" {
+		return locale
+	}
+	return DefaultLocale
+}
+
+func translator(locale string) ut.Translator {
+	if trans, ok := uni.GetTranslator(locale); ok {
+		return trans
+	}
+	trans, _ := uni.GetTranslator(DefaultLocale)
+	return trans
+}
+
+func nullableValue(v reflect.Value) any {
+	if f, ok := v.Interface().(nullable.Field[string]); ok && f.IsSet() {
+		return f.Value
+	}
+	return nil
+}
+
+func fieldName(f reflect.StructField) string {
+	for _, tag := range []string{"json", "query", "params"} {
+		name, _, _ := strings.Cut(f.Tag.Get(tag), ",")
+		if name == "-" {
+			return ""
+		}
+		if name != "" {
+			return name
+		}
+	}
+	return f.Name
+}
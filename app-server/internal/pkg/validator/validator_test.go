@@ -0,0 +1,121 @@
+package validator
+
+import (
+	"testing"
+
+	"github.com/maulana1k/forum-app/internal/domain/errs"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type usernameInput struct {
+	Username string `json:"username" validate:"username"`
+}
+
+type passwordInput struct {
+	Password string `json:"password" validate:"password"`
+}
+
+type uuidParamInput struct {
+	ID string `params:"id" validate:"uuid_param"`
+}
+
+// fieldErrors validates s and returns the field errors it reports.
+func fieldErrors(t *testing.T, s any, locale string) []errs.FieldError {
+	t.Helper()
+
+	err := Struct(s, locale)
+	if err == nil {
+		return nil
+	}
+	e, ok := errs.As(err)
+	require.True(t, ok, "error %v is not an *errs.Error", err)
+	return e.Fields
+}
+
+func TestRules(t *testing.T) {
+	tests := []struct {
+		name  string
+		input any
+		valid bool
+	}{
+		{"username letters digits underscore", &usernameInput{"john_doe42"}, true},
+		{"username shortest", &usernameInput{"abc"}, true},
+		{"username longest", &usernameInput{"a23456789012345678901234567890"}, true},
+		{"username too short", &usernameInput{"ab"}, false},
+		{"username too long", &usernameInput{"a234567890123456789012345678901"}, false},
+		{"username with space", &usernameInput{"john doe"}, false},
+		{"username with hyphen", &usernameInput{"john-doe"}, false},
+		{"username non ascii", &usernameInput{"jöhn"}, false},
+		{"username empty", &usernameInput{""}, false},
+
+		{"password strong", &passwordInput{"Passw0rd"}, true},
+		{"password non ascii letters", &passwordInput{"Ünïcode1x"}, true},
+		{"password too short", &passwordInput{"Pass0rd"}, false},
+		{"password without upper case", &passwordInput{"passw0rd"}, false},
+		{"password without lower case", &passwordInput{"PASSW0RD"}, false},
+		{"password without digit", &passwordInput{"Password"}, false},
+		{"password empty", &passwordInput{""}, false},
+
+		{"uuid canonical", &uuidParamInput{"6ba7b810-9dad-11d1-80b4-00c04fd430c8"}, true},
+		{"uuid upper case", &uuidParamInput{"6BA7B810-9DAD-11D1-80B4-00C04FD430C8"}, true},
+		{"uuid urn", &uuidParamInput{"urn:uuid:6ba7b810-9dad-11d1-80b4-00c04fd430c8"}, true},
+		{"uuid braces", &uuidParamInput{"{6ba7b810-9dad-11d1-80b4-00c04fd430c8}"}, true},
+		{"uuid truncated", &uuidParamInput{"6ba7b810-9dad-11d1-80b4"}, false},
+		{"uuid not hex", &uuidParamInput{"zba7b810-9dad-11d1-80b4-00c04fd430c8"}, false},
+		{"uuid empty", &uuidParamInput{""}, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fields := fieldErrors(t, tt.input, DefaultLocale)
+			if tt.valid {
+				assert.Empty(t, fields)
+			} else {
+				assert.Len(t, fields, 1)
+			}
+		})
+	}
+}
+
+func TestTranslations(t *testing.T) {
+	tests := []struct {
+		name    string
+		input   any
+		locale  string
+		field   string
+		code    string
+		message string
+	}{
+		{"username en", &usernameInput{"x"}, "en", "username", "username",
+			"username must be 3-30 characters of letters, digits or underscores"},
+		{"username id", &usernameInput{"x"}, "id", "username", "username",
+			"username harus terdiri dari 3-30 karakter huruf, angka, atau garis bawah"},
+		{"password en", &passwordInput{"weak"}, "en", "password", "password",
+			"password must be at least 8 characters and contain upper case, lower case and a digit"},
+		{"password id", &passwordInput{"weak"}, "id", "password", "password",
+			"password minimal 8 karakter dan harus mengandung huruf besar, huruf kecil, dan angka"},
+		{"uuid_param en", &uuidParamInput{"nope"}, "en", "id", "uuid_param",
+			"id must be a valid UUID"},
+		{"uuid_param id", &uuidParamInput{"nope"}, "id", "id", "uuid_param",
+			"id harus berupa UUID yang valid"},
+		{"unsupported locale falls back to en", &uuidParamInput{"nope"}, "fr", "id", "uuid_param",
+			"id must be a valid UUID"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fields := fieldErrors(t, tt.input, tt.locale)
+			require.Len(t, fields, 1)
+			assert.Equal(t, errs.FieldError{Field: tt.field, Code: tt.code, Message: tt.message}, fields[0])
+		})
+	}
+}
+
+func TestStructReportsValidationError(t *testing.T) {
+	err := Struct(&passwordInput{"weak"}, DefaultLocale)
+
+	e, ok := errs.As(err)
+	require.True(t, ok)
+	assert.Equal(t, "validation_failed", e.Code)
+}
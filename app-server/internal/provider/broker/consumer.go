@@ -1 +1,97 @@
 package broker
+
+import (
+	"context"
+
+	"github.com/maulana1k/forum-app/internal/pkg/utils"
+	"github.com/maulana1k/forum-app/internal/provider/monitoring"
+	"github.com/maulana1k/forum-app/internal/provider/tracing"
+	"github.com/rabbitmq/amqp091-go"
+	"github.com/sirupsen/logrus"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// Handler processes a single delivery. ctx carries the span continued from
+// the trace context found in the message headers.
+type Handler func(ctx context.Context, msg amqp091.Delivery) error
+
+type Consumer struct {
+	rabbit *RabbitMQ
+	queue  string
+}
+
+func NewConsumer(r *RabbitMQ, queue string) *Consumer {
+	if _, err := r.DeclareQueue(queue); err != nil {
+		panic(err)
+	}
+
+	return &Consumer{rabbit: r, queue: queue}
+}
+
+// Consume delivers every message on the queue to handler until the channel
+// is closed. Messages are acked when handler succeeds and dropped (nack
+// without requeue) when it fails, matching the FastAPI consumers.
+func (c *Consumer) Consume(handler Handler) error {
+	deliveries, err := c.rabbit.Channel.Consume(
+		c.queue,
+		"",    // consumer tag
+		false, // autoAck
+		false, // exclusive
+		false, // noLocal
+		false, // noWait
+		nil,
+	)
+	if err != nil {
+		return err
+	}
+
+	go func() {
+		for msg := range deliveries {
+			err := c.handle(handler, msg)
+			monitoring.ObserveConsume(c.queue, err)
+
+			if err != nil {
+				utils.Logger.WithError(err).WithField("queue", c.queue).Error("failed to handle message")
+				_ = msg.Nack(false, false)
+				continue
+			}
+			_ = msg.Ack(false)
+		}
+		utils.Logger.WithField("queue", c.queue).Warn("consumer stopped")
+	}()
+
+	return nil
+}
+
+func (c *Consumer) handle(handler Handler, msg amqp091.Delivery) error {
+	if msg.Headers == nil {
+		msg.Headers = amqp091.Table{}
+	}
+	ctx := otel.GetTextMapPropagator().Extract(context.Background(), headerCarrier(msg.Headers))
+
+	ctx, span := tracing.Tracer().Start(ctx, c.queue+" process",
+		trace.WithSpanKind(trace.SpanKindConsumer),
+		trace.WithAttributes(
+			attribute.String("messaging.system", "rabbitmq"),
+			attribute.String("messaging.destination.name", c.queue),
+			attribute.String("messaging.operation.type", "process"),
+		),
+	)
+	defer span.End()
+
+	ctx = utils.ContextWithLogger(ctx, utils.Logger.WithFields(logrus.Fields{
+		"component":  "consumer",
+		"queue":      c.queue,
+		"message_id": msg.MessageId,
+	}))
+
+	err := handler(ctx, msg)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return err
+}
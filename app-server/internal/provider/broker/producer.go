@@ -4,7 +4,13 @@ import (
 	"context"
 	"time"
 
+	"github.com/maulana1k/forum-app/internal/provider/monitoring"
+	"github.com/maulana1k/forum-app/internal/provider/tracing"
 	"github.com/rabbitmq/amqp091-go"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
 )
 
 type Producer struct {
@@ -12,7 +18,13 @@ type Producer struct {
 	queue  string
 }
 
+// NewProducer declares queue and returns a producer for it. A nil r gives a
+// producer that drops every message, so tests can run without RabbitMQ.
 func NewProducer(r *RabbitMQ, queue string) *Producer {
+	if r == nil {
+		return &Producer{queue: queue}
+	}
+
 	_, err := r.Channel.QueueDeclare(
 		queue,
 		true,  // durable
@@ -28,19 +40,47 @@ func NewProducer(r *RabbitMQ, queue string) *Producer {
 	return &Producer{rabbit: r, queue: queue}
 }
 
-func (p *Producer) Publish(message []byte) error {
-	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+// Publish sends message to the queue. The span context in ctx is injected
+// into the message headers so consumers can continue the trace.
+func (p *Producer) Publish(ctx context.Context, message []byte) error {
+	if p.rabbit == nil {
+		return nil
+	}
+
+	ctx, span := tracing.Tracer().Start(ctx, p.queue+" publish",
+		trace.WithSpanKind(trace.SpanKindProducer),
+		trace.WithAttributes(
+			attribute.String("messaging.system", "rabbitmq"),
+			attribute.String("messaging.destination.name", p.queue),
+			attribute.String("messaging.operation.type", "send"),
+		),
+	)
+	defer span.End()
+
+	headers := amqp091.Table{}
+	otel.GetTextMapPropagator().Inject(ctx, headerCarrier(headers))
+
+	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
 	defer cancel()
 
-	return p.rabbit.Channel.PublishWithContext(ctx,
+	err := p.rabbit.Channel.PublishWithContext(ctx,
 		"",      // exchange
 		p.queue, // routing key
 		false,   // mandatory
 		false,   // immediate
 		amqp091.Publishing{
 			ContentType: "application/json",
+			Headers:     headers,
 			Body:        message,
 		})
+	monitoring.ObservePublish(p.queue, err)
+
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+
+	return err
 }
 
 // func (p *Producer) ExtendChannel(subqueue string) *Producer {
@@ -0,0 +1,14 @@
+package broker
+
+import (
+	"context"
+	"testing"
+)
+
+func TestNilBrokerProducerDropsMessages(t *testing.T) {
+	producer := NewProducer(nil, "post-create")
+
+	if err := producer.Publish(context.Background(), []byte(`{}`)); err != nil {
+		t.Fatalf("Publish() = %v, want nil", err)
+	}
+}
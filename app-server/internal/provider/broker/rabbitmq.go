@@ -1,9 +1,10 @@
 package broker
 
 import (
-	"log"
 	"time"
 
+	"github.com/maulana1k/forum-app/internal/pkg/utils"
+
 	"github.com/rabbitmq/amqp091-go"
 )
 
@@ -26,16 +27,16 @@ func (r *RabbitMQ) connect() {
 		if err == nil {
 			break
 		}
-		log.Printf("Failed to connect to RabbitMQ (attempt %d): %v", i+1, err)
+		utils.Logger.WithError(err).WithField("attempt", i+1).Warn("failed to connect to RabbitMQ")
 		time.Sleep(2 * time.Second)
 	}
 	if err != nil {
-		log.Fatalf("Cannot connect to RabbitMQ: %v", err)
+		utils.Logger.WithError(err).Fatal("cannot connect to RabbitMQ")
 	}
 
 	r.Channel, err = r.Conn.Channel()
 	if err != nil {
-		log.Fatalf("Failed to open channel: %v", err)
+		utils.Logger.WithError(err).Fatal("failed to open channel")
 	}
 }
 
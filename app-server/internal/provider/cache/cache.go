@@ -0,0 +1,100 @@
+package cache
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"time"
+
+	"github.com/maulana1k/forum-app/internal/pkg/utils"
+	"github.com/maulana1k/forum-app/internal/provider/monitoring"
+	"golang.org/x/sync/singleflight"
+)
+
+// ErrMiss is returned by Get when the key is absent or expired.
+var ErrMiss = errors.New("cache miss")
+
+// Cache is a byte oriented key/value store with per key expiry.
+type Cache interface {
+	Get(ctx context.Context, key string) ([]byte, error)
+	Set(ctx context.Context, key string, value []byte, ttl time.Duration) error
+	Delete(ctx context.Context, keys ...string) error
+	Close() error
+}
+
+// Loader is a read-through cache for one kind of value. Concurrent misses
+// on the same key share a single load, so an expired hot key doesn't
+// stampede the database.
+type Loader[T any] struct {
+	cache  Cache
+	name   string
+	ttl    time.Duration
+	flight singleflight.Group
+}
+
+func NewLoader[T any](c Cache, name string, ttl time.Duration) *Loader[T] {
+	return &Loader[T]{cache: c, name: name, ttl: ttl}
+}
+
+// Key namespaces id under the loader name, e.g. "post:<id>".
+func (l *Loader[T]) Key(id string) string {
+	return l.name + ":" + id
+}
+
+// Get returns the cached value for id or calls load and caches its result.
+// Cache failures are logged and fall through to load; they never fail the
+// request.
+func (l *Loader[T]) Get(ctx context.Context, id string, load func(context.Context) (T, error)) (T, error) {
+	key := l.Key(id)
+
+	if raw, err := l.cache.Get(ctx, key); err == nil {
+		var v T
+		if err := json.Unmarshal(raw, &v); err == nil {
+			monitoring.ObserveCache(l.name, true)
+			return v, nil
+		}
+	} else if !errors.Is(err, ErrMiss) {
+		utils.LoggerFromContext(ctx).WithError(err).WithField("key", key).Warn("cache get failed")
+	}
+	monitoring.ObserveCache(l.name, false)
+
+	v, err, _ := l.flight.Do(key, func() (any, error) {
+		// The load is shared by every waiter, so one caller going away
+		// must not cancel it for the rest.
+		v, err := load(context.WithoutCancel(ctx))
+		if err != nil {
+			return v, err
+		}
+		if raw, err := json.Marshal(v); err == nil {
+			if err := l.cache.Set(ctx, key, raw, l.ttl); err != nil {
+				utils.LoggerFromContext(ctx).WithError(err).WithField("key", key).Warn("cache set failed")
+			}
+		}
+		return v, nil
+	})
+	if err != nil {
+		var zero T
+		return zero, err
+	}
+	return v.(T), nil
+}
+
+// Invalidate drops the cached values for ids.
+func (l *Loader[T]) Invalidate(ctx context.Context, ids ...string) {
+	keys := make([]string, len(ids))
+	for i, id := range ids {
+		keys[i] = l.Key(id)
+	}
+	if err := l.cache.Delete(ctx, keys...); err != nil {
+		utils.LoggerFromContext(ctx).WithError(err).WithField("keys", keys).Warn("cache invalidation failed")
+	}
+}
+
+// New builds the Cache selected by driver: "redis" or, by default, the
+// in-memory store.
+func New(driver, redisAddr, redisPassword string) Cache {
+	if driver == "redis" {
+		return NewRedis(redisAddr, redisPassword)
+	}
+	return NewMemory()
+}
@@ -0,0 +1,95 @@
+package cache
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type item struct {
+	ID    string
+	Likes int
+}
+
+func TestLoaderReadsThroughAndInvalidates(t *testing.T) {
+	ctx := context.Background()
+	loader := NewLoader[*item](NewMemory(), "item", time.Minute)
+
+	var loads int
+	load := func(context.Context) (*item, error) {
+		loads++
+		return &item{ID: "a", Likes: loads}, nil
+	}
+
+	first, err := loader.Get(ctx, "a", load)
+	require.NoError(t, err)
+	second, err := loader.Get(ctx, "a", load)
+	require.NoError(t, err)
+	assert.Equal(t, 1, loads)
+	assert.Equal(t, first, second)
+
+	loader.Invalidate(ctx, "a")
+	third, err := loader.Get(ctx, "a", load)
+	require.NoError(t, err)
+	assert.Equal(t, 2, loads)
+	assert.Equal(t, 2, third.Likes)
+}
+
+func TestLoaderDoesNotCacheErrors(t *testing.T) {
+	ctx := context.Background()
+	loader := NewLoader[*item](NewMemory(), "item", time.Minute)
+	notFound := errors.New("not found")
+
+	_, err := loader.Get(ctx, "a", func(context.Context) (*item, error) { return nil, notFound })
+	assert.ErrorIs(t, err, notFound)
+
+	v, err := loader.Get(ctx, "a", func(context.Context) (*item, error) { return &item{ID: "a"}, nil })
+	require.NoError(t, err)
+	assert.Equal(t, "a", v.ID)
+}
+
+func TestLoaderCollapsesConcurrentMisses(t *testing.T) {
+	ctx := context.Background()
+	loader := NewLoader[*item](NewMemory(), "item", time.Minute)
+
+	var loads atomic.Int32
+	release := make(chan struct{})
+	load := func(context.Context) (*item, error) {
+		loads.Add(1)
+		<-release
+		return &item{ID: "a"}, nil
+	}
+
+	var wg sync.WaitGroup
+	for range 10 {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			_, err := loader.Get(ctx, "a", load)
+			assert.NoError(t, err)
+		}()
+	}
+
+	time.Sleep(50 * time.Millisecond)
+	close(release)
+	wg.Wait()
+
+	assert.Equal(t, int32(1), loads.Load())
+}
+
+func TestMemoryExpiry(t *testing.T) {
+	ctx := context.Background()
+	m := NewMemory()
+
+	require.NoError(t, m.Set(ctx, "k", []byte("v"), time.Millisecond))
+	time.Sleep(5 * time.Millisecond)
+
+	_, err := m.Get(ctx, "k")
+	assert.ErrorIs(t, err, ErrMiss)
+}
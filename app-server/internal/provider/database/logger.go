@@ -0,0 +1,77 @@
+package database
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/maulana1k/forum-app/internal/pkg/utils"
+	"github.com/sirupsen/logrus"
+	"gorm.io/gorm"
+	"gorm.io/gorm/logger"
+)
+
+// slowQueryThreshold marks queries that are logged as warnings.
+const slowQueryThreshold = 200 * time.Millisecond
+
+// gormLogger routes GORM logs through the request logger found in the
+// statement context, so SQL errors and slow queries carry the request,
+// user and trace IDs.
+type gormLogger struct {
+	level logger.LogLevel
+}
+
+func newGormLogger() logger.Interface {
+	return &gormLogger{level: logger.Warn}
+}
+
+func (l *gormLogger) LogMode(level logger.LogLevel) logger.Interface {
+	return &gormLogger{level: level}
+}
+
+func (l *gormLogger) Info(ctx context.Context, msg string, args ...any) {
+	if l.level >= logger.Info {
+		utils.LoggerFromContext(ctx).Infof(msg, args...)
+	}
+}
+
+func (l *gormLogger) Warn(ctx context.Context, msg string, args ...any) {
+	if l.level >= logger.Warn {
+		utils.LoggerFromContext(ctx).Warnf(msg, args...)
+	}
+}
+
+func (l *gormLogger) Error(ctx context.Context, msg string, args ...any) {
+	if l.level >= logger.Error {
+		utils.LoggerFromContext(ctx).Errorf(msg, args...)
+	}
+}
+
+func (l *gormLogger) Trace(ctx context.Context, begin time.Time, fc func() (string, int64), err error) {
+	if l.level <= logger.Silent {
+		return
+	}
+
+	elapsed := time.Since(begin)
+
+	switch {
+	case err != nil && !errors.Is(err, gorm.ErrRecordNotFound) && l.level >= logger.Error:
+		sql, rows := fc()
+		l.entry(ctx, sql, rows, elapsed).WithError(err).Error("query failed")
+	case elapsed > slowQueryThreshold && l.level >= logger.Warn:
+		sql, rows := fc()
+		l.entry(ctx, sql, rows, elapsed).Warn("slow query")
+	case l.level >= logger.Info:
+		sql, rows := fc()
+		l.entry(ctx, sql, rows, elapsed).Debug("query")
+	}
+}
+
+func (l *gormLogger) entry(ctx context.Context, sql string, rows int64, elapsed time.Duration) *logrus.Entry {
+	return utils.LoggerFromContext(ctx).WithFields(logrus.Fields{
+		"component": "database",
+		"sql":       sql,
+		"rows":      rows,
+		"elapsed":   elapsed.Round(time.Microsecond).String(),
+	})
+}
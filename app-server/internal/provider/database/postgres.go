@@ -2,13 +2,16 @@ package database
 
 import (
 	"errors"
-	"log"
 	"sync"
 	"time"
 
+	"github.com/maulana1k/forum-app/internal/pkg/utils"
+
 	"github.com/maulana1k/forum-app/internal/domain/models"
+	"github.com/maulana1k/forum-app/internal/provider/monitoring"
 	"gorm.io/driver/postgres"
 	"gorm.io/gorm"
+	otelgorm "gorm.io/plugin/opentelemetry/tracing"
 )
 
 type DB struct {
@@ -70,39 +73,130 @@ func (db *DB) Connect() *gorm.DB {
 	db.StartTime = time.Now()
 
 	var err error
-	db.DB, err = gorm.Open(postgres.Open(db.DSN), &gorm.Config{})
+	db.DB, err = gorm.Open(postgres.Open(db.DSN), &gorm.Config{Logger: newGormLogger()})
 	if err != nil {
-		log.Fatalf("Failed to connect to database: %v", err)
+		utils.Logger.WithError(err).Fatal("failed to connect to database")
+	}
+
+	utils.Logger.Info("connected to PostgreSQL successfully with GORM")
+
+	if err := db.DB.Use(monitoring.GormPlugin{}); err != nil {
+		utils.Logger.WithError(err).Fatal("failed to register metrics plugin")
+	}
+	if err := db.DB.Use(otelgorm.NewPlugin(otelgorm.WithoutMetrics(), otelgorm.WithoutQueryVariables())); err != nil {
+		utils.Logger.WithError(err).Fatal("failed to register tracing plugin")
 	}
 
-	log.Println("Connected to PostgreSQL successfully with GORM")
+	// post_embeddings needs pgvector, e.g. the pgvector/pgvector image.
+	// Without it the table is left out and related posts are turned off.
+	vector := true
+	if err := db.DB.Exec("CREATE EXTENSION IF NOT EXISTS vector").Error; err != nil {
+		utils.Logger.WithError(err).Warn("pgvector is unavailable, related posts are disabled")
+		vector = false
+	}
 
 	// Auto migrate all models
 	tableMigration := []any{
 		&models.User{},
+		&models.UserSettings{},
+		&models.UsernameReservation{},
+		&models.EmailChange{},
+		&models.DataExport{},
+		&models.Media{},
+		&models.Community{},
+		&models.CommunityMember{},
+		&models.UserRelation{},
+		&models.MutedWord{},
+		&models.LinkPreview{},
 		&models.Post{},
 		&models.Replies{},
 		&models.PostInteractions{},
+		&models.PostReaction{},
+		&models.PostReactionCount{},
+		&models.Poll{},
+		&models.PollOption{},
+		&models.PollVote{},
+		&models.PostRevision{},
+		&models.RecommendationImpression{},
+		&models.ModerationResult{},
+	}
+	if vector {
+		tableMigration = append(tableMigration, &models.PostEmbedding{})
 	}
 
 	if err := db.DB.AutoMigrate(tableMigration...); err != nil {
-		log.Fatalf("Failed to migrate models: %v", err)
+		utils.Logger.WithError(err).Fatal("failed to migrate models")
+	}
+
+	// GORM cannot declare an index with an access method and operator
+	// class, so the ANN index for related posts is created here. pgvector
+	// before 0.5 has no HNSW; similarity search then scans instead.
+	if vector {
+		if err := db.DB.Exec(`CREATE INDEX IF NOT EXISTS idx_post_embeddings_hnsw
+			ON post_embeddings USING hnsw (embedding vector_cosine_ops)`).Error; err != nil {
+			utils.Logger.WithError(err).Warn("failed to create post embedding index")
+		}
+	}
+
+	if err := migrateLikesToReactions(db.DB); err != nil {
+		utils.Logger.WithError(err).Fatal("failed to migrate likes to reactions")
 	}
 
 	return db.DB
 }
 
+// migrateLikesToReactions moves legacy LIKE interactions into
+// post_reactions. Migrated rows are soft deleted, so once none are left it
+// only checks that and writes nothing.
+func migrateLikesToReactions(db *gorm.DB) error {
+	var pending bool
+	if err := db.Raw(`SELECT EXISTS (SELECT 1 FROM post_interactions
+		WHERE interaction_type IN (?, ?) AND deleted_at IS NULL)`, models.LIKE, models.DISLIKE).
+		Scan(&pending).Error; err != nil {
+		return err
+	}
+	if !pending {
+		return nil
+	}
+
+	return db.Transaction(func(tx *gorm.DB) error {
+		res := tx.Exec(`
+			INSERT INTO post_reactions (post_id, user_id, reaction, created_at, updated_at)
+			SELECT DISTINCT ON (post_id, user_id) post_id, user_id, ?, created_at, updated_at
+			FROM post_interactions
+			WHERE interaction_type = ? AND deleted_at IS NULL
+			ORDER BY post_id, user_id, created_at
+			ON CONFLICT DO NOTHING`, models.ReactionLike, models.LIKE)
+		if res.Error != nil {
+			return res.Error
+		}
+		if res.RowsAffected > 0 {
+			utils.Logger.WithField("rows", res.RowsAffected).Info("migrated likes to reactions")
+
+			if err := tx.Exec(`
+				INSERT INTO post_reaction_counts (post_id, reaction, count)
+				SELECT post_id, reaction, COUNT(*) FROM post_reactions GROUP BY post_id, reaction
+				ON CONFLICT (post_id, reaction) DO UPDATE SET count = EXCLUDED.count`).Error; err != nil {
+				return err
+			}
+		}
+
+		return tx.Exec(`UPDATE post_interactions SET deleted_at = NOW()
+			WHERE interaction_type IN (?, ?) AND deleted_at IS NULL`, models.LIKE, models.DISLIKE).Error
+	})
+}
+
 func (db *DB) Close() {
 	if db.DB != nil {
 		sqlDB, err := db.DB.DB()
 		if err != nil {
-			log.Printf("Error getting underlying sql.DB: %v", err)
+			utils.Logger.WithError(err).Error("error getting underlying sql.DB")
 			return
 		}
 		if err := sqlDB.Close(); err != nil {
-			log.Printf("Error closing database: %v", err)
+			utils.Logger.WithError(err).Error("error closing database")
 		} else {
-			log.Println("Database connection closed")
+			utils.Logger.Info("database connection closed")
 		}
 	}
 }
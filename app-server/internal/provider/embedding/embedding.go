@@ -0,0 +1,48 @@
+// Package embedding turns post text into vectors for similarity search.
+// Vectors have Dimensions components and unit length, so the cosine
+// similarity of two of them is their dot product.
+package embedding
+
+import (
+	"context"
+	"math"
+	"time"
+
+	pb "github.com/maulana1k/forum-app/gen/embedding"
+	"google.golang.org/grpc"
+)
+
+// Dimensions is the length of every vector. It matches the FastAPI
+// service's model and the post_embeddings column, so changing it needs a
+// migration.
+const Dimensions = 384
+
+// Embedder embeds a batch of texts. vectors[i] is the vector of texts[i];
+// model names what produced them.
+type Embedder interface {
+	Embed(ctx context.Context, texts []string) (vectors [][]float32, model string, err error)
+}
+
+// New returns the embedder for driver: "hash" for the local hashing
+// embedder, anything else for the FastAPI service reached through conn.
+func New(driver string, conn grpc.ClientConnInterface, timeout time.Duration) Embedder {
+	if driver == "hash" {
+		return NewHashing()
+	}
+	return NewRemote(pb.NewEmbeddingServiceClient(conn), timeout)
+}
+
+// normalize scales v to unit length in place. A zero vector is left as is.
+func normalize(v []float32) {
+	var sum float64
+	for _, x := range v {
+		sum += float64(x) * float64(x)
+	}
+	if sum == 0 {
+		return
+	}
+	scale := float32(1 / math.Sqrt(sum))
+	for i := range v {
+		v[i] *= scale
+	}
+}
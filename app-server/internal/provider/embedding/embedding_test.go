@@ -0,0 +1,76 @@
+package embedding
+
+import (
+	"context"
+	"math"
+	"testing"
+
+	pb "github.com/maulana1k/forum-app/gen/embedding"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+)
+
+func dot(a, b []float32) float64 {
+	var sum float64
+	for i := range a {
+		sum += float64(a[i]) * float64(b[i])
+	}
+	return sum
+}
+
+func TestHashingIsDeterministicAndNormalised(t *testing.T) {
+	texts := []string{"Go generics in practice", "go GENERICS, in practice!", ""}
+	first, model, err := NewHashing().Embed(context.Background(), texts)
+	require.NoError(t, err)
+	again, _, err := NewHashing().Embed(context.Background(), texts)
+	require.NoError(t, err)
+
+	assert.Equal(t, HashingModel, model)
+	assert.Equal(t, first, again)
+	for _, v := range first {
+		require.Len(t, v, Dimensions)
+		assert.InDelta(t, 1, math.Sqrt(dot(v, v)), 1e-5)
+	}
+	// Case and punctuation do not matter.
+	assert.InDelta(t, 1, dot(first[0], first[1]), 1e-5)
+}
+
+func TestHashingRanksSharedWordsHigher(t *testing.T) {
+	vectors, _, err := NewHashing().Embed(context.Background(), []string{
+		"tuning postgres indexes for slow queries",
+		"which postgres indexes speed up slow queries",
+		"best hiking trails around the lake",
+	})
+	require.NoError(t, err)
+
+	assert.Greater(t, dot(vectors[0], vectors[1]), dot(vectors[0], vectors[2]))
+}
+
+type fakeClient struct {
+	resp *pb.EmbedResponse
+}
+
+func (c fakeClient) Embed(context.Context, *pb.EmbedRequest, ...grpc.CallOption) (*pb.EmbedResponse, error) {
+	return c.resp, nil
+}
+
+func TestRemoteChecksResponse(t *testing.T) {
+	values := make([]float32, Dimensions)
+	values[0] = 3
+	values[1] = 4
+
+	r := NewRemote(fakeClient{&pb.EmbedResponse{Model: "m", Embeddings: []*pb.Embedding{{Values: values}}}}, 0)
+	vectors, model, err := r.Embed(context.Background(), []string{"a"})
+	require.NoError(t, err)
+	assert.Equal(t, "m", model)
+	assert.InDelta(t, 0.6, vectors[0][0], 1e-6)
+	assert.InDelta(t, 0.8, vectors[0][1], 1e-6)
+
+	_, _, err = r.Embed(context.Background(), []string{"a", "b"})
+	assert.Error(t, err)
+
+	short := NewRemote(fakeClient{&pb.EmbedResponse{Embeddings: []*pb.Embedding{{Values: []float32{1}}}}}, 0)
+	_, _, err = short.Embed(context.Background(), []string{"a"})
+	assert.Error(t, err)
+}
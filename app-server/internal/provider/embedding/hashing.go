@@ -0,0 +1,64 @@
+package embedding
+
+import (
+	"context"
+	"hash/fnv"
+	"strings"
+	"unicode"
+)
+
+// HashingModel is the model name of vectors made by Hashing.
+const HashingModel = "hashing-v1"
+
+// Hashing is a deterministic embedder that needs no model: words and
+// adjacent word pairs are hashed into signed buckets. Texts sharing words
+// end up close, which is enough for tests and local development but
+// knows nothing of meaning.
+type Hashing struct{}
+
+func NewHashing() *Hashing {
+	return &Hashing{}
+}
+
+func (*Hashing) Embed(_ context.Context, texts []string) ([][]float32, string, error) {
+	vectors := make([][]float32, len(texts))
+	for i, text := range texts {
+		vectors[i] = hashText(text)
+	}
+	return vectors, HashingModel, nil
+}
+
+func hashText(text string) []float32 {
+	v := make([]float32, Dimensions)
+	words := strings.FieldsFunc(strings.ToLower(text), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsNumber(r)
+	})
+	// A text without words still needs a direction.
+	if len(words) == 0 {
+		words = []string{""}
+	}
+
+	for i, word := range words {
+		addFeature(v, word)
+		if i > 0 {
+			addFeature(v, words[i-1]+" "+word)
+		}
+	}
+	normalize(v)
+	return v
+}
+
+// addFeature adds ±1 to the bucket of feature. The sign comes from another
+// bit of the hash, so unrelated features cancel out rather than pile up.
+func addFeature(v []float32, feature string) {
+	h := fnv.New64a()
+	h.Write([]byte(feature))
+	sum := h.Sum64()
+
+	bucket := sum % Dimensions
+	if sum>>63 == 1 {
+		v[bucket]--
+	} else {
+		v[bucket]++
+	}
+}
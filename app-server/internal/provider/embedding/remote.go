@@ -0,0 +1,48 @@
+package embedding
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	pb "github.com/maulana1k/forum-app/gen/embedding"
+)
+
+// Remote embeds texts with the FastAPI service's model over gRPC.
+type Remote struct {
+	client  pb.EmbeddingServiceClient
+	timeout time.Duration
+}
+
+// NewRemote builds a Remote whose calls give up after timeout; zero means
+// 10 seconds.
+func NewRemote(client pb.EmbeddingServiceClient, timeout time.Duration) *Remote {
+	if timeout <= 0 {
+		timeout = 10 * time.Second
+	}
+	return &Remote{client: client, timeout: timeout}
+}
+
+func (r *Remote) Embed(ctx context.Context, texts []string) ([][]float32, string, error) {
+	ctx, cancel := context.WithTimeout(ctx, r.timeout)
+	defer cancel()
+
+	resp, err := r.client.Embed(ctx, &pb.EmbedRequest{Texts: texts})
+	if err != nil {
+		return nil, "", err
+	}
+	if len(resp.GetEmbeddings()) != len(texts) {
+		return nil, "", fmt.Errorf("embedding: got %d vectors for %d texts", len(resp.GetEmbeddings()), len(texts))
+	}
+
+	vectors := make([][]float32, len(texts))
+	for i, e := range resp.GetEmbeddings() {
+		if len(e.GetValues()) != Dimensions {
+			return nil, "", fmt.Errorf("embedding: model %q returned %d dimensions, want %d", resp.GetModel(), len(e.GetValues()), Dimensions)
+		}
+		// Normalise anyway, so a model that does not cannot skew scores.
+		vectors[i] = e.GetValues()
+		normalize(vectors[i])
+	}
+	return vectors, resp.GetModel(), nil
+}
@@ -0,0 +1,206 @@
+// Package engagement streams what users do with posts to the ML pipeline.
+// Events are batched in memory and written to every configured sink; the
+// request that raised them never waits on the sinks.
+//
+// Events are pseudonymous: user IDs are replaced by a keyed hash that is
+// stable for a given secret, so training jobs can group a user's events
+// without being able to tell who they are. Content, IPs and devices are
+// never recorded.
+package engagement
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"sync"
+	"time"
+
+	"github.com/maulana1k/forum-app/internal/pkg/utils"
+	"github.com/maulana1k/forum-app/internal/provider/monitoring"
+)
+
+// Kind is the type of an engagement event.
+type Kind string
+
+const (
+	// KindView is a post shown on screen.
+	KindView Kind = "view"
+	// KindDwell reports how long a post stayed on screen.
+	KindDwell Kind = "dwell"
+	// KindReaction is a reaction added to a post or switched.
+	KindReaction Kind = "reaction"
+	// KindRecommendationClick is a recommended post the user opened.
+	KindRecommendationClick Kind = "recommendation_click"
+)
+
+// Event is one record of the stream. Its JSON form is what the sinks
+// write, one object per line.
+type Event struct {
+	Kind Kind `json:"kind"`
+	// User is the pseudonym of the user; see Recorder.Record.
+	User   string `json:"user"`
+	PostID string `json:"post_id,omitempty"`
+	// Surface is where the post was shown, e.g. "feed".
+	Surface          string    `json:"surface,omitempty"`
+	DwellMs          int64     `json:"dwell_ms,omitempty"`
+	Reaction         string    `json:"reaction,omitempty"`
+	RecommendationID string    `json:"recommendation_id,omitempty"`
+	OccurredAt       time.Time `json:"occurred_at"`
+}
+
+// Sink stores a batch of events.
+type Sink interface {
+	Name() string
+	Write(ctx context.Context, batch []Event) error
+}
+
+// Options tunes a Recorder.
+type Options struct {
+	// Secret keys user pseudonyms. Without one a per-process key is used
+	// and pseudonyms change on every restart.
+	Secret string
+	// BatchSize events are written together; a partial batch is written
+	// after FlushInterval.
+	BatchSize     int
+	FlushInterval time.Duration
+	// BufferSize events may wait to be written. Events beyond it are
+	// dropped rather than slowing requests down.
+	BufferSize int
+}
+
+// Recorder batches events and writes them to its sinks in the
+// background. A nil Recorder drops events, so services can be built
+// without one.
+type Recorder struct {
+	sinks  []Sink
+	opts   Options
+	secret []byte
+	events chan Event
+	done   chan struct{}
+
+	mu     sync.RWMutex
+	closed bool
+}
+
+func NewRecorder(opts Options, sinks ...Sink) *Recorder {
+	if opts.BatchSize <= 0 {
+		opts.BatchSize = 200
+	}
+	if opts.FlushInterval <= 0 {
+		opts.FlushInterval = 10 * time.Second
+	}
+	if opts.BufferSize < opts.BatchSize {
+		opts.BufferSize = 10 * opts.BatchSize
+	}
+
+	secret := []byte(opts.Secret)
+	if len(secret) == 0 {
+		utils.Logger.Warn("ENGAGEMENT_SECRET is not set, engagement pseudonyms will change on restart")
+		secret = make([]byte, 32)
+		_, _ = rand.Read(secret)
+	}
+
+	r := &Recorder{
+		sinks:  sinks,
+		opts:   opts,
+		secret: secret,
+		events: make(chan Event, opts.BufferSize),
+		done:   make(chan struct{}),
+	}
+	go r.run()
+	return r
+}
+
+// Record queues event on behalf of userID, replacing the ID by its
+// pseudonym. OccurredAt defaults to now.
+func (r *Recorder) Record(userID string, event Event) {
+	if r == nil {
+		return
+	}
+
+	event.User = r.Pseudonym(userID)
+	if event.OccurredAt.IsZero() {
+		event.OccurredAt = time.Now()
+	}
+	event.OccurredAt = event.OccurredAt.UTC().Truncate(time.Millisecond)
+
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	if r.closed {
+		return
+	}
+	select {
+	case r.events <- event:
+	default:
+		monitoring.ObserveEngagementDropped(1)
+	}
+}
+
+// Pseudonym returns the stable stand-in for userID in the stream.
+func (r *Recorder) Pseudonym(userID string) string {
+	mac := hmac.New(sha256.New, r.secret)
+	mac.Write([]byte(userID))
+	return hex.EncodeToString(mac.Sum(nil)[:16])
+}
+
+// Close writes the events still queued and stops the recorder. Events
+// recorded after Close are dropped.
+func (r *Recorder) Close() {
+	if r == nil {
+		return
+	}
+	r.mu.Lock()
+	if !r.closed {
+		r.closed = true
+		close(r.events)
+	}
+	r.mu.Unlock()
+	<-r.done
+}
+
+func (r *Recorder) run() {
+	defer close(r.done)
+
+	ticker := time.NewTicker(r.opts.FlushInterval)
+	defer ticker.Stop()
+
+	batch := make([]Event, 0, r.opts.BatchSize)
+	for {
+		select {
+		case event, ok := <-r.events:
+			if !ok {
+				r.flush(batch)
+				return
+			}
+			batch = append(batch, event)
+			if len(batch) >= r.opts.BatchSize {
+				r.flush(batch)
+				batch = batch[:0]
+			}
+		case <-ticker.C:
+			r.flush(batch)
+			batch = batch[:0]
+		}
+	}
+}
+
+// flush hands batch to every sink. A failed sink loses the batch; the
+// stream is for training, where a gap is better than stalled requests.
+func (r *Recorder) flush(batch []Event) {
+	if len(batch) == 0 {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	for _, sink := range r.sinks {
+		err := sink.Write(ctx, batch)
+		monitoring.ObserveEngagementBatch(sink.Name(), len(batch), err)
+		if err != nil {
+			utils.Logger.WithError(err).WithField("sink", sink.Name()).WithField("events", len(batch)).Error("failed to write engagement events")
+		}
+	}
+}
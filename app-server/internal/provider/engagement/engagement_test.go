@@ -0,0 +1,131 @@
+package engagement
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type memorySink struct {
+	mu      sync.Mutex
+	batches [][]Event
+}
+
+func (s *memorySink) Name() string { return "memory" }
+
+func (s *memorySink) Write(_ context.Context, batch []Event) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.batches = append(s.batches, append([]Event(nil), batch...))
+	return nil
+}
+
+func (s *memorySink) sizes() []int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	var sizes []int
+	for _, b := range s.batches {
+		sizes = append(sizes, len(b))
+	}
+	return sizes
+}
+
+func TestRecorderBatches(t *testing.T) {
+	sink := &memorySink{}
+	r := NewRecorder(Options{Secret: "s", BatchSize: 2, FlushInterval: time.Hour}, sink)
+
+	for i := 0; i < 5; i++ {
+		r.Record("user-1", Event{Kind: KindView, PostID: "p"})
+	}
+	r.Close()
+
+	// Two full batches, then the rest on Close.
+	assert.Equal(t, []int{2, 2, 1}, sink.sizes())
+
+	// Events after Close are dropped without panicking.
+	r.Record("user-1", Event{Kind: KindView})
+}
+
+func TestRecorderFlushesOnInterval(t *testing.T) {
+	sink := &memorySink{}
+	r := NewRecorder(Options{Secret: "s", BatchSize: 100, FlushInterval: 10 * time.Millisecond}, sink)
+	defer r.Close()
+
+	r.Record("user-1", Event{Kind: KindView})
+	assert.Eventually(t, func() bool { return len(sink.sizes()) == 1 }, time.Second, 5*time.Millisecond)
+}
+
+func TestRecorderPseudonymisesUsers(t *testing.T) {
+	sink := &memorySink{}
+	r := NewRecorder(Options{Secret: "s", BatchSize: 10}, sink)
+	r.Record("user-1", Event{Kind: KindView})
+	r.Record("user-1", Event{Kind: KindDwell, DwellMs: 1200})
+	r.Record("user-2", Event{Kind: KindView})
+	r.Close()
+
+	events := sink.batches[0]
+	assert.NotContains(t, events[0].User, "user-1")
+	assert.Len(t, events[0].User, 32)
+	assert.Equal(t, events[0].User, events[1].User)
+	assert.NotEqual(t, events[0].User, events[2].User)
+	assert.False(t, events[0].OccurredAt.IsZero())
+
+	// Another secret gives unrelated pseudonyms.
+	other := NewRecorder(Options{Secret: "t"})
+	defer other.Close()
+	assert.NotEqual(t, r.Pseudonym("user-1"), other.Pseudonym("user-1"))
+}
+
+func TestNilRecorder(t *testing.T) {
+	var r *Recorder
+	r.Record("user-1", Event{Kind: KindView})
+	r.Close()
+}
+
+func TestFileSinkAppendsPerDay(t *testing.T) {
+	dir := t.TempDir()
+	sink, err := NewFileSink(filepath.Join(dir, "events"))
+	require.NoError(t, err)
+
+	day1 := time.Date(2024, 5, 1, 23, 59, 0, 0, time.UTC)
+	day2 := day1.Add(time.Minute)
+	require.NoError(t, sink.Write(context.Background(), []Event{
+		{Kind: KindView, User: "u", PostID: "a", OccurredAt: day1},
+		{Kind: KindView, User: "u", PostID: "b", OccurredAt: day2},
+	}))
+	require.NoError(t, sink.Write(context.Background(), []Event{
+		{Kind: KindReaction, User: "u", PostID: "c", Reaction: "like", OccurredAt: day1},
+	}))
+
+	read := func(day string) []Event {
+		f, err := os.Open(sink.path(day))
+		require.NoError(t, err)
+		defer f.Close()
+
+		var events []Event
+		scanner := bufio.NewScanner(f)
+		for scanner.Scan() {
+			var e Event
+			require.NoError(t, json.Unmarshal(scanner.Bytes(), &e))
+			events = append(events, e)
+		}
+		return events
+	}
+
+	first := read("2024-05-01")
+	require.Len(t, first, 2)
+	assert.Equal(t, "a", first[0].PostID)
+	assert.Equal(t, "like", first[1].Reaction)
+
+	second := read("2024-05-02")
+	require.Len(t, second, 1)
+	assert.Equal(t, "b", second[0].PostID)
+}
@@ -0,0 +1,112 @@
+package engagement
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/maulana1k/forum-app/internal/provider/broker"
+)
+
+// Queue carries batches of engagement events as NDJSON, one message per
+// batch.
+const Queue = "engagement-events"
+
+// encode renders batch as newline-delimited JSON.
+func encode(batch []Event) ([]byte, error) {
+	var buf bytes.Buffer
+	enc := json.NewEncoder(&buf)
+	for _, event := range batch {
+		if err := enc.Encode(event); err != nil {
+			return nil, err
+		}
+	}
+	return buf.Bytes(), nil
+}
+
+// BrokerSink publishes batches to Queue.
+type BrokerSink struct {
+	producer *broker.Producer
+}
+
+// NewBrokerSink declares Queue up front, so a broker problem shows at
+// startup rather than in the flushing goroutine.
+func NewBrokerSink(r *broker.RabbitMQ) *BrokerSink {
+	return &BrokerSink{producer: broker.NewProducer(r, Queue)}
+}
+
+func (s *BrokerSink) Name() string { return "broker" }
+
+func (s *BrokerSink) Write(ctx context.Context, batch []Event) error {
+	body, err := encode(batch)
+	if err != nil {
+		return err
+	}
+	return s.producer.Publish(ctx, body)
+}
+
+// FileSink appends events to one NDJSON file per day and host under Dir,
+// named engagement-YYYY-MM-DD.<host>.ndjson after the events' UTC date.
+// Files are only ever appended to, so ETL jobs can read any closed day
+// while the server keeps writing today's.
+type FileSink struct {
+	dir  string
+	host string
+}
+
+func NewFileSink(dir string) (*FileSink, error) {
+	if err := os.MkdirAll(dir, 0o750); err != nil {
+		return nil, err
+	}
+	// Replicas sharing the directory each write their own files.
+	host, err := os.Hostname()
+	if err != nil || host == "" {
+		host = "local"
+	}
+	return &FileSink{dir: dir, host: host}, nil
+}
+
+func (s *FileSink) Name() string { return "file" }
+
+func (s *FileSink) Write(_ context.Context, batch []Event) error {
+	byDay := make(map[string][]Event)
+	var days []string
+	for _, event := range batch {
+		day := event.OccurredAt.UTC().Format("2006-01-02")
+		if _, ok := byDay[day]; !ok {
+			days = append(days, day)
+		}
+		byDay[day] = append(byDay[day], event)
+	}
+
+	for _, day := range days {
+		if err := s.append(s.path(day), byDay[day]); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (s *FileSink) path(day string) string {
+	return filepath.Join(s.dir, fmt.Sprintf("engagement-%s.%s.ndjson", day, s.host))
+}
+
+func (s *FileSink) append(path string, events []Event) error {
+	body, err := encode(events)
+	if err != nil {
+		return err
+	}
+
+	f, err := os.OpenFile(path, os.O_WRONLY|os.O_CREATE|os.O_APPEND, 0o640)
+	if err != nil {
+		return err
+	}
+	if _, err := f.Write(body); err != nil {
+		f.Close()
+		return err
+	}
+	return f.Close()
+}
@@ -1,17 +1,23 @@
 package grpc
 
 import (
-	"log"
+	"github.com/maulana1k/forum-app/internal/pkg/utils"
 
+	"github.com/maulana1k/forum-app/internal/provider/monitoring"
+	"go.opentelemetry.io/contrib/instrumentation/google.golang.org/grpc/otelgrpc"
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/credentials/insecure"
 )
 
 func NewGRPCClient(address string) *grpc.ClientConn {
 	// conn, err := grpc.Dial(address, grpc.WithInsecure(), grpc.WithBlock(), grpc.WithTimeout(5*time.Second))
-	conn, err := grpc.NewClient(address, grpc.WithTransportCredentials(insecure.NewCredentials()))
+	conn, err := grpc.NewClient(address,
+		grpc.WithTransportCredentials(insecure.NewCredentials()),
+		grpc.WithStatsHandler(otelgrpc.NewClientHandler()),
+		grpc.WithChainUnaryInterceptor(monitoring.UnaryClientInterceptor()),
+	)
 	if err != nil {
-		log.Fatalf("failed to connect to gRPC server: %v", err)
+		utils.Logger.WithError(err).Fatal("failed to connect to gRPC server")
 	}
 	// return recommender.NewRecommenderServiceClient(conn)
 	return conn
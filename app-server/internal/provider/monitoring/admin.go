@@ -0,0 +1,31 @@
+package monitoring
+
+import (
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/gofiber/fiber/v2/middleware/adaptor"
+	"github.com/gofiber/fiber/v2/middleware/monitor"
+	"github.com/gofiber/fiber/v2/middleware/pprof"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+)
+
+// NewAdminServer builds the internal admin app that exposes Prometheus
+// metrics, the Fiber monitor page and pprof. It is meant to listen on a
+// separate port that is not published to clients.
+func NewAdminServer() *fiber.App {
+	admin := fiber.New(fiber.Config{
+		AppName:               "Forum App Admin",
+		DisableStartupMessage: true,
+	})
+
+	admin.Use(pprof.New())
+
+	admin.Get("/metrics", adaptor.HTTPHandler(promhttp.HandlerFor(Registry, promhttp.HandlerOpts{
+		Registry:          Registry,
+		EnableOpenMetrics: true,
+	})))
+	admin.Get("/monitor", monitor.New(monitor.Config{Title: "Forum App Metrics", Refresh: time.Second}))
+
+	return admin
+}
@@ -1 +1,374 @@
 package monitoring
+
+import (
+	"context"
+	"errors"
+	"strconv"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/collectors"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/status"
+	"gorm.io/gorm"
+)
+
+const namespace = "forum_app"
+
+// Registry holds every collector exposed by the admin /metrics endpoint.
+var Registry = prometheus.NewRegistry()
+
+var factory = promauto.With(Registry)
+
+// HTTP RED metrics
+var (
+	httpRequestsTotal = factory.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Subsystem: "http",
+		Name:      "requests_total",
+		Help:      "Total number of HTTP requests by route and status.",
+	}, []string{"method", "route", "status"})
+
+	httpRequestDuration = factory.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: namespace,
+		Subsystem: "http",
+		Name:      "request_duration_seconds",
+		Help:      "HTTP request latency by route.",
+		Buckets:   prometheus.DefBuckets,
+	}, []string{"method", "route", "status"})
+
+	httpRequestsInFlight = factory.NewGauge(prometheus.GaugeOpts{
+		Namespace: namespace,
+		Subsystem: "http",
+		Name:      "requests_in_flight",
+		Help:      "Number of HTTP requests currently being served.",
+	})
+)
+
+// Database metrics
+var dbQueryDuration = factory.NewHistogramVec(prometheus.HistogramOpts{
+	Namespace: namespace,
+	Subsystem: "db",
+	Name:      "query_duration_seconds",
+	Help:      "GORM query latency by operation and table.",
+	Buckets:   []float64{.001, .0025, .005, .01, .025, .05, .1, .25, .5, 1, 2.5},
+}, []string{"operation", "table", "status"})
+
+// gRPC client metrics
+var (
+	grpcClientDuration = factory.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: namespace,
+		Subsystem: "grpc_client",
+		Name:      "call_duration_seconds",
+		Help:      "Outgoing gRPC call latency by method and status code.",
+		Buckets:   prometheus.DefBuckets,
+	}, []string{"method", "code"})
+
+	grpcClientErrors = factory.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Subsystem: "grpc_client",
+		Name:      "errors_total",
+		Help:      "Outgoing gRPC calls that returned a non-OK status.",
+	}, []string{"method", "code"})
+)
+
+// gRPC server metrics
+var (
+	grpcServerHandled = factory.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Subsystem: "grpc_server",
+		Name:      "handled_total",
+		Help:      "Incoming gRPC calls by method and status code.",
+	}, []string{"method", "code"})
+
+	grpcServerDuration = factory.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: namespace,
+		Subsystem: "grpc_server",
+		Name:      "handling_seconds",
+		Help:      "Incoming gRPC call latency by method and status code; streams are timed until they end.",
+		Buckets:   prometheus.DefBuckets,
+	}, []string{"method", "code"})
+)
+
+// RabbitMQ metrics
+var (
+	brokerPublished = factory.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Subsystem: "broker",
+		Name:      "messages_published_total",
+		Help:      "Messages published to RabbitMQ by queue and result.",
+	}, []string{"queue", "result"})
+
+	brokerConsumed = factory.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Subsystem: "broker",
+		Name:      "messages_consumed_total",
+		Help:      "Messages consumed from RabbitMQ by queue and result.",
+	}, []string{"queue", "result"})
+)
+
+// Engagement stream metrics
+var (
+	engagementEvents = factory.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Subsystem: "engagement",
+		Name:      "events_written_total",
+		Help:      "Engagement events written by sink and result.",
+	}, []string{"sink", "result"})
+
+	engagementDropped = factory.NewCounter(prometheus.CounterOpts{
+		Namespace: namespace,
+		Subsystem: "engagement",
+		Name:      "events_dropped_total",
+		Help:      "Engagement events dropped because the buffer was full.",
+	})
+)
+
+// Cache metrics
+var cacheRequests = factory.NewCounterVec(prometheus.CounterOpts{
+	Namespace: namespace,
+	Subsystem: "cache",
+	Name:      "requests_total",
+	Help:      "Read-through cache lookups by cache name and result.",
+}, []string{"cache", "result"})
+
+// Domain counters
+var (
+	PostsCreated = factory.NewCounter(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "posts_created_total",
+		Help:      "Number of posts created.",
+	})
+
+	PostLikes = factory.NewCounter(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "post_likes_total",
+		Help:      "Number of likes given to posts.",
+	})
+
+	UserSignups = factory.NewCounter(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "user_signups_total",
+		Help:      "Number of users that signed up.",
+	})
+
+	PostsFlagged = factory.NewCounter(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "posts_flagged_total",
+		Help:      "Number of posts flagged by moderation models.",
+	})
+
+	CountersRepaired = factory.NewCounter(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "post_counters_repaired_total",
+		Help:      "Posts whose engagement counters were repaired by the reconciler.",
+	})
+
+	RecommendationsServed = factory.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "recommendations_served_total",
+		Help:      "Recommendation pages served, by whether the recommender or the fallback ranker produced them.",
+	}, []string{"source"})
+)
+
+func init() {
+	Registry.MustRegister(
+		collectors.NewGoCollector(),
+		collectors.NewProcessCollector(collectors.ProcessCollectorOpts{}),
+	)
+}
+
+// FiberMiddleware records request count and latency per matched route.
+// The route pattern (e.g. /api/v1/posts/:id) is used instead of the raw
+// path to keep label cardinality bounded.
+func FiberMiddleware() fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		start := time.Now()
+		httpRequestsInFlight.Inc()
+		defer httpRequestsInFlight.Dec()
+
+		err := c.Next()
+
+		status := c.Response().StatusCode()
+		if err != nil {
+			if e, ok := err.(*fiber.Error); ok {
+				status = e.Code
+			} else if status < fiber.StatusBadRequest {
+				status = fiber.StatusInternalServerError
+			}
+		}
+
+		route := c.Route().Path
+		if status == fiber.StatusNotFound && route == "/" {
+			route = "unmatched"
+		}
+
+		labels := prometheus.Labels{
+			"method": c.Method(),
+			"route":  route,
+			"status": strconv.Itoa(status),
+		}
+		httpRequestsTotal.With(labels).Inc()
+		httpRequestDuration.With(labels).Observe(time.Since(start).Seconds())
+
+		return err
+	}
+}
+
+// ObservePublish records the outcome of a RabbitMQ publish.
+func ObservePublish(queue string, err error) {
+	brokerPublished.WithLabelValues(queue, result(err)).Inc()
+}
+
+// ObserveConsume records the outcome of handling a consumed RabbitMQ message.
+func ObserveConsume(queue string, err error) {
+	brokerConsumed.WithLabelValues(queue, result(err)).Inc()
+}
+
+// ObserveEngagementBatch records a batch of n events written to sink.
+func ObserveEngagementBatch(sink string, n int, err error) {
+	engagementEvents.WithLabelValues(sink, result(err)).Add(float64(n))
+}
+
+// ObserveEngagementDropped records n events lost to a full buffer.
+func ObserveEngagementDropped(n int) {
+	engagementDropped.Add(float64(n))
+}
+
+// ObserveCache records a cache hit or miss.
+func ObserveCache(name string, hit bool) {
+	res := "miss"
+	if hit {
+		res = "hit"
+	}
+	cacheRequests.WithLabelValues(name, res).Inc()
+}
+
+func result(err error) string {
+	if err != nil {
+		return "error"
+	}
+	return "ok"
+}
+
+// UnaryClientInterceptor measures latency and errors of outgoing unary gRPC calls.
+func UnaryClientInterceptor() grpc.UnaryClientInterceptor {
+	return func(ctx context.Context, method string, req, reply any, cc *grpc.ClientConn, invoker grpc.UnaryInvoker, opts ...grpc.CallOption) error {
+		start := time.Now()
+		err := invoker(ctx, method, req, reply, cc, opts...)
+
+		code := status.Code(err).String()
+		grpcClientDuration.WithLabelValues(method, code).Observe(time.Since(start).Seconds())
+		if err != nil {
+			grpcClientErrors.WithLabelValues(method, code).Inc()
+		}
+		return err
+	}
+}
+
+// UnaryServerInterceptor measures latency and status of incoming unary
+// gRPC calls.
+func UnaryServerInterceptor() grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+		start := time.Now()
+		resp, err := handler(ctx, req)
+		observeGRPCServer(info.FullMethod, start, err)
+		return resp, err
+	}
+}
+
+// StreamServerInterceptor measures latency and status of incoming
+// streaming gRPC calls.
+func StreamServerInterceptor() grpc.StreamServerInterceptor {
+	return func(srv any, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		start := time.Now()
+		err := handler(srv, ss)
+		observeGRPCServer(info.FullMethod, start, err)
+		return err
+	}
+}
+
+func observeGRPCServer(method string, start time.Time, err error) {
+	code := status.Code(err).String()
+	grpcServerHandled.WithLabelValues(method, code).Inc()
+	grpcServerDuration.WithLabelValues(method, code).Observe(time.Since(start).Seconds())
+}
+
+// GormPlugin times every GORM statement through before/after callbacks.
+type GormPlugin struct{}
+
+const gormStartKey = "monitoring:start"
+
+func (GormPlugin) Name() string {
+	return "monitoring:prometheus"
+}
+
+func (GormPlugin) Initialize(db *gorm.DB) error {
+	cb := db.Callback()
+
+	if err := cb.Create().Before("gorm:create").Register("monitoring:before_create", before); err != nil {
+		return err
+	}
+	if err := cb.Create().After("gorm:create").Register("monitoring:after_create", after("create")); err != nil {
+		return err
+	}
+	if err := cb.Query().Before("gorm:query").Register("monitoring:before_query", before); err != nil {
+		return err
+	}
+	if err := cb.Query().After("gorm:query").Register("monitoring:after_query", after("query")); err != nil {
+		return err
+	}
+	if err := cb.Update().Before("gorm:update").Register("monitoring:before_update", before); err != nil {
+		return err
+	}
+	if err := cb.Update().After("gorm:update").Register("monitoring:after_update", after("update")); err != nil {
+		return err
+	}
+	if err := cb.Delete().Before("gorm:delete").Register("monitoring:before_delete", before); err != nil {
+		return err
+	}
+	if err := cb.Delete().After("gorm:delete").Register("monitoring:after_delete", after("delete")); err != nil {
+		return err
+	}
+	if err := cb.Row().Before("gorm:row").Register("monitoring:before_row", before); err != nil {
+		return err
+	}
+	if err := cb.Row().After("gorm:row").Register("monitoring:after_row", after("row")); err != nil {
+		return err
+	}
+	if err := cb.Raw().Before("gorm:raw").Register("monitoring:before_raw", before); err != nil {
+		return err
+	}
+	return cb.Raw().After("gorm:raw").Register("monitoring:after_raw", after("raw"))
+}
+
+func before(db *gorm.DB) {
+	db.InstanceSet(gormStartKey, time.Now())
+}
+
+func after(operation string) func(*gorm.DB) {
+	return func(db *gorm.DB) {
+		v, ok := db.InstanceGet(gormStartKey)
+		if !ok {
+			return
+		}
+		start, ok := v.(time.Time)
+		if !ok {
+			return
+		}
+
+		table := db.Statement.Table
+		if table == "" {
+			table = "unknown"
+		}
+
+		status := "ok"
+		if db.Error != nil && !errors.Is(db.Error, gorm.ErrRecordNotFound) {
+			status = "error"
+		}
+
+		dbQueryDuration.WithLabelValues(operation, table, status).Observe(time.Since(start).Seconds())
+	}
+}
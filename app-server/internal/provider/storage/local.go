@@ -0,0 +1,203 @@
+package storage
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"errors"
+	"io"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/maulana1k/forum-app/internal/pkg/utils"
+)
+
+// ErrInvalidUpload is returned by VerifyUpload for a forged, malformed or
+// expired token.
+var ErrInvalidUpload = errors.New("storage: invalid upload token")
+
+// ErrInvalidDownload is the VerifyDownload counterpart of ErrInvalidUpload.
+var ErrInvalidDownload = errors.New("storage: invalid download token")
+
+// Token purposes, so an upload token cannot be used to download and the
+// other way round.
+const (
+	purposeUpload   = "put"
+	purposeDownload = "get"
+)
+
+// Local is a BlobStore on the local filesystem, for development and single
+// node deployments. Objects are served from PublicURL by the app itself.
+type Local struct {
+	dir         string
+	publicURL   string
+	uploadURL   string
+	downloadURL string
+	secret      []byte
+}
+
+// NewLocal stores objects under dir. Without a secret upload and download
+// URLs are signed with a per-process key and stop working after a restart.
+func NewLocal(dir, publicURL, uploadURL, downloadURL, secret string) (*Local, error) {
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return nil, err
+	}
+
+	key := []byte(secret)
+	if secret == "" {
+		utils.Logger.Warn("MEDIA_UPLOAD_SECRET is not set, upload URLs will not survive restarts")
+		key = make([]byte, 32)
+		if _, err := rand.Read(key); err != nil {
+			return nil, err
+		}
+	}
+
+	return &Local{
+		dir:         dir,
+		publicURL:   strings.TrimRight(publicURL, "/"),
+		uploadURL:   strings.TrimRight(uploadURL, "/"),
+		downloadURL: strings.TrimRight(downloadURL, "/"),
+		secret:      key,
+	}, nil
+}
+
+// Dir is the directory objects are written to.
+func (l *Local) Dir() string {
+	return l.dir
+}
+
+// path maps key into the store directory, refusing keys that would escape
+// it.
+func (l *Local) path(key string) (string, error) {
+	clean := filepath.Clean("/" + key)
+	if clean == "/" {
+		return "", ErrNotFound
+	}
+	return filepath.Join(l.dir, clean), nil
+}
+
+func (l *Local) Put(ctx context.Context, key string, r io.Reader, size int64, contentType string) error {
+	path, err := l.path(key)
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return err
+	}
+
+	// Write to a temp file and rename so readers never see partial objects.
+	tmp, err := os.CreateTemp(filepath.Dir(path), ".upload-*")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmp.Name())
+
+	if _, err := io.Copy(tmp, r); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		return err
+	}
+	return os.Rename(tmp.Name(), path)
+}
+
+func (l *Local) Get(ctx context.Context, key string) (io.ReadCloser, error) {
+	path, err := l.path(key)
+	if err != nil {
+		return nil, err
+	}
+	f, err := os.Open(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, ErrNotFound
+	}
+	return f, err
+}
+
+func (l *Local) Delete(ctx context.Context, key string) error {
+	path, err := l.path(key)
+	if err != nil {
+		return err
+	}
+	if err := os.Remove(path); err != nil && !errors.Is(err, os.ErrNotExist) {
+		return err
+	}
+	return nil
+}
+
+func (l *Local) URL(key string) string {
+	return l.publicURL + "/" + key
+}
+
+// PresignPut returns an upload URL on the API carrying a signed token for
+// key. The content type is not bound; uploads are sniffed afterwards.
+func (l *Local) PresignPut(ctx context.Context, key, contentType string, ttl time.Duration) (string, error) {
+	return l.uploadURL + "/" + l.token(purposeUpload, ttl, key), nil
+}
+
+// PresignGet returns a download URL on the API carrying a signed token for
+// key and filename.
+func (l *Local) PresignGet(ctx context.Context, key, filename string, ttl time.Duration) (string, error) {
+	return l.downloadURL + "/" + l.token(purposeDownload, ttl, key, filename), nil
+}
+
+func (l *Local) VerifyUpload(token string) (string, error) {
+	fields, ok := l.verify(purposeUpload, token)
+	if !ok || len(fields) != 1 {
+		return "", ErrInvalidUpload
+	}
+	return fields[0], nil
+}
+
+func (l *Local) VerifyDownload(token string) (string, string, error) {
+	fields, ok := l.verify(purposeDownload, token)
+	if !ok || len(fields) != 2 {
+		return "", "", ErrInvalidDownload
+	}
+	return fields[0], fields[1], nil
+}
+
+// token signs fields with an expiry ttl from now. Fields must not contain
+// "|".
+func (l *Local) token(purpose string, ttl time.Duration, fields ...string) string {
+	payload := strings.Join(fields, "|") + "|" + strconv.FormatInt(time.Now().Add(ttl).Unix(), 10)
+	return base64.RawURLEncoding.EncodeToString([]byte(payload)) + "." + l.sign(purpose, payload)
+}
+
+// verify returns the fields of a token signed for purpose that has not
+// expired.
+func (l *Local) verify(purpose, token string) ([]string, bool) {
+	encoded, sig, ok := strings.Cut(token, ".")
+	if !ok {
+		return nil, false
+	}
+	raw, err := base64.RawURLEncoding.DecodeString(encoded)
+	if err != nil {
+		return nil, false
+	}
+	payload := string(raw)
+	if !hmac.Equal([]byte(sig), []byte(l.sign(purpose, payload))) {
+		return nil, false
+	}
+
+	i := strings.LastIndexByte(payload, '|')
+	if i < 0 {
+		return nil, false
+	}
+	expires, err := strconv.ParseInt(payload[i+1:], 10, 64)
+	if err != nil || time.Now().Unix() > expires {
+		return nil, false
+	}
+	return strings.Split(payload[:i], "|"), true
+}
+
+func (l *Local) sign(purpose, payload string) string {
+	mac := hmac.New(sha256.New, l.secret)
+	mac.Write([]byte(purpose + "\n" + payload))
+	return base64.RawURLEncoding.EncodeToString(mac.Sum(nil))
+}
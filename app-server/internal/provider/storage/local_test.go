@@ -0,0 +1,94 @@
+package storage
+
+import (
+	"context"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newLocal(t *testing.T) *Local {
+	t.Helper()
+	l, err := NewLocal(t.TempDir(), "/media", "/api/v1/media/uploads", "/api/v1/media/downloads", "secret")
+	require.NoError(t, err)
+	return l
+}
+
+func TestLocalRoundTrip(t *testing.T) {
+	ctx := context.Background()
+	l := newLocal(t)
+
+	require.NoError(t, l.Put(ctx, "media/a.png", strings.NewReader("data"), 4, "image/png"))
+
+	rc, err := l.Get(ctx, "media/a.png")
+	require.NoError(t, err)
+	body, _ := io.ReadAll(rc)
+	rc.Close()
+	assert.Equal(t, "data", string(body))
+	assert.Equal(t, "/media/media/a.png", l.URL("media/a.png"))
+
+	require.NoError(t, l.Delete(ctx, "media/a.png"))
+	_, err = l.Get(ctx, "media/a.png")
+	assert.ErrorIs(t, err, ErrNotFound)
+}
+
+func TestLocalKeysStayInsideDir(t *testing.T) {
+	ctx := context.Background()
+	l := newLocal(t)
+
+	require.NoError(t, l.Put(ctx, "../../escape", strings.NewReader("x"), 1, "text/plain"))
+
+	_, err := os.Stat(filepath.Join(l.Dir(), "escape"))
+	assert.NoError(t, err)
+}
+
+func TestLocalPresignedUpload(t *testing.T) {
+	ctx := context.Background()
+	l := newLocal(t)
+
+	url, err := l.PresignPut(ctx, "media/b", "image/png", time.Minute)
+	require.NoError(t, err)
+	token := strings.TrimPrefix(url, "/api/v1/media/uploads/")
+
+	key, err := l.VerifyUpload(token)
+	require.NoError(t, err)
+	assert.Equal(t, "media/b", key)
+
+	_, err = l.VerifyUpload(token + "x")
+	assert.ErrorIs(t, err, ErrInvalidUpload)
+
+	expired, _ := l.PresignPut(ctx, "media/b", "image/png", -time.Minute)
+	_, err = l.VerifyUpload(strings.TrimPrefix(expired, "/api/v1/media/uploads/"))
+	assert.ErrorIs(t, err, ErrInvalidUpload)
+}
+
+func TestLocalPresignedDownload(t *testing.T) {
+	ctx := context.Background()
+	l := newLocal(t)
+
+	url, err := l.PresignGet(ctx, "private/exports/a.zip", "export.zip", time.Minute)
+	require.NoError(t, err)
+	token := strings.TrimPrefix(url, "/api/v1/media/downloads/")
+
+	key, filename, err := l.VerifyDownload(token)
+	require.NoError(t, err)
+	assert.Equal(t, "private/exports/a.zip", key)
+	assert.Equal(t, "export.zip", filename)
+
+	_, err = l.VerifyUpload(token)
+	assert.ErrorIs(t, err, ErrInvalidUpload, "download tokens cannot be used to upload")
+
+	upload, _ := l.PresignPut(ctx, "media/b", "image/png", time.Minute)
+	_, _, err = l.VerifyDownload(strings.TrimPrefix(upload, "/api/v1/media/uploads/"))
+	assert.ErrorIs(t, err, ErrInvalidDownload, "upload tokens cannot be used to download")
+
+	expired, _ := l.PresignGet(ctx, "private/exports/a.zip", "export.zip", -time.Minute)
+	_, _, err = l.VerifyDownload(strings.TrimPrefix(expired, "/api/v1/media/downloads/"))
+	assert.ErrorIs(t, err, ErrInvalidDownload)
+}
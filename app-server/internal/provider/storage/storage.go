@@ -0,0 +1,69 @@
+// Package storage holds uploaded media objects.
+package storage
+
+import (
+	"context"
+	"errors"
+	"io"
+	"time"
+)
+
+// ErrNotFound is returned when an object does not exist.
+var ErrNotFound = errors.New("storage: object not found")
+
+// PrivatePrefix marks keys that must never be served at their public URL.
+// They are only reachable through PresignGet links.
+const PrivatePrefix = "private/"
+
+// BlobStore is an object store addressed by key.
+type BlobStore interface {
+	Put(ctx context.Context, key string, r io.Reader, size int64, contentType string) error
+	Get(ctx context.Context, key string) (io.ReadCloser, error)
+	Delete(ctx context.Context, key string) error
+	// URL is where clients can download the object.
+	URL(key string) string
+	// PresignPut returns a URL the client can PUT the object body to
+	// directly, valid for ttl.
+	PresignPut(ctx context.Context, key, contentType string, ttl time.Duration) (string, error)
+	// PresignGet returns a URL the client can download the object from,
+	// as an attachment named filename, valid for ttl.
+	PresignGet(ctx context.Context, key, filename string, ttl time.Duration) (string, error)
+}
+
+// SignedUploads is implemented by stores whose presigned URLs point back at
+// the API instead of at the store itself. The API verifies the token and
+// writes the body through Put.
+type SignedUploads interface {
+	VerifyUpload(token string) (key string, err error)
+}
+
+// SignedDownloads is the download counterpart of SignedUploads.
+type SignedDownloads interface {
+	VerifyDownload(token string) (key, filename string, err error)
+}
+
+// Config selects and configures a BlobStore.
+type Config struct {
+	Driver    string
+	PublicURL string
+	// Local filesystem driver.
+	LocalDir    string
+	UploadURL   string
+	DownloadURL string
+	Secret      string
+	// S3 compatible driver (AWS S3, MinIO, ...).
+	S3Endpoint  string
+	S3AccessKey string
+	S3SecretKey string
+	S3Bucket    string
+	S3Region    string
+	S3UseSSL    bool
+}
+
+// New returns the store named by cfg.Driver, "local" by default.
+func New(cfg Config) (BlobStore, error) {
+	if cfg.Driver == "s3" {
+		return NewS3(cfg)
+	}
+	return NewLocal(cfg.LocalDir, cfg.PublicURL, cfg.UploadURL, cfg.DownloadURL, cfg.Secret)
+}
@@ -1,14 +1,20 @@
 package shared_suite
 
 import (
+	"os"
+	"path/filepath"
 	"sync"
 
 	"github.com/gofiber/fiber/v2"
 	"github.com/google/uuid"
 	"github.com/maulana1k/forum-app/internal/app/container"
+	"github.com/maulana1k/forum-app/internal/app/middleware"
 	"github.com/maulana1k/forum-app/internal/app/routes"
+	"github.com/maulana1k/forum-app/internal/config"
 	"github.com/maulana1k/forum-app/internal/domain/models"
 	"github.com/maulana1k/forum-app/internal/pkg/utils"
+	"github.com/maulana1k/forum-app/internal/provider/cache"
+	"github.com/maulana1k/forum-app/internal/provider/storage"
 	"github.com/maulana1k/forum-app/tests/helper"
 	"gorm.io/gorm"
 )
@@ -36,8 +42,12 @@ func GetSharedSuite() *SharedSuite {
 			tx := db.Begin()
 
 			// Setup Fiber app
-			app := fiber.New()
-			c := container.NewContainer(tx, nil)
+			app := fiber.New(fiber.Config{ErrorHandler: middleware.ErrorHandler})
+			blobs, err := storage.NewLocal(filepath.Join(os.TempDir(), "forum-media-test"), "/media", "/api/v1/media/uploads", "/api/v1/media/downloads", "test")
+			if err != nil {
+				panic("failed to create media store: " + err.Error())
+			}
+			c := container.NewContainer(tx, nil, nil, cache.NewMemory(), blobs, nil, &config.Configuration{})
 			routes.Register(app, c)
 
 			shared.App = app
@@ -74,6 +74,12 @@ class Settings(BaseSettings):
     TRAIN_INTERVAL_MINUTES: int = 240
     USE_CUDA: bool = False
 
+    # ------------------------
+    # ML Models Embedding
+    # ------------------------
+    # Must produce 384-dimensional vectors to fit post_embeddings.embedding.
+    EMBEDDING_MODEL: str = "sentence-transformers/all-MiniLM-L6-v2"
+
     # ------------------------
     # RabbitMQ
     # ------------------------
@@ -4,7 +4,8 @@ from concurrent import futures
 import logging
 from typing import Optional
 
-from src.grpc import recommender_pb2_grpc
+from src.grpc import embedding_pb2_grpc, recommender_pb2_grpc
+from src.services.embedding.embedding_service import EmbeddingService
 from src.services.recommendation.post_recommendation import RecommenderService
 from src.core.interceptor import LoggingInterceptor, DetailedLoggingInterceptor
 
@@ -30,6 +31,9 @@ class GRPCServer:
         recommender_pb2_grpc.add_RecommenderServiceServicer_to_server(
             RecommenderService(), self.server
         )
+        embedding_pb2_grpc.add_EmbeddingServiceServicer_to_server(
+            EmbeddingService(), self.server
+        )
 
         # Listen on port
         listen_addr = f"{self.host}:{self.port}"
@@ -41,13 +41,17 @@ class RabbitMQManager:
         return await cls._channel.declare_exchange(exchange_name, type=type, durable=durable)  # type: ignore
 
     @classmethod
-    async def publish(cls, body: dict, routing_key: str):
+    async def publish(
+        cls, body: dict, routing_key: str, headers: Optional[dict] = None
+    ):
         await cls.connect()
         queue = await cls.declare_queue(routing_key)
         exchange = await cls.declare_exchange(routing_key + "-exchange")  # type: ignore
         await queue.bind(exchange=exchange, routing_key=routing_key)
         message = Message(
-            body=json.dumps(body).encode(), delivery_mode=DeliveryMode.PERSISTENT
+            body=json.dumps(body).encode(),
+            delivery_mode=DeliveryMode.PERSISTENT,
+            headers=headers or {},
         )
         await exchange.publish(message, routing_key=routing_key)
         logger.info(f"Published message to {routing_key}: {body}")
@@ -24,7 +24,7 @@ _sym_db = _symbol_database.Default()
 
 
 
-DESCRIPTOR = _descriptor_pool.Default().AddSerializedFile(b'\n\x11recommender.proto\x12\x0brecommender\"F\n\x15RecommendationRequest\x12\x0f\n\x07user_id\x18\x01 \x01(\t\x12\r\n\x05topic\x18\x02 \x01(\t\x12\r\n\x05limit\x18\x03 \x01(\x05\"?\n\x08PostItem\x12\x0f\n\x07post_id\x18\x01 \x01(\t\x12\x0f\n\x07\x63ontent\x18\x02 \x01(\t\x12\x11\n\tauthor_id\x18\x03 \x01(\t\">\n\x16RecommendationResponse\x12$\n\x05posts\x18\x01 \x03(\x0b\x32\x15.recommender.PostItem2t\n\x12RecommenderService\x12^\n\x13GetRecommendedPosts\x12\".recommender.RecommendationRequest\x1a#.recommender.RecommendationResponseB\x0eZ\x0c/recommenderb\x06proto3')
+DESCRIPTOR = _descriptor_pool.Default().AddSerializedFile(b'\n\x11recommender.proto\x12\x0brecommender\"\x90\x01\n\x15RecommendationRequest\x12\x0f\n\x07user_id\x18\x01 \x01(\t\x12\r\n\x05topic\x18\x02 \x01(\t\x12\r\n\x05limit\x18\x03 \x01(\x05\x12\x12\n\npage_token\x18\x04 \x01(\t\x12\x18\n\x10\x65xclude_post_ids\x18\x05 \x03(\t\x12\x1a\n\x12\x65xclude_author_ids\x18\x06 \x03(\t\"x\n\x08PostItem\x12\x0f\n\x07post_id\x18\x01 \x01(\t\x12\x0f\n\x07\x63ontent\x18\x02 \x01(\t\x12\x11\n\tauthor_id\x18\x03 \x01(\t\x12\r\n\x05score\x18\x04 \x01(\x01\x12(\n\x07reasons\x18\x05 \x03(\x0e\x32\x17.recommender.ReasonCode\"W\n\x16RecommendationResponse\x12$\n\x05posts\x18\x01 \x03(\x0b\x32\x15.recommender.PostItem\x12\x17\n\x0fnext_page_token\x18\x02 \x01(\t*\xab\x01\n\nReasonCode\x12\x16\n\x12REASON_UNSPECIFIED\x10\x00\x12\x13\n\x0fREASON_TRENDING\x10\x01\x12\x16\n\x12REASON_TOPIC_MATCH\x10\x02\x12\x1a\n\x16REASON_FOLLOWED_AUTHOR\x10\x03\x12\x1b\n\x17REASON_SIMILAR_TO_LIKED\x10\x04\x12\x1f\n\x1bREASON_POPULAR_IN_COMMUNITY\x10\x05\x32t\n\x12RecommenderService\x12^\n\x13GetRecommendedPosts\x12\".recommender.RecommendationRequest\x1a#.recommender.RecommendationResponseB\x0eZ\x0c/recommenderb\x06proto3')
 
 _globals = globals()
 _builder.BuildMessageAndEnumDescriptors(DESCRIPTOR, _globals)
@@ -32,12 +32,14 @@ _builder.BuildTopDescriptorsAndMessages(DESCRIPTOR, 'recommender_pb2', _globals)
 if not _descriptor._USE_C_DESCRIPTORS:
   _globals['DESCRIPTOR']._loaded_options = None
   _globals['DESCRIPTOR']._serialized_options = b'Z\014/recommender'
-  _globals['_RECOMMENDATIONREQUEST']._serialized_start=34
-  _globals['_RECOMMENDATIONREQUEST']._serialized_end=104
-  _globals['_POSTITEM']._serialized_start=106
-  _globals['_POSTITEM']._serialized_end=169
-  _globals['_RECOMMENDATIONRESPONSE']._serialized_start=171
-  _globals['_RECOMMENDATIONRESPONSE']._serialized_end=233
-  _globals['_RECOMMENDERSERVICE']._serialized_start=235
-  _globals['_RECOMMENDERSERVICE']._serialized_end=351
+  _globals['_REASONCODE']._serialized_start=393
+  _globals['_REASONCODE']._serialized_end=564
+  _globals['_RECOMMENDATIONREQUEST']._serialized_start=35
+  _globals['_RECOMMENDATIONREQUEST']._serialized_end=179
+  _globals['_POSTITEM']._serialized_start=181
+  _globals['_POSTITEM']._serialized_end=301
+  _globals['_RECOMMENDATIONRESPONSE']._serialized_start=303
+  _globals['_RECOMMENDATIONRESPONSE']._serialized_end=390
+  _globals['_RECOMMENDERSERVICE']._serialized_start=566
+  _globals['_RECOMMENDERSERVICE']._serialized_end=682
 # @@protoc_insertion_point(module_scope)
@@ -1,4 +1,5 @@
 from google.protobuf.internal import containers as _containers
+from google.protobuf.internal import enum_type_wrapper as _enum_type_wrapper
 from google.protobuf import descriptor as _descriptor
 from google.protobuf import message as _message
 from collections.abc import Iterable as _Iterable, Mapping as _Mapping
@@ -6,28 +7,55 @@ from typing import ClassVar as _ClassVar, Optional as _Optional, Union as _Union
 
 DESCRIPTOR: _descriptor.FileDescriptor
 
+class ReasonCode(int, metaclass=_enum_type_wrapper.EnumTypeWrapper):
+    __slots__ = ()
+    REASON_UNSPECIFIED: _ClassVar[ReasonCode]
+    REASON_TRENDING: _ClassVar[ReasonCode]
+    REASON_TOPIC_MATCH: _ClassVar[ReasonCode]
+    REASON_FOLLOWED_AUTHOR: _ClassVar[ReasonCode]
+    REASON_SIMILAR_TO_LIKED: _ClassVar[ReasonCode]
+    REASON_POPULAR_IN_COMMUNITY: _ClassVar[ReasonCode]
+REASON_UNSPECIFIED: ReasonCode
+REASON_TRENDING: ReasonCode
+REASON_TOPIC_MATCH: ReasonCode
+REASON_FOLLOWED_AUTHOR: ReasonCode
+REASON_SIMILAR_TO_LIKED: ReasonCode
+REASON_POPULAR_IN_COMMUNITY: ReasonCode
+
 class RecommendationRequest(_message.Message):
-    __slots__ = ("user_id", "topic", "limit")
+    __slots__ = ("user_id", "topic", "limit", "page_token", "exclude_post_ids", "exclude_author_ids")
     USER_ID_FIELD_NUMBER: _ClassVar[int]
     TOPIC_FIELD_NUMBER: _ClassVar[int]
     LIMIT_FIELD_NUMBER: _ClassVar[int]
+    PAGE_TOKEN_FIELD_NUMBER: _ClassVar[int]
+    EXCLUDE_POST_IDS_FIELD_NUMBER: _ClassVar[int]
+    EXCLUDE_AUTHOR_IDS_FIELD_NUMBER: _ClassVar[int]
     user_id: str
     topic: str
     limit: int
-    def __init__(self, user_id: _Optional[str] = ..., topic: _Optional[str] = ..., limit: _Optional[int] = ...) -> None: ...
+    page_token: str
+    exclude_post_ids: _containers.RepeatedScalarFieldContainer[str]
+    exclude_author_ids: _containers.RepeatedScalarFieldContainer[str]
+    def __init__(self, user_id: _Optional[str] = ..., topic: _Optional[str] = ..., limit: _Optional[int] = ..., page_token: _Optional[str] = ..., exclude_post_ids: _Optional[_Iterable[str]] = ..., exclude_author_ids: _Optional[_Iterable[str]] = ...) -> None: ...
 
 class PostItem(_message.Message):
-    __slots__ = ("post_id", "content", "author_id")
+    __slots__ = ("post_id", "content", "author_id", "score", "reasons")
     POST_ID_FIELD_NUMBER: _ClassVar[int]
     CONTENT_FIELD_NUMBER: _ClassVar[int]
     AUTHOR_ID_FIELD_NUMBER: _ClassVar[int]
+    SCORE_FIELD_NUMBER: _ClassVar[int]
+    REASONS_FIELD_NUMBER: _ClassVar[int]
     post_id: str
     content: str
     author_id: str
-    def __init__(self, post_id: _Optional[str] = ..., content: _Optional[str] = ..., author_id: _Optional[str] = ...) -> None: ...
+    score: float
+    reasons: _containers.RepeatedScalarFieldContainer[ReasonCode]
+    def __init__(self, post_id: _Optional[str] = ..., content: _Optional[str] = ..., author_id: _Optional[str] = ..., score: _Optional[float] = ..., reasons: _Optional[_Iterable[_Union[ReasonCode, str]]] = ...) -> None: ...
 
 class RecommendationResponse(_message.Message):
-    __slots__ = ("posts",)
+    __slots__ = ("posts", "next_page_token")
     POSTS_FIELD_NUMBER: _ClassVar[int]
+    NEXT_PAGE_TOKEN_FIELD_NUMBER: _ClassVar[int]
     posts: _containers.RepeatedCompositeFieldContainer[PostItem]
-    def __init__(self, posts: _Optional[_Iterable[_Union[PostItem, _Mapping]]] = ...) -> None: ...
+    next_page_token: str
+    def __init__(self, posts: _Optional[_Iterable[_Union[PostItem, _Mapping]]] = ..., next_page_token: _Optional[str] = ...) -> None: ...
@@ -0,0 +1,83 @@
+import asyncio
+import grpc
+import logging
+import threading
+from typing import List
+
+import torch
+from transformers import AutoModel, AutoTokenizer
+
+from src.config.settings import settings
+from src.grpc import embedding_pb2
+from src.grpc import embedding_pb2_grpc
+
+logger = logging.getLogger(__name__)
+
+MAX_TEXTS = 64
+MAX_TOKENS = 256
+
+
+class SentenceEmbedder:
+    """Mean-pooled, L2-normalized sentence embeddings. The model is loaded
+    on first use so servers that never embed don't pay for it."""
+
+    def __init__(self, model_name: str):
+        self.model_name = model_name
+        self._tokenizer = None
+        self._model = None
+        self._lock = threading.Lock()
+
+    def _load(self):
+        with self._lock:
+            if self._model is None:
+                logger.info(f"Loading embedding model {self.model_name}")
+                self._tokenizer = AutoTokenizer.from_pretrained(self.model_name)
+                self._model = AutoModel.from_pretrained(self.model_name)
+                self._model.eval()
+
+    def embed(self, texts: List[str]) -> List[List[float]]:
+        if self._model is None:
+            self._load()
+
+        encoded = self._tokenizer(
+            texts,
+            padding=True,
+            truncation=True,
+            max_length=MAX_TOKENS,
+            return_tensors="pt",
+        )
+        with torch.no_grad():
+            output = self._model(**encoded)
+
+        mask = encoded["attention_mask"].unsqueeze(-1).float()
+        summed = (output.last_hidden_state * mask).sum(dim=1)
+        pooled = summed / mask.sum(dim=1).clamp(min=1e-9)
+        normalized = torch.nn.functional.normalize(pooled, p=2, dim=1)
+        return normalized.tolist()
+
+
+class EmbeddingService(embedding_pb2_grpc.EmbeddingServiceServicer):
+    def __init__(self, embedder: SentenceEmbedder | None = None):
+        self.embedder = embedder or SentenceEmbedder(settings.EMBEDDING_MODEL)
+
+    async def Embed(self, request, context):
+        texts = list(request.texts)
+        if len(texts) > MAX_TEXTS:
+            context.set_code(grpc.StatusCode.INVALID_ARGUMENT)
+            context.set_details(f"At most {MAX_TEXTS} texts per request")
+            return embedding_pb2.EmbedResponse()
+        if not texts:
+            return embedding_pb2.EmbedResponse(model=self.embedder.model_name)
+
+        try:
+            vectors = await asyncio.to_thread(self.embedder.embed, texts)
+        except Exception as e:
+            logger.exception("Error embedding texts")
+            context.set_code(grpc.StatusCode.INTERNAL)
+            context.set_details(f"Error embedding texts: {str(e)}")
+            return embedding_pb2.EmbedResponse()
+
+        return embedding_pb2.EmbedResponse(
+            embeddings=[embedding_pb2.Embedding(values=v) for v in vectors],
+            model=self.embedder.model_name,
+        )
@@ -1,42 +1,123 @@
 import grpc
-from concurrent import futures
 import logging
-from typing import List
+from typing import List, Tuple
 
+from sqlalchemy import bindparam, text
+
+from src.config.database import SessionLocal
 from src.grpc import recommender_pb2
 from src.grpc import recommender_pb2_grpc
 
 # from src.ml.models.predictor import MLModelManager  # Your ML model
 
+logger = logging.getLogger(__name__)
+
+DEFAULT_LIMIT = 10
+MAX_LIMIT = 100
+
+
 class RecommenderService(recommender_pb2_grpc.RecommenderServiceServicer):
     async def GetRecommendedPosts(self, request, context):
         try:
+            limit = min(request.limit or DEFAULT_LIMIT, MAX_LIMIT)
+            offset = _parse_page_token(request.page_token)
+
             # Your recommendation logic here
             # This is where you'd call your ML model
-            recommended_posts = await self._get_recommendations(
-                request.user_id, 
-                request.topic, 
-                request.limit
+            recommended_posts, has_more = await self._get_recommendations(
+                request.topic,
+                limit,
+                offset,
+                list(request.exclude_post_ids),
+                list(request.exclude_author_ids),
+            )
+
+            next_page_token = str(offset + limit) if has_more else ""
+            return recommender_pb2.RecommendationResponse(
+                posts=recommended_posts,
+                next_page_token=next_page_token,
             )
-            
-            return recommender_pb2.RecommendationResponse(posts=recommended_posts)
-            
+
+        except ValueError:
+            context.set_code(grpc.StatusCode.INVALID_ARGUMENT)
+            context.set_details("Invalid page token")
+            return recommender_pb2.RecommendationResponse()
         except Exception as e:
+            logger.exception("Error getting recommendations")
             context.set_code(grpc.StatusCode.INTERNAL)
             context.set_details(f"Error getting recommendations: {str(e)}")
             return recommender_pb2.RecommendationResponse()
 
-    async def _get_recommendations(self, user_id: str, topic: str, limit: int) -> List[recommender_pb2.PostItem]:
-        # Example implementation - replace with your actual logic
-        # This would typically call your ML model
-        
-        # For now, return mock data
+    async def _get_recommendations(
+        self,
+        topic: str,
+        limit: int,
+        offset: int,
+        exclude_post_ids: List[str],
+        exclude_author_ids: List[str],
+    ) -> Tuple[List[recommender_pb2.PostItem], bool]:
+        # Placeholder until the ML model is wired in: rank recent published
+        # posts by engagement, preferring ones tagged with the topic. The
+        # ids are real so the app server can load the posts.
+        query = text(
+            """
+            SELECT id::text AS id, content, author_id::text AS author_id,
+                   likes_count + 2 * replies_count + 3 * reposts_count AS engagement,
+                   (:topic <> '' AND tags ILIKE '%' || :topic || '%') AS topic_match
+            FROM posts
+            WHERE deleted_at IS NULL
+              AND status = 'published'
+              AND NOT (id::text IN :exclude_posts)
+              AND NOT (author_id::text IN :exclude_authors)
+            ORDER BY topic_match DESC, engagement DESC, created_at DESC, id
+            LIMIT :limit OFFSET :offset
+            """
+        ).bindparams(
+            bindparam("exclude_posts", expanding=True),
+            bindparam("exclude_authors", expanding=True),
+        )
+
+        db = SessionLocal()
+        try:
+            rows = db.execute(
+                query,
+                {
+                    "topic": topic or "",
+                    # Expanding an empty list is not portable; "" never
+                    # matches a UUID.
+                    "exclude_posts": exclude_post_ids or [""],
+                    "exclude_authors": exclude_author_ids or [""],
+                    "limit": limit + 1,
+                    "offset": offset,
+                },
+            ).all()
+        finally:
+            db.close()
+
+        has_more = len(rows) > limit
+        rows = rows[:limit]
+
         posts = []
-        for i in range(min(limit, 5)):  # Mock 5 posts max
+        for rank, row in enumerate(rows):
+            reasons = [recommender_pb2.REASON_TRENDING]
+            if row.topic_match:
+                reasons.insert(0, recommender_pb2.REASON_TOPIC_MATCH)
             posts.append(recommender_pb2.PostItem(
-                post_id=f"post_{user_id}_{i}",
-                content=f"Recommended content about {topic} for user {user_id}",
-                author_id=f"author_{i}",
+                post_id=row.id,
+                content=row.content,
+                author_id=row.author_id,
+                # Scores only need to order this list.
+                score=1.0 / (offset + rank + 1),
+                reasons=reasons,
             ))
-        
-        return posts
\ No newline at end of file
+
+        return posts, has_more
+
+
+def _parse_page_token(token: str) -> int:
+    if not token:
+        return 0
+    offset = int(token)
+    if offset < 0:
+        raise ValueError("negative page token")
+    return offset
@@ -9,6 +9,15 @@ from src.config.settings import settings
 logging.basicConfig(level=logging.DEBUG)
 logger = logging.getLogger(__name__)
 
+# W3C trace context headers set by the Go producer. They are forwarded to the
+# result message so the sentiment outcome joins the trace of post creation.
+TRACE_HEADERS = ("traceparent", "tracestate", "baggage")
+
+
+def trace_headers(message) -> dict:
+    headers = message.headers or {}
+    return {k: headers[k] for k in TRACE_HEADERS if k in headers}
+
 
 class SentimentConsumer:
     def __init__(self, model: SentimentModel):
@@ -44,6 +53,7 @@ class SentimentConsumer:
                                 "score": score,
                             },
                             routing_key=self.sentiment_post_queue,
+                            headers=trace_headers(message),
                         )
                 except Exception as e:
                     logger.exception(f"Failed to process message: {e}")
@@ -81,7 +81,7 @@ export default function (data) {
   // check(profileRes, { "profile OK": (r) => r.status === 200 });
 
   // Get recommendations
-  let recRes = http.get(`${BASE_URL}/v1/recommendation/posts?user_id=test`, {
+  let recRes = http.get(`${BASE_URL}/v1/recommendation/posts`, {
     headers: { Authorization: `Bearer ${token}` },
   });
   check(recRes, { "recommendations OK": (r) => r.status === 200 });